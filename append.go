@@ -0,0 +1,90 @@
+package main
+
+import "encoding/json"
+
+// AppendText and AppendJSON write DateTime, NullDateTime and Date straight
+// into a caller's buffer with time.AppendFormat, instead of building the
+// string and then quoting it with json.Marshal. MarshalJSON and MarshalText
+// use them, so each costs one allocation, the returned slice; an encoder
+// that keeps its own buffer gets none:
+//
+//	buf = append(buf, `{"placed_at":`...)
+//	buf = order.PlacedAt.AppendJSON(buf)
+//	buf = append(buf, '}')
+//
+// AppendText has the signature of Go 1.24's encoding.TextAppender.
+
+// dateTimeBufferSize fits DateTimeLayouts[0] with nanoseconds and quotes,
+// so the buffer of MarshalJSON does not grow.
+const dateTimeBufferSize = 48
+
+/*
+	This part implements `encoding.TextAppender`
+	type TextAppender interface {
+		AppendText(b []byte) ([]byte, error)
+	}
+*/
+func (dt DateTime) AppendText(b []byte) ([]byte, error) {
+	return dt.appendFormat(b), nil
+}
+
+// AppendJSON appends the JSON string MarshalJSON returns.
+func (dt DateTime) AppendJSON(b []byte) []byte {
+	b = append(b, '"')
+	start := len(b)
+	return closeJSONString(dt.appendFormat(b), start)
+}
+
+func (dt DateTime) appendFormat(b []byte) []byte {
+	if text, ok := dt.lazyText(); ok {
+		return append(b, text...)
+	}
+	t := dt.Time()
+	if DateTimeOutputUTC {
+		t = t.UTC()
+	}
+	return t.AppendFormat(b, dt.format())
+}
+
+func (ndt NullDateTime) AppendText(b []byte) ([]byte, error) {
+	if !ndt.Valid || ndt.DateTime.IsZero() {
+		return b, nil
+	}
+	return ndt.DateTime.AppendText(b)
+}
+
+// AppendJSON appends null when ndt is not valid.
+func (ndt NullDateTime) AppendJSON(b []byte) []byte {
+	if !ndt.Valid || ndt.DateTime.IsZero() {
+		return append(b, "null"...)
+	}
+	return ndt.DateTime.AppendJSON(b)
+}
+
+func (d Date) AppendText(b []byte) ([]byte, error) {
+	return d.appendFormat(b), nil
+}
+
+func (d Date) AppendJSON(b []byte) []byte {
+	b = append(b, '"')
+	start := len(b)
+	return closeJSONString(d.appendFormat(b), start)
+}
+
+func (d Date) appendFormat(b []byte) []byte {
+	return d.time.AppendFormat(b, dateLayout)
+}
+
+// closeJSONString ends the JSON string whose text starts at b[start], after
+// the opening quote. A layout's literal text may need escaping; then the
+// text goes through json.Marshal, so the output is byte for byte what it
+// was before.
+func closeJSONString(b []byte, start int) []byte {
+	for _, c := range b[start:] {
+		if !jsonSafe(c) {
+			quoted, _ := json.Marshal(string(b[start:]))
+			return append(b[:start-1], quoted...)
+		}
+	}
+	return append(b, '"')
+}
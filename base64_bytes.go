@@ -0,0 +1,130 @@
+package main
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrCodeBase64BytesNotString = RegisterErrorCode(ErrorCode{
+		Code: "base64.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeBase64BytesTooLarge = RegisterErrorCode(ErrorCode{
+		Code: "base64.too_large", Status: http.StatusBadRequest,
+		Message: "must not be larger than {max} bytes", Params: []string{"max"}, Example: "must not be larger than 1048576 bytes",
+	})
+	ErrCodeBase64BytesInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "base64.invalid_format", Status: http.StatusBadRequest,
+		Message: "must be valid base64", Example: "must be valid base64",
+		Kind: ErrInvalidFormat,
+	})
+)
+
+// Base64BytesMaxSize caps the decoded size of a Base64Bytes field. Zero or
+// negative disables the check.
+var Base64BytesMaxSize = 1 << 20
+
+// Base64Bytes accepts standard or URL-safe base64, padded or not, and
+// marshals as padded standard base64.
+type Base64Bytes []byte
+
+func ParseBase64Bytes(s string) (Base64Bytes, error) {
+	s = strings.TrimRight(s, "=")
+	// Checked before decoding so an oversized payload is never allocated.
+	if Base64BytesMaxSize > 0 && base64.RawStdEncoding.DecodedLen(len(s)) > Base64BytesMaxSize {
+		return nil, ErrCodeBase64BytesTooLarge.Err(map[string]string{"max": strconv.Itoa(Base64BytesMaxSize)})
+	}
+
+	encoding := base64.RawStdEncoding
+	if strings.ContainsAny(s, "-_") {
+		encoding = base64.RawURLEncoding
+	}
+	b, err := encoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrCodeBase64BytesInvalidFormat.Err(nil)
+	}
+	return Base64Bytes(b), nil
+}
+
+func (bb Base64Bytes) Bytes() []byte {
+	return []byte(bb)
+}
+
+func (bb Base64Bytes) String() string {
+	return base64.StdEncoding.EncodeToString(bb)
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (bb Base64Bytes) MarshalJSON() ([]byte, error) {
+	if bb == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(bb.String())
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (bb *Base64Bytes) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeBase64BytesNotString.Err(map[string]string{"value": string(b)})
+	}
+	parsed, err := ParseBase64Bytes(s)
+	if err != nil {
+		return err
+	}
+
+	*bb = parsed
+
+	return nil
+}
+
+/*
+	This part implements `sql.Scanner`
+	type Scanner interface {
+		Scan(src any) error
+	}
+*/
+func (bb *Base64Bytes) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*bb = nil
+	case []byte:
+		// The driver may reuse its buffer after Scan returns.
+		*bb = append(Base64Bytes{}, v...)
+	case string:
+		*bb = Base64Bytes(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Base64Bytes", src)
+	}
+	return nil
+}
+
+/*
+	This part implements `driver.Valuer`
+	type Valuer interface {
+		Value() (Value, error)
+	}
+*/
+func (bb Base64Bytes) Value() (driver.Value, error) {
+	if bb == nil {
+		return nil, nil
+	}
+	return []byte(bb), nil
+}
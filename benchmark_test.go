@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// Run with
+//
+//	go test -run '^$' -bench . -benchmem
+//
+// and compare runs with benchstat; benchmarks.txt is the last one published.
+
+// typeBenchmarks is one valid JSON value per type.
+var typeBenchmarks = []struct {
+	name  string
+	input string
+	value func() interface{}
+}{
+	{"ArrayString", `"a,b,c"`, func() interface{} { return new(ArrayString) }},
+	{"Base64Bytes", `"aGVsbG8_Pz8"`, func() interface{} { return new(Base64Bytes) }},
+	{"BasisPoints", `125`, func() interface{} { return new(BasisPoints) }},
+	{"BitString", `"10110010"`, func() interface{} { return new(BitString) }},
+	{"BoundedString", `"devi"`, func() interface{} { return new(Username) }},
+	{"BreakerConfig", `{"window":"30s"}`, func() interface{} { return new(BreakerConfig) }},
+	{"ByteSize", `"1.5MiB"`, func() interface{} { return new(ByteSize) }},
+	{"CIDR", `"10.0.0.0/8"`, func() interface{} { return new(CIDR) }},
+	{"CellRange", `"'Q1 Report'!b2:d10"`, func() interface{} { return new(CellRange) }},
+	{"Channel", `{"type":"email","address":"ops@example.com"}`, func() interface{} { return new(Channel) }},
+	{"CompositeKey", `"acme:invoice:INV-42"`, func() interface{} { return new(CompositeKey) }},
+	{"CountryCode", `"de"`, func() interface{} { return new(CountryCode) }},
+	{"CreditCardNumber", `"4111 1111 1111 1111"`, func() interface{} { return new(CreditCardNumber) }},
+	{"CronExpression", `"*/30 9-10 * * MON-FRI"`, func() interface{} { return new(CronExpression) }},
+	{"CurrencyCode", `"eur"`, func() interface{} { return new(CurrencyCode) }},
+	{"Date", `"2024-03-08"`, func() interface{} { return new(Date) }},
+	{"DateRange", `{"from":"2024-03-05","to":"2024-03-08"}`, func() interface{} { return new(DateRange) }},
+	{"DateTime", `"2020-01-01T02:02:05+07:00"`, func() interface{} { return new(DateTime) }},
+	{"DelimitedMap", `"team:payments, env:prod, tier:1"`, func() interface{} { return new(DelimitedMap) }},
+	{"Duration", `"1h30m"`, func() interface{} { return new(Duration) }},
+	{"Enum", `"active"`, func() interface{} { return new(OrderStatus) }},
+	{"FieldMapping", `[{"source":"First Name","target":"given_name","transform":["trim"]}]`, func() interface{} { return new(FieldMapping) }},
+	{"FlexibleBool", `"yes"`, func() interface{} { return new(FlexibleBool) }},
+	{"GeoPoint", `{"lat":-6.2,"lng":106.8}`, func() interface{} { return new(GeoPoint) }},
+	{"HTTPDate", `"Sun, 06 Nov 1994 08:49:37 GMT"`, func() interface{} { return new(HTTPDate) }},
+	{"HealthCheck", `{"url":"https://example.com/healthz","interval":"30s","timeout":"5s"}`, func() interface{} { return new(HealthCheck) }},
+	{"HexColor", `"#1E90FF"`, func() interface{} { return new(HexColor) }},
+	{"IPAddress", `"10.1.2.3"`, func() interface{} { return new(IPAddress) }},
+	{"IntRange", `"100-500"`, func() interface{} { return new(IntRange) }},
+	{"JSONRaw", `{"a":[1,2,{"b":null}]}`, func() interface{} { return new(JSONRaw) }},
+	{"LanguageTag", `"en-US"`, func() interface{} { return new(LanguageTag) }},
+	{"MaskedString", `"3171234567890001"`, func() interface{} { return new(MaskedString) }},
+	{"Month", `"AUG"`, func() interface{} { return new(Month) }},
+	{"NonEmptyString", `"Devi"`, func() interface{} { return new(NonEmptyString) }},
+	{"NormalizedString", `" José \t  Díaz "`, func() interface{} { return new(NormalizedString) }},
+	{"NullDateTime", `"2020-01-01T02:02:05+07:00"`, func() interface{} { return new(NullDateTime) }},
+	{"NullInt64", `42`, func() interface{} { return new(NullInt64) }},
+	{"NullString", `"Devi"`, func() interface{} { return new(NullString) }},
+	{"ObfuscatedID", `"BEAsrn5Q"`, func() interface{} { return new(ObfuscatedID) }},
+	{"Password", `"correct horse 1"`, func() interface{} { return new(Password) }},
+	{"Percentage", `"12.5%"`, func() interface{} { return new(Percentage) }},
+	{"Period", `"P1DT2H30M"`, func() interface{} { return new(Period) }},
+	{"Priority", `"high"`, func() interface{} { return new(Priority) }},
+	{"Recurrence", `"FREQ=WEEKLY;BYDAY=MO,WE"`, func() interface{} { return new(Recurrence) }},
+	{"RegexPattern", `"^INV-\\d{4}$"`, func() interface{} { return new(RegexPattern) }},
+	{"ResourceName", `"projects/acme/locations/asia-southeast2/jobs/nightly-export"`, func() interface{} { return new(ResourceName) }},
+	{"RetryPolicy", `{"max_attempts":5,"backoff":"exponential","base":"200ms","max":"30s"}`, func() interface{} { return new(RetryPolicy) }},
+	{"Semver", `"1.2.3"`, func() interface{} { return new(Semver) }},
+	{"ShortCode", `"ABCD1234A"`, func() interface{} { return new(ShortCode) }},
+	{"Slug", `"hello-world"`, func() interface{} { return new(Slug) }},
+	{"StringInt64", `"9007199254740993"`, func() interface{} { return new(StringInt64) }},
+	{"TimeRange", `{"from":"2024-03-05T11:00:00+07:00","to":"2024-03-05T12:30:00+07:00"}`, func() interface{} { return new(TimeRange) }},
+	{"Timezone", `"Asia/Jakarta"`, func() interface{} { return new(Timezone) }},
+	{"TrimmedString", `"  SKU-001\n"`, func() interface{} { return new(TrimmedString) }},
+	{"Weekday", `"Sun"`, func() interface{} { return new(Weekday) }},
+}
+
+func BenchmarkUnmarshalJSON(b *testing.B) {
+	for _, bench := range typeBenchmarks {
+		input := []byte(bench.input)
+		b.Run(bench.name, func(b *testing.B) {
+			target := bench.value()
+			if err := unmarshalBinaryJSON(target, input); err != nil {
+				b.Fatalf("%s: %v", input, err)
+			}
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = json.Unmarshal(input, target)
+			}
+		})
+	}
+}
+
+func BenchmarkMarshalJSON(b *testing.B) {
+	for _, bench := range typeBenchmarks {
+		input := []byte(bench.input)
+		b.Run(bench.name, func(b *testing.B) {
+			decoded := bench.value()
+			if err := unmarshalBinaryJSON(decoded, input); err != nil {
+				b.Fatalf("%s: %v", input, err)
+			}
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = json.Marshal(decoded)
+			}
+		})
+	}
+}
+
+func BenchmarkOmitZero(b *testing.B) {
+	event := ResponseContentEvent{Name: "standup", StartsAt: MustParseDateTime("2020-01-01T09:00:00+07:00")}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = OmitZero(event).MarshalJSON()
+	}
+}
+
+func BenchmarkRedisValueMsgpack(b *testing.B) {
+	var policy RetryPolicy
+	if err := unmarshalBinaryJSON(&policy, []byte(`{"max_attempts":5,"backoff":"exponential","base":"200ms","max":"30s"}`)); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = RedisValue{V: policy, Codec: RedisMsgpack}.MarshalBinary()
+	}
+}
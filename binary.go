@@ -0,0 +1,239 @@
+package main
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+	"unicode/utf8"
+)
+
+// The struct-based types keep their state in unexported fields, which
+// encoding/gob rejects ("type Date has no exported fields"). They implement
+// encoding.BinaryMarshaler and BinaryUnmarshaler so they can be stored in
+// gob-based caches and sent over net/rpc:
+//
+//   - DateTime writes its instant with time.Time's binary form, keeping
+//     nanoseconds and offset, plus its precision and layout.
+//   - CreditCardNumber refuses to be written, like its Valuer; cache a token
+//     or Last4 instead. Reading one back checks it like ParseCreditCardNumber.
+//   - Password refuses to be written too, so gob never sends the plaintext.
+//   - MaskedString writes the unmasked value, so treat such caches as
+//     holding the secret.
+//   - The others write their JSON form and read it back with the same
+//     validation. The zero value is written as no bytes.
+//
+// The types with MarshalText (Duration, ByteSize, IPAddress, ...) and the
+// integer and slice types already work with gob.
+
+const dateTimeBinaryVersion = 1
+
+/*
+	This part implements `encoding.BinaryMarshaler`
+	type BinaryMarshaler interface {
+		MarshalBinary() (data []byte, err error)
+	}
+*/
+func (dt DateTime) MarshalBinary() ([]byte, error) {
+	t, err := dt.Time().MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	b := make([]byte, 4, 4+len(dt.layout)+len(t))
+	b[0] = dateTimeBinaryVersion
+	b[1] = byte(dt.precision)
+	binary.BigEndian.PutUint16(b[2:], uint16(len(dt.layout)))
+	b = append(b, dt.layout...)
+	return append(b, t...), nil
+}
+
+/*
+	This part implements `encoding.BinaryUnmarshaler`
+	type BinaryUnmarshaler interface {
+		UnmarshalBinary(data []byte) error
+	}
+
+	The location and constraints set on dt are kept.
+*/
+func (dt *DateTime) UnmarshalBinary(b []byte) error {
+	if len(b) < 4 || b[0] != dateTimeBinaryVersion {
+		return errors.New("DateTime.UnmarshalBinary: unsupported data")
+	}
+	n := int(binary.BigEndian.Uint16(b[2:]))
+	if len(b) < 4+n {
+		return errors.New("DateTime.UnmarshalBinary: invalid length")
+	}
+	var t time.Time
+	if err := t.UnmarshalBinary(b[4+n:]); err != nil {
+		return err
+	}
+	*dt = dt.withTime(t)
+	dt.precision = DateTimePrecision(b[1])
+	dt.layout = string(b[4 : 4+n])
+	return nil
+}
+
+func (cc CreditCardNumber) MarshalBinary() ([]byte, error) {
+	return nil, errors.New("cannot write a CreditCardNumber to a binary payload, store a token or its Last4 instead")
+}
+
+func (cc *CreditCardNumber) UnmarshalBinary(b []byte) error {
+	if len(b) == 0 {
+		*cc = CreditCardNumber{}
+		return nil
+	}
+	parsed, err := ParseCreditCardNumber(string(b))
+	if err != nil {
+		return err
+	}
+	*cc = parsed
+	return nil
+}
+
+func (p Password) MarshalBinary() ([]byte, error) {
+	return nil, errors.New("cannot write a Password to a binary payload, store its hash instead")
+}
+
+// MarshalBinary drops the mask strategy, a func; the decoded value uses
+// DefaultMaskStrategy unless the target already had one.
+func (ms MaskedString) MarshalBinary() ([]byte, error) {
+	return []byte(ms.value), nil
+}
+
+func (ms *MaskedString) UnmarshalBinary(b []byte) error {
+	if len(b) == 0 {
+		ms.value = ""
+		return nil
+	}
+	if !utf8.Valid(b) {
+		return errors.New("MaskedString.UnmarshalBinary: must be valid UTF-8")
+	}
+	ms.value = string(b)
+	return nil
+}
+
+// Pagination is only ever written to JSON, so it has its own binary form:
+// page, per page and total as varints.
+func (p Pagination) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 3*binary.MaxVarintLen64)
+	n := binary.PutVarint(b, int64(p.page))
+	n += binary.PutVarint(b[n:], int64(p.perPage))
+	n += binary.PutVarint(b[n:], p.total)
+	return b[:n], nil
+}
+
+func (p *Pagination) UnmarshalBinary(b []byte) error {
+	var values [3]int64
+	for i := range values {
+		v, n := binary.Varint(b)
+		if n <= 0 {
+			return errors.New("Pagination.UnmarshalBinary: invalid data")
+		}
+		values[i], b = v, b[n:]
+	}
+	p.page, p.perPage, p.total = int(values[0]), int(values[1]), values[2]
+	return nil
+}
+
+func (bs BitString) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(bs) }
+func (bs *BitString) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(bs, b) }
+
+func (bs BoundedString[T]) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(bs) }
+func (bs *BoundedString[T]) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(bs, b) }
+
+func (bc BreakerConfig) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(bc) }
+func (bc *BreakerConfig) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(bc, b) }
+
+func (r CellRange) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(r) }
+func (r *CellRange) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(r, b) }
+
+func (c Channel) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(c) }
+func (c *Channel) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(c, b) }
+
+func (c CronExpression) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(c) }
+func (c *CronExpression) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(c, b) }
+
+func (d Date) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(d) }
+func (d *Date) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(d, b) }
+
+func (dr DateRange) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(dr) }
+func (dr *DateRange) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(dr, b) }
+
+func (dm DelimitedMap) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(dm) }
+func (dm *DelimitedMap) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(dm, b) }
+
+func (e Enum[T]) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(e) }
+func (e *Enum[T]) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(e, b) }
+
+func (fm FieldMapping) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(fm) }
+func (fm *FieldMapping) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(fm, b) }
+
+func (gp GeoPoint) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(gp) }
+func (gp *GeoPoint) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(gp, b) }
+
+func (hc HealthCheck) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(hc) }
+func (hc *HealthCheck) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(hc, b) }
+
+func (c HexColor) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(c) }
+func (c *HexColor) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(c, b) }
+
+func (hd HTTPDate) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(hd) }
+func (hd *HTTPDate) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(hd, b) }
+
+func (ir IntRange) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(ir) }
+func (ir *IntRange) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(ir, b) }
+
+func (lt LanguageTag) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(lt) }
+func (lt *LanguageTag) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(lt, b) }
+
+func (p Period) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(p) }
+func (p *Period) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(p, b) }
+
+func (r Recurrence) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(r) }
+func (r *Recurrence) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(r, b) }
+
+func (rp RegexPattern) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(rp) }
+func (rp *RegexPattern) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(rp, b) }
+
+func (rn ResourceName) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(rn) }
+func (rn *ResourceName) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(rn, b) }
+
+func (rp RetryPolicy) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(rp) }
+func (rp *RetryPolicy) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(rp, b) }
+
+func (v Semver) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(v) }
+func (v *Semver) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(v, b) }
+
+func (sc ShortCode) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(sc) }
+func (sc *ShortCode) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(sc, b) }
+
+func (tr TimeRange) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(tr) }
+func (tr *TimeRange) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(tr, b) }
+
+func (tz Timezone) MarshalBinary() ([]byte, error)  { return marshalBinaryAsJSON(tz) }
+func (tz *Timezone) UnmarshalBinary(b []byte) error { return unmarshalBinaryAsJSON(tz, b) }
+
+// jsonZeroer is a type stored as its JSON form, with the zero value stored
+// as nothing.
+type jsonZeroer interface {
+	json.Marshaler
+	IsZero() bool
+}
+
+func marshalBinaryAsJSON(v jsonZeroer) ([]byte, error) {
+	if v.IsZero() {
+		return nil, nil
+	}
+	return v.MarshalJSON()
+}
+
+func unmarshalBinaryAsJSON(target json.Unmarshaler, b []byte) (err error) {
+	if len(b) == 0 {
+		v := reflect.ValueOf(target).Elem()
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	defer recoverBadRequest(&err)
+	return target.UnmarshalJSON(b)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+)
+
+func TestCreditCardNumberBinary(t *testing.T) {
+	card, err := ParseCreditCardNumber("4111 1111 1111 1111")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, err := card.MarshalBinary(); err == nil {
+		t.Errorf("MarshalBinary wrote %q, want an error", b)
+	}
+	var cache bytes.Buffer
+	if err := gob.NewEncoder(&cache).Encode(struct{ Card CreditCardNumber }{card}); err == nil || bytes.Contains(cache.Bytes(), []byte("4111111111111111")) {
+		t.Errorf("gob encoded the card number: %v %q", err, cache.Bytes())
+	}
+
+	for _, tc := range []struct {
+		data    string
+		message string
+	}{
+		{"4111111111111112", "must be a valid card number"},
+		{"4111-1111-1111-111x", "must only contain digits, spaces or dashes"},
+		{"4111", "must be between 12 and 19 digits"},
+	} {
+		var cc CreditCardNumber
+		if err := cc.UnmarshalBinary([]byte(tc.data)); err == nil || err.Error() != tc.message {
+			t.Errorf("UnmarshalBinary(%q): error %v, want %q", tc.data, err, tc.message)
+		}
+	}
+	var cc CreditCardNumber
+	if err := cc.UnmarshalBinary([]byte("4111 1111 1111 1111")); err != nil || cc.Last4() != "1111" {
+		t.Errorf("UnmarshalBinary of a valid number: %v, last4 %s", err, cc.Last4())
+	}
+}
+
+func TestPasswordBinary(t *testing.T) {
+	if b, err := testPassword.MarshalBinary(); err == nil {
+		t.Errorf("MarshalBinary wrote %q, want an error", b)
+	}
+	var cache bytes.Buffer
+	if err := gob.NewEncoder(&cache).Encode(struct{ Secret Password }{testPassword}); err == nil || bytes.Contains(cache.Bytes(), []byte(testPassword)) {
+		t.Errorf("gob encoded the password: %v %q", err, cache.Bytes())
+	}
+}
+
+func TestMaskedStringBinary(t *testing.T) {
+	ms := NewMaskedString("3171234567890001", nil)
+	b, err := ms.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back MaskedString
+	if err := back.UnmarshalBinary(b); err != nil || back.Reveal() != ms.Reveal() {
+		t.Errorf("round trip %q, %v", back.Reveal(), err)
+	}
+	if err := back.UnmarshalBinary([]byte{0xff, 0xfe}); err == nil {
+		t.Error("UnmarshalBinary accepted invalid UTF-8")
+	}
+	if err := back.UnmarshalBinary(nil); err != nil || !back.IsZero() {
+		t.Errorf("no bytes: %q, %v, want the zero value", back.Reveal(), err)
+	}
+}
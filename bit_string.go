@@ -0,0 +1,183 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrCodeBitStringNotString = RegisterErrorCode(ErrorCode{
+		Code: "bit_string.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeBitStringEmpty = RegisterErrorCode(ErrorCode{
+		Code: "bit_string.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeBitStringInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "bit_string.invalid_format", Status: http.StatusBadRequest,
+		Message: "must only contain 0 and 1, or be 0x-prefixed hex", Params: []string{"value"}, Example: "must only contain 0 and 1, or be 0x-prefixed hex",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeBitStringHexEmpty = RegisterErrorCode(ErrorCode{
+		Code: "bit_string.hex_empty", Status: http.StatusBadRequest,
+		Message: "hex form must have at least one digit", Params: []string{"value"}, Example: "hex form must have at least one digit",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeBitStringHexInvalid = RegisterErrorCode(ErrorCode{
+		Code: "bit_string.hex_invalid", Status: http.StatusBadRequest,
+		Message: "hex form must only contain 0-9 and a-f", Params: []string{"value"}, Example: "hex form must only contain 0-9 and a-f",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeBitStringTooLong = RegisterErrorCode(ErrorCode{
+		Code: "bit_string.too_long", Status: http.StatusBadRequest,
+		Message: "must be at most {max} bits", Params: []string{"max", "value"}, Example: "must be at most 1024 bits",
+	})
+)
+
+// BitStringMaxLength bounds how many bits a BitString may carry.
+var BitStringMaxLength = 1024
+
+// BitString is a fixed-length bitset sent either as binary ("1011001") or,
+// with a 0x prefix, as hex ("0xB2"). Bit 0 is the leftmost bit as sent.
+type BitString struct {
+	bits   []byte
+	length int
+}
+
+func ParseBitString(s string) (BitString, error) {
+	var bs BitString
+
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		hex := s[2:]
+		if hex == "" {
+			return BitString{}, ErrCodeBitStringHexEmpty.Err(map[string]string{"value": s})
+		}
+		bs.length = len(hex) * 4
+		if bs.length > BitStringMaxLength {
+			return BitString{}, ErrCodeBitStringTooLong.Err(map[string]string{"max": strconv.Itoa(BitStringMaxLength), "value": s})
+		}
+		bs.bits = make([]byte, (bs.length+7)/8)
+		for i := 0; i < len(hex); i++ {
+			v, err := strconv.ParseUint(hex[i:i+1], 16, 8)
+			if err != nil {
+				return BitString{}, ErrCodeBitStringHexInvalid.Err(map[string]string{"value": s})
+			}
+			for j := 0; j < 4; j++ {
+				if v&(8>>j) != 0 {
+					bs.set(i*4 + j)
+				}
+			}
+		}
+		return bs, nil
+	}
+
+	bs.length = len(s)
+	if bs.length > BitStringMaxLength {
+		return BitString{}, ErrCodeBitStringTooLong.Err(map[string]string{"max": strconv.Itoa(BitStringMaxLength), "value": s})
+	}
+	bs.bits = make([]byte, (bs.length+7)/8)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '1':
+			bs.set(i)
+		case '0':
+		default:
+			return BitString{}, ErrCodeBitStringInvalidFormat.Err(map[string]string{"value": s})
+		}
+	}
+	return bs, nil
+}
+
+func (bs *BitString) set(i int) {
+	bs.bits[i/8] |= 0x80 >> (i % 8)
+}
+
+func (bs BitString) Len() int {
+	return bs.length
+}
+
+// Bit reports whether bit i is set; bits past the end read as unset.
+func (bs BitString) Bit(i int) bool {
+	if i < 0 || i >= bs.length {
+		return false
+	}
+	return bs.bits[i/8]&(0x80>>(i%8)) != 0
+}
+
+// Binary always renders the full "0101..." form.
+func (bs BitString) Binary() string {
+	b := make([]byte, bs.length)
+	for i := range b {
+		if bs.Bit(i) {
+			b[i] = '1'
+		} else {
+			b[i] = '0'
+		}
+	}
+	return string(b)
+}
+
+// String uses the shorter hex form when the length is a whole number of hex
+// digits, so it round-trips through ParseBitString with the same length.
+func (bs BitString) String() string {
+	if bs.length == 0 || bs.length%4 != 0 {
+		return bs.Binary()
+	}
+
+	const digits = "0123456789abcdef"
+	b := make([]byte, 0, 2+bs.length/4)
+	b = append(b, "0x"...)
+	for i := 0; i < bs.length; i += 4 {
+		v := 0
+		for j := 0; j < 4; j++ {
+			if bs.Bit(i + j) {
+				v |= 8 >> j
+			}
+		}
+		b = append(b, digits[v])
+	}
+	return string(b)
+}
+
+func (bs BitString) IsZero() bool {
+	return bs.length == 0
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (bs BitString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bs.String())
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (bs *BitString) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeBitStringNotString.Err(map[string]string{"value": string(b)})
+	}
+	if s == "" {
+		return ErrCodeBitStringEmpty.Err(nil)
+	}
+	parsed, err := ParseBitString(s)
+	if err != nil {
+		return err
+	}
+
+	*bs = parsed
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrCodeNonEmptyStringNotString = RegisterErrorCode(ErrorCode{
+		Code: "non_empty_string.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeNonEmptyStringEmpty = RegisterErrorCode(ErrorCode{
+		Code: "non_empty_string.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeBoundedStringNotString = RegisterErrorCode(ErrorCode{
+		Code: "bounded_string.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeBoundedStringLength = RegisterErrorCode(ErrorCode{
+		Code: "bounded_string.length", Status: http.StatusBadRequest,
+		Message: "must be between {min} and {max} characters", Params: []string{"min", "max", "value"}, Example: "must be between 3 and 20 characters",
+	})
+	ErrCodeBoundedStringExactLength = RegisterErrorCode(ErrorCode{
+		Code: "bounded_string.exact_length", Status: http.StatusBadRequest,
+		Message: "must be exactly {length} characters", Params: []string{"length", "value"}, Example: "must be exactly 6 characters",
+	})
+)
+
+// NonEmptyString rejects "" and whitespace-only strings. The value is kept
+// as sent, surrounding whitespace included.
+type NonEmptyString string
+
+func (nes NonEmptyString) String() string {
+	return string(nes)
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (nes *NonEmptyString) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeNonEmptyStringNotString.Err(map[string]string{"value": string(b)})
+	}
+	if strings.TrimSpace(s) == "" {
+		return ErrCodeNonEmptyStringEmpty.Err(nil)
+	}
+
+	*nes = NonEmptyString(s)
+
+	return nil
+}
+
+/*
+	StringBounds declares the length limits of a `BoundedString`, counted in
+	characters (runes), not bytes:
+
+	type usernameBounds struct{}
+
+	func (usernameBounds) Bounds() (min int, max int) {
+		return 3, 50
+	}
+
+	type Username = BoundedString[usernameBounds]
+*/
+type StringBounds interface {
+	Bounds() (min int, max int)
+}
+
+type BoundedString[T StringBounds] struct {
+	value string
+}
+
+func ParseBoundedString[T StringBounds](s string) (BoundedString[T], error) {
+	var bounds T
+	min, max := bounds.Bounds()
+	if n := utf8.RuneCountInString(s); n < min || n > max {
+		if min == max {
+			return BoundedString[T]{}, ErrCodeBoundedStringExactLength.Err(map[string]string{"length": strconv.Itoa(min), "value": s})
+		}
+		return BoundedString[T]{}, ErrCodeBoundedStringLength.Err(map[string]string{"min": strconv.Itoa(min), "max": strconv.Itoa(max), "value": s})
+	}
+	return BoundedString[T]{value: s}, nil
+}
+
+func (bs BoundedString[T]) String() string {
+	return bs.value
+}
+
+func (bs BoundedString[T]) IsZero() bool {
+	return bs.value == ""
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (bs BoundedString[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bs.value)
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (bs *BoundedString[T]) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeBoundedStringNotString.Err(map[string]string{"value": string(b)})
+	}
+	parsed, err := ParseBoundedString[T](s)
+	if err != nil {
+		return err
+	}
+
+	*bs = parsed
+
+	return nil
+}
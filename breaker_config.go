@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrCodeBreakerConfigNotObject = RegisterErrorCode(ErrorCode{
+		Code: "breaker_config.not_object", Status: http.StatusBadRequest,
+		Message: "must be a valid breaker config object", Example: "must be a valid breaker config object",
+	})
+	ErrCodeBreakerConfigErrorRate = RegisterErrorCode(ErrorCode{
+		Code: "breaker_config.error_rate_percent", Status: http.StatusBadRequest,
+		Message: "error_rate_percent must be greater than 0 and at most 100", Params: []string{"value"}, Example: "error_rate_percent must be greater than 0 and at most 100",
+	})
+	ErrCodeBreakerConfigWindowFormat = RegisterErrorCode(ErrorCode{
+		Code: "breaker_config.window_format", Status: http.StatusBadRequest,
+		Message: "window must be a valid duration", Params: []string{"value"}, Example: "window must be a valid duration",
+	})
+	ErrCodeBreakerConfigWindowRange = RegisterErrorCode(ErrorCode{
+		Code: "breaker_config.window_range", Status: http.StatusBadRequest,
+		Message: "window must be between 1s and 1h", Params: []string{"value"}, Example: "window must be between 1s and 1h",
+	})
+	ErrCodeBreakerConfigMinRequests = RegisterErrorCode(ErrorCode{
+		Code: "breaker_config.min_requests", Status: http.StatusBadRequest,
+		Message: "min_requests must be at least 1", Params: []string{"value"}, Example: "min_requests must be at least 1",
+	})
+)
+
+type BreakerConfig struct {
+	errorRatePercent float64
+	window           time.Duration
+	minRequests      int
+}
+
+type breakerConfigJSON struct {
+	ErrorRatePercent *float64 `json:"error_rate_percent"`
+	Window           *string  `json:"window"`
+	MinRequests      *int     `json:"min_requests"`
+}
+
+// DefaultBreakerConfig is used as the starting point when unmarshaling, so
+// any field omitted from the request keeps its default value.
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{
+		errorRatePercent: 50,
+		window:           10 * time.Second,
+		minRequests:      20,
+	}
+}
+
+func (bc BreakerConfig) ErrorRatePercent() float64 {
+	return bc.errorRatePercent
+}
+
+func (bc BreakerConfig) Window() time.Duration {
+	return bc.window
+}
+
+func (bc BreakerConfig) MinRequests() int {
+	return bc.minRequests
+}
+
+// ShouldTrip reports whether the observed counts inside one window exceed the
+// configured error rate, ignoring windows with too few requests.
+func (bc BreakerConfig) ShouldTrip(requests, failures int) bool {
+	if requests < bc.minRequests {
+		return false
+	}
+	return float64(failures)*100/float64(requests) >= bc.errorRatePercent
+}
+
+func (bc BreakerConfig) IsZero() bool {
+	return bc == BreakerConfig{}
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (bc BreakerConfig) MarshalJSON() ([]byte, error) {
+	window := bc.window.String()
+	return json.Marshal(breakerConfigJSON{
+		ErrorRatePercent: &bc.errorRatePercent,
+		Window:           &window,
+		MinRequests:      &bc.minRequests,
+	})
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (bc *BreakerConfig) UnmarshalJSON(b []byte) error {
+	var raw breakerConfigJSON
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return codedOr(err, ErrCodeBreakerConfigNotObject.Err(nil))
+	}
+
+	config := DefaultBreakerConfig()
+
+	if raw.ErrorRatePercent != nil {
+		if *raw.ErrorRatePercent <= 0 || *raw.ErrorRatePercent > 100 {
+			return ErrCodeBreakerConfigErrorRate.Err(map[string]string{"value": strconv.FormatFloat(*raw.ErrorRatePercent, 'f', -1, 64)})
+		}
+		config.errorRatePercent = *raw.ErrorRatePercent
+	}
+
+	if raw.Window != nil {
+		window, err := time.ParseDuration(*raw.Window)
+		if err != nil {
+			return ErrCodeBreakerConfigWindowFormat.Err(map[string]string{"value": *raw.Window})
+		}
+		if window < time.Second || window > time.Hour {
+			return ErrCodeBreakerConfigWindowRange.Err(map[string]string{"value": *raw.Window})
+		}
+		config.window = window
+	}
+
+	if raw.MinRequests != nil {
+		if *raw.MinRequests < 1 {
+			return ErrCodeBreakerConfigMinRequests.Err(map[string]string{"value": strconv.Itoa(*raw.MinRequests)})
+		}
+		config.minRequests = *raw.MinRequests
+	}
+
+	*bc = config
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// DateTime and ArrayString implement bson.ValueMarshaler and
+// ValueUnmarshaler of the MongoDB driver, so request structs can be stored
+// as they are: DateTime as a BSON datetime, which Mongo indexes and
+// compares as a date, and ArrayString as an array of strings, so
+// {"tags": "gift"} matches. Both also read the string form, for documents
+// written before. A Password is written as BSON null, so the plaintext
+// never reaches the database.
+//
+// A BSON datetime is milliseconds in UTC; a decoded DateTime is in
+// instantLocation and truncated to its precision.
+
+/*
+	This part implements `bson.ValueMarshaler`
+	type ValueMarshaler interface {
+		MarshalBSONValue() (bsontype.Type, []byte, error)
+	}
+*/
+func (dt DateTime) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return bsontype.DateTime, bsoncore.AppendDateTime(nil, dt.Time().UnixMilli()), nil
+}
+
+/*
+	This part implements `bson.ValueUnmarshaler`
+	type ValueUnmarshaler interface {
+		UnmarshalBSONValue(bsontype.Type, []byte) error
+	}
+*/
+func (dt *DateTime) UnmarshalBSONValue(t bsontype.Type, b []byte) error {
+	value := bsoncore.Value{Type: t, Data: b}
+	switch t {
+	case bsontype.Null:
+		return nil
+	case bsontype.String:
+		return unmarshalTextAsJSON(dt, []byte(value.StringValue()))
+	}
+	ms, ok := value.DateTimeOK()
+	if !ok {
+		return ErrCodeWrongType.Err(map[string]string{"type": "a date and time"})
+	}
+	return dt.setTime(time.UnixMilli(ms).In(dt.instantLocation()))
+}
+
+func (dt ArrayString) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	if dt == nil {
+		return bsontype.Null, nil, nil
+	}
+	values := make([]bsoncore.Value, len(dt))
+	for i, item := range dt {
+		values[i] = bsoncore.Value{Type: bsontype.String, Data: bsoncore.AppendString(nil, item)}
+	}
+	return bsontype.Array, bsoncore.BuildArray(nil, values...), nil
+}
+
+func (dt *ArrayString) UnmarshalBSONValue(t bsontype.Type, b []byte) error {
+	value := bsoncore.Value{Type: t, Data: b}
+	switch t {
+	case bsontype.Null:
+		*dt = nil
+		return nil
+	case bsontype.String:
+		return unmarshalTextAsJSON(dt, []byte(value.StringValue()))
+	}
+	array, ok := value.ArrayOK()
+	if !ok {
+		return ErrCodeWrongType.Err(map[string]string{"type": "an array"})
+	}
+	values, err := array.Values()
+	if err != nil {
+		return err
+	}
+	list := make(ArrayString, len(values))
+	for i, item := range values {
+		if list[i], ok = item.StringValueOK(); !ok {
+			return ErrCodeArrayNotString.Err(nil)
+		}
+	}
+	*dt = list
+	return nil
+}
+
+func (p Password) MarshalBSONValue() (bsontype.Type, []byte, error) {
+	return bsontype.Null, nil, nil
+}
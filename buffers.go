@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+	"unicode/utf8"
+)
+
+// The encoders that build their output piece by piece (DelimitedMap,
+// RedisValue, MarshalICS and MarshalVCard) write into a pooled buffer and
+// return a copy of exactly the right size, rather than growing a new
+// bytes.Buffer on every call. OmitZero does not: its output is usually
+// small enough that the copy costs more than the pool saves. The benchmarks
+// are in benchmark_test.go; benchmarks.txt has the numbers.
+
+// maxPooledBuffer keeps the pool from holding on to the buffer of one
+// unusually large document.
+const maxPooledBuffer = 64 << 10
+
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBuffer {
+		return
+	}
+	bufferPool.Put(buf)
+}
+
+// bufferBytes copies the contents of buf and puts buf back in the pool, so
+// the caller must not use buf afterwards.
+func bufferBytes(buf *bytes.Buffer) []byte {
+	b := append([]byte(nil), buf.Bytes()...)
+	putBuffer(buf)
+	return b
+}
+
+// writeJSONString writes s as a JSON string, what json.Marshal(s) returns,
+// without its two allocations when s needs no escaping.
+func writeJSONString(buf *bytes.Buffer, s string) {
+	for i := 0; i < len(s); i++ {
+		if !jsonSafe(s[i]) {
+			quoted, _ := json.Marshal(s)
+			buf.Write(quoted)
+			return
+		}
+	}
+	buf.WriteByte('"')
+	buf.WriteString(s)
+	buf.WriteByte('"')
+}
+
+// jsonSafe reports whether encoding/json writes c unchanged in a string,
+// with its default HTML escaping.
+func jsonSafe(c byte) bool {
+	return c >= 0x20 && c < utf8.RuneSelf && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&'
+}
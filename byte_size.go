@@ -0,0 +1,252 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrCodeByteSizeNotString = RegisterErrorCode(ErrorCode{
+		Code: "byte_size.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeByteSizeEmpty = RegisterErrorCode(ErrorCode{
+		Code: "byte_size.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeByteSizeInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "byte_size.invalid_format", Status: http.StatusBadRequest,
+		Message: "format must be a number followed by a unit like MB or GiB", Params: []string{"value"}, Example: "format must be a number followed by a unit like MB or GiB",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeByteSizeUnknownUnit = RegisterErrorCode(ErrorCode{
+		Code: "byte_size.unknown_unit", Status: http.StatusBadRequest,
+		Message: "unknown unit {unit}", Params: []string{"unit", "value"}, Example: "unknown unit XB",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeByteSizeNotNumber = RegisterErrorCode(ErrorCode{
+		Code: "byte_size.not_number", Status: http.StatusBadRequest,
+		Message: "must be a number", Params: []string{"value"}, Example: "must be a number",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeByteSizeFraction = RegisterErrorCode(ErrorCode{
+		Code: "byte_size.fraction", Status: http.StatusBadRequest,
+		Message: "must be a whole number of bytes", Params: []string{"value"}, Example: "must be a whole number of bytes",
+	})
+	ErrCodeByteSizeTooLarge = RegisterErrorCode(ErrorCode{
+		Code: "byte_size.too_large", Status: http.StatusBadRequest,
+		Message: "is too large", Params: []string{"value"}, Example: "is too large",
+	})
+	ErrCodeByteSizeOutOfRange = RegisterErrorCode(ErrorCode{
+		Code: "byte_size.out_of_range", Status: http.StatusBadRequest,
+		Message: "must be between {min} and {max}", Params: []string{"min", "max", "value"}, Example: "must be between 1KiB and 2GiB",
+	})
+	ErrCodeByteSizeRejected = RegisterErrorCode(ErrorCode{
+		Code: "byte_size.rejected", Status: http.StatusBadRequest,
+		Message: "{reason}", Params: []string{"reason"}, Example: "must be between 1KiB and 2GiB",
+	})
+)
+
+// ByteSizeValidate, when set, runs after parsing; the error message it
+// returns is sent back to the client as a 400. See ByteSizeBetween.
+var ByteSizeValidate func(size ByteSize) error
+
+var byteSizePattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([A-Za-z]*)$`)
+
+type byteSizeUnit struct {
+	suffix string
+	size   int64
+}
+
+// Ordered from largest to smallest so String picks the biggest unit that
+// still represents the value exactly.
+var byteSizeUnits = []byteSizeUnit{
+	{"EiB", 1 << 60}, {"EB", 1e18},
+	{"PiB", 1 << 50}, {"PB", 1e15},
+	{"TiB", 1 << 40}, {"TB", 1e12},
+	{"GiB", 1 << 30}, {"GB", 1e9},
+	{"MiB", 1 << 20}, {"MB", 1e6},
+	{"KiB", 1 << 10}, {"KB", 1e3},
+}
+
+// ByteSize is a byte count written for humans: SI suffixes ("10MB" is
+// 10,000,000) and IEC suffixes ("1.5GiB" is 1,610,612,736) are accepted case
+// insensitively, as are plain numbers of bytes.
+type ByteSize int64
+
+func ParseByteSize(s string) (ByteSize, error) {
+	m := byteSizePattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, ErrCodeByteSizeInvalidFormat.Err(map[string]string{"value": s})
+	}
+
+	unit := int64(1)
+	if suffix := strings.ToLower(m[2]); suffix != "" && suffix != "b" {
+		found := false
+		for _, u := range byteSizeUnits {
+			if strings.ToLower(u.suffix) == suffix {
+				unit, found = u.size, true
+				break
+			}
+		}
+		if !found {
+			return 0, ErrCodeByteSizeUnknownUnit.Err(map[string]string{"unit": m[2], "value": s})
+		}
+	}
+
+	n, ok := new(big.Rat).SetString(m[1])
+	if !ok {
+		return 0, ErrCodeByteSizeNotNumber.Err(map[string]string{"value": s})
+	}
+	n.Mul(n, new(big.Rat).SetInt64(unit))
+	if !n.IsInt() {
+		return 0, ErrCodeByteSizeFraction.Err(map[string]string{"value": s})
+	}
+	if n.Num().Cmp(big.NewInt(math.MaxInt64)) > 0 {
+		return 0, ErrCodeByteSizeTooLarge.Err(map[string]string{"value": s})
+	}
+	return ByteSize(n.Num().Int64()), nil
+}
+
+// ByteSizeBetween is a ready-made ByteSizeValidate hook.
+func ByteSizeBetween(min ByteSize, max ByteSize) func(size ByteSize) error {
+	return func(size ByteSize) error {
+		if size < min || size > max {
+			return ErrCodeByteSizeOutOfRange.Err(map[string]string{"min": min.String(), "max": max.String(), "value": size.String()})
+		}
+		return nil
+	}
+}
+
+func (bs ByteSize) Bytes() int64 {
+	return int64(bs)
+}
+
+// String uses the largest unit that keeps the value exact to two
+// decimals ("1.5GiB", "10MB", "1023B"), so it always parses back to the
+// same number of bytes.
+func (bs ByteSize) String() string {
+	n := big.NewInt(int64(bs))
+	for _, u := range byteSizeUnits {
+		if int64(bs) < u.size {
+			continue
+		}
+		hundredths := new(big.Int).Mul(n, big.NewInt(100))
+		q, r := new(big.Int).QuoRem(hundredths, big.NewInt(u.size), new(big.Int))
+		if r.Sign() != 0 {
+			continue
+		}
+		whole, frac := q.Int64()/100, q.Int64()%100
+		s := strconv.FormatInt(whole, 10)
+		if frac != 0 {
+			s += "." + strings.TrimRight(fmt.Sprintf("%02d", frac), "0")
+		}
+		return s + u.suffix
+	}
+	return strconv.FormatInt(int64(bs), 10) + "B"
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (bs ByteSize) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bs.String())
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+
+	A bare JSON number is read as bytes.
+*/
+func (bs *ByteSize) UnmarshalJSON(b []byte) error {
+	var s string
+	if len(b) > 0 && b[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return ErrCodeByteSizeNotString.Err(map[string]string{"value": string(b)})
+		}
+		if s == "" {
+			return ErrCodeByteSizeEmpty.Err(nil)
+		}
+	} else {
+		s = string(b)
+	}
+	size, err := ParseByteSize(s)
+	if err != nil {
+		return err
+	}
+	if ByteSizeValidate != nil {
+		if err := ByteSizeValidate(size); err != nil {
+			return codedOr(err, ErrCodeByteSizeRejected.Err(map[string]string{"reason": err.Error()}))
+		}
+	}
+
+	*bs = size
+
+	return nil
+}
+
+/*
+	This part implements `encoding.TextMarshaler`
+	type TextMarshaler interface {
+		MarshalText() (text []byte, err error)
+	}
+*/
+func (bs ByteSize) MarshalText() ([]byte, error) {
+	return []byte(bs.String()), nil
+}
+
+/*
+	This part implements `encoding.TextUnmarshaler`
+	type TextUnmarshaler interface {
+		UnmarshalText(text []byte) error
+	}
+*/
+func (bs *ByteSize) UnmarshalText(b []byte) error {
+	return unmarshalTextAsJSON(bs, b)
+}
+
+/*
+	This part implements `yaml.Marshaler`
+	type Marshaler interface {
+		MarshalYAML() (interface{}, error)
+	}
+*/
+func (bs ByteSize) MarshalYAML() (interface{}, error) {
+	return bs.String(), nil
+}
+
+/*
+	This part implements `yaml.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalYAML(value *yaml.Node) error
+	}
+*/
+func (bs *ByteSize) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshalYAMLAsJSON(bs, value)
+}
+
+/*
+	This part implements BurntSushi/toml's `toml.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalTOML(interface{}) error
+	}
+*/
+func (bs *ByteSize) UnmarshalTOML(value interface{}) error {
+	return unmarshalValueAsJSON(bs, value)
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Catalog holds data that validation depends on but that may change while
+// the service runs: message overrides, supported currencies, country lists.
+// Readers always see a complete value; Swap and Reload replace it
+// atomically. Values are shared between goroutines, so never modify one
+// returned by Get; build a new value and Swap it in.
+type Catalog[T any] struct {
+	name  string
+	value atomic.Value
+}
+
+// catalogBox gives atomic.Value one concrete type even when T is an
+// interface or nil.
+type catalogBox[T any] struct {
+	value T
+}
+
+func NewCatalog[T any](name string, initial T) *Catalog[T] {
+	c := &Catalog[T]{name: name}
+	c.Swap(initial)
+	return c
+}
+
+func (c *Catalog[T]) Name() string {
+	return c.name
+}
+
+func (c *Catalog[T]) Get() T {
+	return c.value.Load().(catalogBox[T]).value
+}
+
+func (c *Catalog[T]) Swap(value T) {
+	c.value.Store(catalogBox[T]{value: value})
+}
+
+// Reload fetches a new value from p and swaps it in. On error the current
+// value stays in place.
+func (c *Catalog[T]) Reload(p CatalogProvider[T]) error {
+	value, err := p.LoadCatalog()
+	if err != nil {
+		return fmt.Errorf("catalog %s: %w", c.name, err)
+	}
+	c.Swap(value)
+	return nil
+}
+
+// Watch reloads from p every interval until ctx is done. Providers that
+// implement CatalogVersioner are only reloaded when their version changes.
+// Failed reloads keep the previous value and are passed to onError, which
+// may be nil.
+func (c *Catalog[T]) Watch(ctx context.Context, p CatalogProvider[T], interval time.Duration, onError func(error)) {
+	versioner, _ := p.(CatalogVersioner)
+	lastVersion := ""
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if versioner != nil {
+			version, err := versioner.CatalogVersion()
+			if err == nil && version == lastVersion {
+				continue
+			}
+			lastVersion = version
+		}
+		if err := c.Reload(p); err != nil && onError != nil {
+			onError(err)
+		}
+	}
+}
+
+// CatalogProvider fetches the current value of a catalog, e.g. from a file
+// or a remote config service.
+type CatalogProvider[T any] interface {
+	LoadCatalog() (T, error)
+}
+
+// CatalogVersioner is implemented by providers that can tell cheaply
+// whether their data changed, such as a file's modification time.
+type CatalogVersioner interface {
+	CatalogVersion() (string, error)
+}
+
+// CatalogProviderFunc adapts a function, typically a remote config client
+// call, to CatalogProvider.
+type CatalogProviderFunc[T any] func() (T, error)
+
+func (f CatalogProviderFunc[T]) LoadCatalog() (T, error) {
+	return f()
+}
+
+// FileCatalogProvider reads a catalog from a JSON (".json") or YAML file.
+type FileCatalogProvider[T any] struct {
+	Path string
+}
+
+func (p FileCatalogProvider[T]) LoadCatalog() (T, error) {
+	var value T
+	b, err := os.ReadFile(p.Path)
+	if err != nil {
+		return value, err
+	}
+	if strings.EqualFold(filepath.Ext(p.Path), ".json") {
+		err = json.NewDecoder(bytes.NewReader(b)).Decode(&value)
+	} else {
+		err = unmarshalYAMLStrict(b, &value)
+	}
+	return value, err
+}
+
+func (p FileCatalogProvider[T]) CatalogVersion() (string, error) {
+	info, err := os.Stat(p.Path)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d-%d", info.ModTime().UnixNano(), info.Size()), nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// DateTime, ArrayString and Duration implement cbor.Marshaler and
+// Unmarshaler of fxamacker/cbor for COSE and IoT payloads: DateTime as tag
+// 0 or 1, see DateTimeCBORTag, ArrayString as an array of text strings and
+// Duration as its text. The integer types (ByteSize, StringInt64,
+// Percentage, ...) are CBOR integers as they are. Password is written as
+// CBOR null, as it is null in JSON.
+
+// CBORTimeTag is the CBOR tag DateTime is written with. Both are read.
+type CBORTimeTag int
+
+const (
+	// CBORTimeString is tag 0, the DateTime's text, which keeps its offset.
+	CBORTimeString CBORTimeTag = iota
+	// CBORTimeEpoch is tag 1, seconds since the epoch, with a fraction
+	// only when there is one. It is smaller, but the offset is lost.
+	CBORTimeEpoch
+)
+
+// DateTimeCBORTag is the tag DateTime.MarshalCBOR uses.
+var DateTimeCBORTag = CBORTimeString
+
+// epochCBOR writes a time.Time as tag 1, an integer when it has no
+// fractional seconds.
+var epochCBOR, _ = cbor.EncOptions{Time: cbor.TimeUnixDynamic, TimeTag: cbor.EncTagRequired}.EncMode()
+
+// cborTag0 is the initial byte of a tag 0 item.
+const cborTag0 = 0xc0
+
+/*
+	This part implements `cbor.Marshaler`
+	type Marshaler interface {
+		MarshalCBOR() ([]byte, error)
+	}
+*/
+func (dt DateTime) MarshalCBOR() ([]byte, error) {
+	if DateTimeCBORTag == CBORTimeEpoch {
+		return epochCBOR.Marshal(dt.Time())
+	}
+	return cbor.Marshal(cbor.Tag{Number: 0, Content: dt.String()})
+}
+
+/*
+	This part implements `cbor.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalCBOR([]byte) error
+	}
+
+	Text, tagged 0 or not, goes through DateTimeLayouts like JSON; tag 1
+	and bare numbers are epoch seconds, in instantLocation.
+*/
+func (dt *DateTime) UnmarshalCBOR(b []byte) error {
+	text := b
+	if len(text) > 0 && text[0] == cborTag0 {
+		text = text[1:]
+	}
+	var s string
+	if err := cbor.Unmarshal(text, &s); err == nil {
+		return unmarshalTextAsJSON(dt, []byte(s))
+	}
+
+	var t time.Time
+	if err := cbor.Unmarshal(b, &t); err != nil {
+		return ErrCodeWrongType.Err(map[string]string{"type": "a date and time"})
+	}
+	return dt.setTime(t.In(dt.instantLocation()))
+}
+
+func (dt ArrayString) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal([]string(dt))
+}
+
+// UnmarshalCBOR also accepts the separated text string.
+func (dt *ArrayString) UnmarshalCBOR(b []byte) error {
+	var list []string
+	if err := cbor.Unmarshal(b, &list); err != nil {
+		var s string
+		if err := cbor.Unmarshal(b, &s); err != nil {
+			return ErrCodeArrayNotString.Err(nil)
+		}
+		return unmarshalTextAsJSON(dt, []byte(s))
+	}
+	if list != nil && len(list) == 0 {
+		return ErrCodeArrayEmpty.Err(nil)
+	}
+	*dt = list
+	return nil
+}
+
+func (d Duration) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal(d.String())
+}
+
+func (d *Duration) UnmarshalCBOR(b []byte) error {
+	var s string
+	if err := cbor.Unmarshal(b, &s); err != nil {
+		return ErrCodeNotString.Err(nil)
+	}
+	return unmarshalTextAsJSON(d, []byte(s))
+}
+
+// cborNull is the encoded CBOR null.
+var cborNull = []byte{0xf6}
+
+func (p Password) MarshalCBOR() ([]byte, error) {
+	return cborNull, nil
+}
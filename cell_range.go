@@ -0,0 +1,250 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrCodeCellRangeNotString = RegisterErrorCode(ErrorCode{
+		Code: "cell_range.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeCellRangeEmpty = RegisterErrorCode(ErrorCode{
+		Code: "cell_range.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeCellRangeInvalidCell = RegisterErrorCode(ErrorCode{
+		Code: "cell_range.invalid_cell", Status: http.StatusBadRequest,
+		Message: "cell must look like A1", Params: []string{"value"}, Example: "cell must look like A1",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeCellRangeColumn = RegisterErrorCode(ErrorCode{
+		Code: "cell_range.column", Status: http.StatusBadRequest,
+		Message: "column must not be past XFD", Params: []string{"value"}, Example: "column must not be past XFD",
+	})
+	ErrCodeCellRangeRow = RegisterErrorCode(ErrorCode{
+		Code: "cell_range.row", Status: http.StatusBadRequest,
+		Message: "row must not be past {max}", Params: []string{"max", "value"}, Example: "row must not be past 1048576",
+	})
+	ErrCodeCellRangeUnclosedSheet = RegisterErrorCode(ErrorCode{
+		Code: "cell_range.unclosed_sheet", Status: http.StatusBadRequest,
+		Message: "quoted sheet name must be closed", Example: "quoted sheet name must be closed",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeCellRangeUnquotedSheet = RegisterErrorCode(ErrorCode{
+		Code: "cell_range.unquoted_sheet", Status: http.StatusBadRequest,
+		Message: "sheet names with spaces must be quoted", Example: "sheet names with spaces must be quoted",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeCellRangeSheetName = RegisterErrorCode(ErrorCode{
+		Code: "cell_range.sheet_name", Status: http.StatusBadRequest,
+		Message: "sheet name must be 1-31 characters without []:*?/\\", Example: "sheet name must be 1-31 characters without []:*?/\\",
+	})
+	ErrCodeCellRangeReversed = RegisterErrorCode(ErrorCode{
+		Code: "cell_range.reversed", Status: http.StatusBadRequest,
+		Message: "range end must not be above or left of its start", Example: "range end must not be above or left of its start",
+	})
+)
+
+// Spreadsheet limits as defined by the xlsx format.
+const (
+	maxCellColumn = 16384   // XFD
+	maxCellRow    = 1048576 // 2^20
+)
+
+// CellRef is a single cell; Column and Row are 1-based.
+type CellRef struct {
+	Column int
+	Row    int
+}
+
+func ParseCellRef(s string) (CellRef, error) {
+	invalid := ErrCodeCellRangeInvalidCell.Err(map[string]string{"value": s})
+
+	i := 0
+	col := 0
+	for i < len(s) && s[i] >= 'A' && s[i] <= 'Z' {
+		col = col*26 + int(s[i]-'A'+1)
+		if col > maxCellColumn {
+			return CellRef{}, ErrCodeCellRangeColumn.Err(map[string]string{"value": s})
+		}
+		i++
+	}
+	if i == 0 || i == len(s) || s[i] == '0' {
+		return CellRef{}, invalid
+	}
+	row, err := strconv.Atoi(s[i:])
+	if err != nil || row < 1 {
+		return CellRef{}, invalid
+	}
+	if row > maxCellRow {
+		return CellRef{}, ErrCodeCellRangeRow.Err(map[string]string{"max": strconv.Itoa(maxCellRow), "value": s})
+	}
+
+	return CellRef{Column: col, Row: row}, nil
+}
+
+// ColumnName converts the column number back to letters (1 → A, 27 → AA).
+func (c CellRef) ColumnName() string {
+	var b []byte
+	for n := c.Column; n > 0; n = (n - 1) / 26 {
+		b = append([]byte{byte('A' + (n-1)%26)}, b...)
+	}
+	return string(b)
+}
+
+func (c CellRef) String() string {
+	return c.ColumnName() + strconv.Itoa(c.Row)
+}
+
+// CellRange is an A1-style reference such as "A1:C10", "Sheet1!B2" or
+// "'Q1 Report'!A1:B2". A single cell is a range whose start equals its end.
+type CellRange struct {
+	sheet string
+	start CellRef
+	end   CellRef
+}
+
+func ParseCellRange(s string) (CellRange, error) {
+	var r CellRange
+
+	if i := strings.LastIndex(s, "!"); i >= 0 {
+		sheet := s[:i]
+		if strings.HasPrefix(sheet, "'") {
+			if len(sheet) < 3 || !strings.HasSuffix(sheet, "'") {
+				return CellRange{}, ErrCodeCellRangeUnclosedSheet.Err(nil)
+			}
+			sheet = strings.ReplaceAll(sheet[1:len(sheet)-1], "''", "'")
+		} else if strings.ContainsAny(sheet, " '") {
+			return CellRange{}, ErrCodeCellRangeUnquotedSheet.Err(nil)
+		}
+		if sheet == "" || len([]rune(sheet)) > 31 || strings.ContainsAny(sheet, `[]:*?/\`) {
+			return CellRange{}, ErrCodeCellRangeSheetName.Err(nil)
+		}
+		r.sheet = sheet
+		s = s[i+1:]
+	}
+
+	from, to, isRange := strings.Cut(strings.ToUpper(s), ":")
+	start, err := ParseCellRef(from)
+	if err != nil {
+		return CellRange{}, err
+	}
+	end := start
+	if isRange {
+		if end, err = ParseCellRef(to); err != nil {
+			return CellRange{}, err
+		}
+	}
+	if end.Column < start.Column || end.Row < start.Row {
+		return CellRange{}, ErrCodeCellRangeReversed.Err(nil)
+	}
+
+	r.start, r.end = start, end
+	return r, nil
+}
+
+func (r CellRange) Sheet() string {
+	return r.sheet
+}
+
+func (r CellRange) Start() CellRef {
+	return r.start
+}
+
+func (r CellRange) End() CellRef {
+	return r.end
+}
+
+func (r CellRange) Rows() int {
+	return r.end.Row - r.start.Row + 1
+}
+
+func (r CellRange) Columns() int {
+	return r.end.Column - r.start.Column + 1
+}
+
+func (r CellRange) Len() int {
+	return r.Rows() * r.Columns()
+}
+
+func (r CellRange) Contains(c CellRef) bool {
+	return c.Column >= r.start.Column && c.Column <= r.end.Column &&
+		c.Row >= r.start.Row && c.Row <= r.end.Row
+}
+
+// Each visits the cells row by row, left to right, until fn returns false.
+func (r CellRange) Each(fn func(CellRef) bool) {
+	for row := r.start.Row; row <= r.end.Row; row++ {
+		for col := r.start.Column; col <= r.end.Column; col++ {
+			if !fn(CellRef{Column: col, Row: row}) {
+				return
+			}
+		}
+	}
+}
+
+func (r CellRange) String() string {
+	if r.start.Row == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	if r.sheet != "" {
+		if strings.ContainsAny(r.sheet, " '") {
+			sb.WriteString("'" + strings.ReplaceAll(r.sheet, "'", "''") + "'")
+		} else {
+			sb.WriteString(r.sheet)
+		}
+		sb.WriteByte('!')
+	}
+	sb.WriteString(r.start.String())
+	if r.end != r.start {
+		sb.WriteByte(':')
+		sb.WriteString(r.end.String())
+	}
+	return sb.String()
+}
+
+func (r CellRange) IsZero() bool {
+	return r == CellRange{}
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (r CellRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.String())
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (r *CellRange) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeCellRangeNotString.Err(map[string]string{"value": string(b)})
+	}
+	if s == "" {
+		return ErrCodeCellRangeEmpty.Err(nil)
+	}
+	parsed, err := ParseCellRange(s)
+	if err != nil {
+		return err
+	}
+
+	*r = parsed
+
+	return nil
+}
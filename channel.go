@@ -0,0 +1,215 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"regexp"
+)
+
+var (
+	ErrCodeChannelNotObject = RegisterErrorCode(ErrorCode{
+		Code: "channel.not_object", Status: http.StatusBadRequest,
+		Message: "must be a valid channel object", Example: "must be a valid channel object",
+	})
+	ErrCodeChannelTypeEmpty = RegisterErrorCode(ErrorCode{
+		Code: "channel.type_empty", Status: http.StatusBadRequest,
+		Message: "type must not be empty", Example: "type must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeChannelType = RegisterErrorCode(ErrorCode{
+		Code: "channel.type", Status: http.StatusBadRequest,
+		Message: "type must be one of email, sms, webhook, slack", Params: []string{"value"}, Example: "type must be one of email, sms, webhook, slack",
+	})
+	ErrCodeChannelVariantNotObject = RegisterErrorCode(ErrorCode{
+		Code: "channel.variant_not_object", Status: http.StatusBadRequest,
+		Message: "must be a valid {type} channel object", Params: []string{"type"}, Example: "must be a valid email channel object",
+	})
+	ErrCodeChannelAddress = RegisterErrorCode(ErrorCode{
+		Code: "channel.address", Status: http.StatusBadRequest,
+		Message: "address must be a valid email address", Params: []string{"value"}, Example: "address must be a valid email address",
+	})
+	ErrCodeChannelPhone = RegisterErrorCode(ErrorCode{
+		Code: "channel.phone", Status: http.StatusBadRequest,
+		Message: "phone must be an E.164 phone number like +6281234567890", Params: []string{"value"}, Example: "phone must be an E.164 phone number like +6281234567890",
+	})
+	ErrCodeChannelURL = RegisterErrorCode(ErrorCode{
+		Code: "channel.url", Status: http.StatusBadRequest,
+		Message: "url must be an absolute https URL", Params: []string{"value"}, Example: "url must be an absolute https URL",
+	})
+	ErrCodeChannelWebhookURL = RegisterErrorCode(ErrorCode{
+		Code: "channel.webhook_url", Status: http.StatusBadRequest,
+		Message: "webhook_url must be an absolute https URL", Params: []string{"value"}, Example: "webhook_url must be an absolute https URL",
+	})
+	ErrCodeChannelSlackChannel = RegisterErrorCode(ErrorCode{
+		Code: "channel.slack_channel", Status: http.StatusBadRequest,
+		Message: "channel must be a slack channel name like #alerts", Params: []string{"value"}, Example: "channel must be a slack channel name like #alerts",
+	})
+)
+
+var (
+	phoneNumberPattern  = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+	slackChannelPattern = regexp.MustCompile(`^#[a-z0-9][a-z0-9_-]{0,79}$`)
+)
+
+// ChannelVariant is implemented by every concrete notification channel. The
+// kind is written to and read from the "type" discriminator field.
+type ChannelVariant interface {
+	Kind() string
+	validate() error
+}
+
+type EmailChannel struct {
+	Address string `json:"address"`
+}
+
+type SMSChannel struct {
+	Phone string `json:"phone"`
+}
+
+type WebhookChannel struct {
+	URL string `json:"url"`
+}
+
+type SlackChannel struct {
+	WebhookURL string `json:"webhook_url"`
+	Channel    string `json:"channel"`
+}
+
+func (EmailChannel) Kind() string   { return "email" }
+func (SMSChannel) Kind() string     { return "sms" }
+func (WebhookChannel) Kind() string { return "webhook" }
+func (SlackChannel) Kind() string   { return "slack" }
+
+func (c EmailChannel) validate() error {
+	addr, err := mail.ParseAddress(c.Address)
+	if err != nil || addr.Address != c.Address {
+		return ErrCodeChannelAddress.Err(map[string]string{"value": c.Address})
+	}
+	return nil
+}
+
+func (c SMSChannel) validate() error {
+	if !phoneNumberPattern.MatchString(c.Phone) {
+		return ErrCodeChannelPhone.Err(map[string]string{"value": c.Phone})
+	}
+	return nil
+}
+
+func (c WebhookChannel) validate() error {
+	if !isHTTPSURL(c.URL) {
+		return ErrCodeChannelURL.Err(map[string]string{"value": c.URL})
+	}
+	return nil
+}
+
+func (c SlackChannel) validate() error {
+	if !isHTTPSURL(c.WebhookURL) {
+		return ErrCodeChannelWebhookURL.Err(map[string]string{"value": c.WebhookURL})
+	}
+	if !slackChannelPattern.MatchString(c.Channel) {
+		return ErrCodeChannelSlackChannel.Err(map[string]string{"value": c.Channel})
+	}
+	return nil
+}
+
+func isHTTPSURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.Scheme == "https" && u.Host != ""
+}
+
+// newChannelVariants maps each discriminator value to a constructor of an
+// empty variant to decode into.
+var newChannelVariants = map[string]func() ChannelVariant{
+	"email":   func() ChannelVariant { return &EmailChannel{} },
+	"sms":     func() ChannelVariant { return &SMSChannel{} },
+	"webhook": func() ChannelVariant { return &WebhookChannel{} },
+	"slack":   func() ChannelVariant { return &SlackChannel{} },
+}
+
+// Channel is a discriminated union of notification targets, encoded as a
+// flat object with a "type" field, e.g. {"type":"sms","phone":"+62..."}.
+type Channel struct {
+	variant ChannelVariant
+}
+
+func NewChannel(variant ChannelVariant) Channel {
+	return Channel{variant: variant}
+}
+
+func (c Channel) Kind() string {
+	if c.variant == nil {
+		return ""
+	}
+	return c.variant.Kind()
+}
+
+// Variant returns the decoded channel (*EmailChannel, *SMSChannel, ...), to
+// be inspected with a type switch.
+func (c Channel) Variant() ChannelVariant {
+	return c.variant
+}
+
+func (c Channel) IsZero() bool {
+	return c.variant == nil
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (c Channel) MarshalJSON() ([]byte, error) {
+	if c.variant == nil {
+		return []byte("null"), nil
+	}
+
+	b, err := json.Marshal(c.variant)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	kind, _ := json.Marshal(c.variant.Kind())
+	fields["type"] = kind
+
+	return json.Marshal(fields)
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (c *Channel) UnmarshalJSON(b []byte) error {
+	var head struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(b, &head); err != nil {
+		return codedOr(err, ErrCodeChannelNotObject.Err(nil))
+	}
+	if head.Type == "" {
+		return ErrCodeChannelTypeEmpty.Err(nil)
+	}
+	newVariant, ok := newChannelVariants[head.Type]
+	if !ok {
+		return ErrCodeChannelType.Err(map[string]string{"value": head.Type})
+	}
+
+	variant := newVariant()
+	if err := json.Unmarshal(b, variant); err != nil {
+		return codedOr(err, ErrCodeChannelVariantNotObject.Err(map[string]string{"type": head.Type}))
+	}
+	if err := variant.validate(); err != nil {
+		return err
+	}
+
+	c.variant = variant
+
+	return nil
+}
@@ -0,0 +1,377 @@
+// Command enumgen writes the boilerplate for string enum types: JSON and text
+// (un)marshaling, Scanner/Valuer, IsValid and an exhaustive Values slice.
+//
+// From a const block in the current package (run through `go generate`):
+//
+//	type Priority string
+//
+//	const (
+//		PriorityLow  Priority = "low"
+//		PriorityHigh Priority = "high"
+//	)
+//
+//	//go:generate go run ./cmd/enumgen -type=Priority
+//
+// Or from a YAML definition, in which case the type and constants are
+// generated too:
+//
+//	type: Priority
+//	values: [low, high]
+//
+//	//go:generate go run ./cmd/enumgen -yaml=priority.yaml
+//
+// The generated UnmarshalJSON returns coded errors (type.not_string,
+// type.empty and type.invalid_value, e.g. priority.empty) registered with the
+// package's RegisterErrorCode, so the target package must declare it along
+// with ErrorCode and the ErrNotAString and ErrEmptyValue sentinels.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+type enumValue struct {
+	Name  string
+	Value string
+}
+
+type enumDefinition struct {
+	Package     string
+	Type        string
+	Values      []enumValue
+	DeclareType bool
+}
+
+type yamlDefinition struct {
+	Package string   `yaml:"package"`
+	Type    string   `yaml:"type"`
+	Values  []string `yaml:"values"`
+}
+
+func main() {
+	typeName := flag.String("type", "", "name of the string type whose constants make up the enum")
+	yamlPath := flag.String("yaml", "", "YAML file describing the enum instead of a const block")
+	dir := flag.String("dir", ".", "package directory to read and write")
+	output := flag.String("output", "", "output file name; default <type>_enum.go")
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("enumgen: ")
+
+	var (
+		def enumDefinition
+		err error
+	)
+	switch {
+	case *yamlPath != "":
+		def, err = loadYAML(*yamlPath, *dir)
+	case *typeName != "":
+		def, err = loadConsts(*dir, *typeName)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := render(def)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	name := *output
+	if name == "" {
+		name = toSnake(def.Type) + "_enum.go"
+	}
+	if err := os.WriteFile(filepath.Join(*dir, name), src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func loadYAML(path string, dir string) (enumDefinition, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return enumDefinition{}, err
+	}
+	var raw yamlDefinition
+	if err := yaml.Unmarshal(b, &raw); err != nil {
+		return enumDefinition{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if raw.Type == "" {
+		return enumDefinition{}, fmt.Errorf("%s: type must not be empty", path)
+	}
+	if len(raw.Values) == 0 {
+		return enumDefinition{}, fmt.Errorf("%s: values must not be empty", path)
+	}
+	if raw.Package == "" {
+		raw.Package, err = packageName(dir)
+		if err != nil {
+			return enumDefinition{}, err
+		}
+	}
+
+	def := enumDefinition{Package: raw.Package, Type: raw.Type, DeclareType: true}
+	seen := make(map[string]bool, len(raw.Values))
+	for _, v := range raw.Values {
+		if seen[v] {
+			return enumDefinition{}, fmt.Errorf("%s: duplicate value %q", path, v)
+		}
+		seen[v] = true
+		def.Values = append(def.Values, enumValue{Name: raw.Type + toPascal(v), Value: v})
+	}
+	return def, nil
+}
+
+func loadConsts(dir string, typeName string) (enumDefinition, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && !strings.HasSuffix(fi.Name(), "_enum.go")
+	}, 0)
+	if err != nil {
+		return enumDefinition{}, err
+	}
+
+	for _, pkg := range pkgs {
+		def := enumDefinition{Package: pkg.Name, Type: typeName}
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.CONST {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					vs := spec.(*ast.ValueSpec)
+					ident, ok := vs.Type.(*ast.Ident)
+					if !ok || ident.Name != typeName {
+						continue
+					}
+					for i, name := range vs.Names {
+						if i >= len(vs.Values) {
+							return enumDefinition{}, fmt.Errorf("%s: constant %s has no value", fset.Position(name.Pos()), name.Name)
+						}
+						lit, ok := vs.Values[i].(*ast.BasicLit)
+						if !ok || lit.Kind != token.STRING {
+							return enumDefinition{}, fmt.Errorf("%s: constant %s must be a string literal", fset.Position(name.Pos()), name.Name)
+						}
+						value, err := strconv.Unquote(lit.Value)
+						if err != nil {
+							return enumDefinition{}, err
+						}
+						def.Values = append(def.Values, enumValue{Name: name.Name, Value: value})
+					}
+				}
+			}
+		}
+		if len(def.Values) > 0 {
+			return def, nil
+		}
+	}
+	return enumDefinition{}, fmt.Errorf("no constants of type %s found in %s", typeName, dir)
+}
+
+func packageName(dir string) (string, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, nil, parser.PackageClauseOnly)
+	if err != nil {
+		return "", err
+	}
+	for name := range pkgs {
+		if !strings.HasSuffix(name, "_test") {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("no Go package found in %s", dir)
+}
+
+func render(def enumDefinition) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := enumTemplate.Execute(&buf, def); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+func toPascal(s string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			sb.WriteRune(unicode.ToUpper(r))
+			upper = false
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+func toSnake(s string) string {
+	var sb strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) && i > 0 {
+			sb.WriteByte('_')
+		}
+		sb.WriteRune(unicode.ToLower(r))
+	}
+	return sb.String()
+}
+
+func joinValues(values []enumValue) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = v.Value
+	}
+	return strings.Join(parts, ", ")
+}
+
+var enumTemplate = template.Must(template.New("enum").Funcs(template.FuncMap{
+	"join":  joinValues,
+	"quote": strconv.Quote,
+	"snake": toSnake,
+}).Parse(`// Code generated by enumgen; DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+{{if .DeclareType}}
+type {{.Type}} string
+
+const (
+{{- range .Values}}
+	{{.Name}} {{$.Type}} = {{quote .Value}}
+{{- end}}
+)
+{{end}}
+const _{{.Type}}Expected = {{quote (printf "must be one of %s" (join .Values))}}
+
+var (
+	ErrCode{{.Type}}NotString = RegisterErrorCode(ErrorCode{
+		Code: "{{snake .Type}}.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCode{{.Type}}Empty = RegisterErrorCode(ErrorCode{
+		Code: "{{snake .Type}}.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCode{{.Type}}InvalidValue = RegisterErrorCode(ErrorCode{
+		Code: "{{snake .Type}}.invalid_value", Status: http.StatusBadRequest,
+		Message: _{{.Type}}Expected, Params: []string{"value"}, Example: _{{.Type}}Expected,
+	})
+)
+
+var _{{.Type}}Values = []{{.Type}}{
+{{- range .Values}}
+	{{.Name}},
+{{- end}}
+}
+
+// {{.Type}}Values returns every declared {{.Type}}, in declaration order.
+func {{.Type}}Values() []{{.Type}} {
+	return append([]{{.Type}}(nil), _{{.Type}}Values...)
+}
+
+func (e {{.Type}}) IsValid() bool {
+	switch e {
+	case {{range $i, $v := .Values}}{{if $i}}, {{end}}{{$v.Name}}{{end}}:
+		return true
+	}
+	return false
+}
+
+func (e {{.Type}}) String() string {
+	return string(e)
+}
+
+func (e {{.Type}}) MarshalText() ([]byte, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("invalid {{.Type}} %q", string(e))
+	}
+	return []byte(e), nil
+}
+
+func (e *{{.Type}}) UnmarshalText(b []byte) error {
+	v := {{.Type}}(b)
+	if !v.IsValid() {
+		return errors.New(_{{.Type}}Expected)
+	}
+	*e = v
+	return nil
+}
+
+func (e {{.Type}}) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(e))
+}
+
+func (e *{{.Type}}) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCode{{.Type}}NotString.Err(map[string]string{"value": string(b)})
+	}
+	if s == "" {
+		return ErrCode{{.Type}}Empty.Err(nil)
+	}
+	v := {{.Type}}(s)
+	if !v.IsValid() {
+		return ErrCode{{.Type}}InvalidValue.Err(map[string]string{"value": s})
+	}
+	*e = v
+	return nil
+}
+
+func (e *{{.Type}}) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into {{.Type}}", src)
+	}
+	v := {{.Type}}(s)
+	if !v.IsValid() {
+		return fmt.Errorf("cannot scan %q into {{.Type}}: %s", s, _{{.Type}}Expected)
+	}
+	*e = v
+	return nil
+}
+
+func (e {{.Type}}) Value() (driver.Value, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("invalid {{.Type}} %q", string(e))
+	}
+	return string(e), nil
+}
+`))
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrCodeCompositeKeyNotString = RegisterErrorCode(ErrorCode{
+		Code: "composite_key.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeCompositeKeyEmpty = RegisterErrorCode(ErrorCode{
+		Code: "composite_key.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeCompositeKeyInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "composite_key.invalid_format", Status: http.StatusBadRequest,
+		Message: "format must be tenant:resource:id", Params: []string{"value"}, Example: "format must be tenant:resource:id",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeCompositeKeyTenant = RegisterErrorCode(ErrorCode{
+		Code: "composite_key.tenant", Status: http.StatusBadRequest,
+		Message: "tenant segment must be lowercase letters, digits or dashes", Params: []string{"value"}, Example: "tenant segment must be lowercase letters, digits or dashes",
+	})
+	ErrCodeCompositeKeyResource = RegisterErrorCode(ErrorCode{
+		Code: "composite_key.resource", Status: http.StatusBadRequest,
+		Message: "resource segment must start with a letter and contain only lowercase letters, digits or underscores", Params: []string{"value"}, Example: "resource segment must start with a letter and contain only lowercase letters, digits or underscores",
+	})
+	ErrCodeCompositeKeyID = RegisterErrorCode(ErrorCode{
+		Code: "composite_key.id", Status: http.StatusBadRequest,
+		Message: "id segment must be letters, digits, dashes or underscores", Params: []string{"value"}, Example: "id segment must be letters, digits, dashes or underscores",
+	})
+)
+
+var (
+	compositeKeyTenantPattern   = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+	compositeKeyResourcePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+	compositeKeyIDPattern       = regexp.MustCompile(`^[A-Za-z0-9_-]{1,128}$`)
+)
+
+// CompositeKey identifies a record across tenants as "tenant:resource:id".
+type CompositeKey struct {
+	tenant   string
+	resource string
+	id       string
+}
+
+func NewCompositeKey(tenant string, resource string, id string) (CompositeKey, error) {
+	if !compositeKeyTenantPattern.MatchString(tenant) {
+		return CompositeKey{}, ErrCodeCompositeKeyTenant.Err(map[string]string{"value": tenant})
+	}
+	if !compositeKeyResourcePattern.MatchString(resource) {
+		return CompositeKey{}, ErrCodeCompositeKeyResource.Err(map[string]string{"value": resource})
+	}
+	if !compositeKeyIDPattern.MatchString(id) {
+		return CompositeKey{}, ErrCodeCompositeKeyID.Err(map[string]string{"value": id})
+	}
+	return CompositeKey{tenant: tenant, resource: resource, id: id}, nil
+}
+
+func ParseCompositeKey(s string) (CompositeKey, error) {
+	if s == "" {
+		return CompositeKey{}, ErrCodeCompositeKeyEmpty.Err(nil)
+	}
+	segments := strings.Split(s, ":")
+	if len(segments) != 3 {
+		return CompositeKey{}, ErrCodeCompositeKeyInvalidFormat.Err(map[string]string{"value": s})
+	}
+	return NewCompositeKey(segments[0], segments[1], segments[2])
+}
+
+// CompositeKeyParam reads a route parameter as a CompositeKey, panicking with
+// its coded error, prefixed with the parameter's name, for ErrorMiddleware.
+func CompositeKeyParam(ctx *gin.Context, name string) CompositeKey {
+	key, err := ParseCompositeKey(ctx.Param(name))
+	if err != nil {
+		panic(prefixed(name, err))
+	}
+	return key
+}
+
+func (ck CompositeKey) Tenant() string {
+	return ck.tenant
+}
+
+func (ck CompositeKey) Resource() string {
+	return ck.resource
+}
+
+func (ck CompositeKey) ID() string {
+	return ck.id
+}
+
+func (ck CompositeKey) IsZero() bool {
+	return ck == CompositeKey{}
+}
+
+func (ck CompositeKey) String() string {
+	if ck.IsZero() {
+		return ""
+	}
+	return ck.tenant + ":" + ck.resource + ":" + ck.id
+}
+
+func (ck CompositeKey) MarshalText() ([]byte, error) {
+	return []byte(ck.String()), nil
+}
+
+func (ck *CompositeKey) UnmarshalText(b []byte) error {
+	key, err := ParseCompositeKey(string(b))
+	if err != nil {
+		return err
+	}
+	*ck = key
+	return nil
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (ck CompositeKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ck.String())
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (ck *CompositeKey) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeCompositeKeyNotString.Err(map[string]string{"value": string(b)})
+	}
+	key, err := ParseCompositeKey(s)
+	if err != nil {
+		return err
+	}
+
+	*ck = key
+
+	return nil
+}
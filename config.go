@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// Defaults that LoadConfig can change. They are read on every parse and
+// marshal, so set them (or call LoadConfig) before serving requests.
+var (
+	// DateTimeLayouts are the layouts DateTime accepts, tried in order. The
+	// first one is also the output format.
+	DateTimeLayouts = []string{time.RFC3339}
+
+	// DefaultDateTimePrecision applies to every DateTime without its own,
+	// see DateTime.WithPrecision.
+	DefaultDateTimePrecision = DateTimeSeconds
+
+	// DateTimeOutputUTC converts every DateTime to UTC when printing it, so
+	// "2020-01-01T02:02:05+07:00" comes back as "2019-12-31T19:02:05Z".
+	// Parsed values still keep the offset they were sent with.
+	DateTimeOutputUTC = false
+
+	// DateTimeDefaultLocation, when set, makes DateTime also accept input
+	// without an offset, in DateTimeLocalLayouts, as wall-clock time there.
+	// Nil (the default) requires an offset. See also
+	// DateTime.WithDefaultLocation.
+	DateTimeDefaultLocation *time.Location
+	DateTimeLocalLayouts    = []string{"2006-01-02T15:04:05", "2006-01-02 15:04:05"}
+
+	// DateTimeLazyParsing makes DateTime.UnmarshalJSON only check that RFC
+	// 3339 input is well formed and keep the text, parsing it when the value
+	// is first used. A value that is only marshaled again is never parsed.
+	DateTimeLazyParsing = false
+
+	// ArrayStringSeparator splits and joins ArrayString values.
+	ArrayStringSeparator = ","
+
+	// DisplayLocale is the language of CountryCode.Name and LanguageTag.Name.
+	DisplayLocale = language.English
+
+	// StrictParsing turns off the lenient input forms: FlexibleBool only
+	// takes JSON booleans, Semver rejects a "v" prefix and DateTime only
+	// accepts the first of DateTimeLayouts.
+	StrictParsing = false
+
+	// MessageOverrides replaces client-facing error messages, keyed by the
+	// built-in message, e.g. "must not be empty": "is required". It can be
+	// reloaded at runtime, see Catalog.
+	MessageOverrides = NewCatalog("messages", map[string]string{})
+
+	// MessageTemplates replaces the message of an error code, keyed by the
+	// code, e.g. "datetime.invalid_format": "{value} is not a {layout} time".
+	// A template may use the code's Params.
+	MessageTemplates = NewCatalog("message_templates", map[string]string{})
+)
+
+// TypeConfig is the file format read by LoadConfig, as YAML or JSON:
+//
+//	datetime_layouts: ["2006-01-02T15:04:05Z07:00", "2006-01-02 15:04:05Z07:00"]
+//	datetime_precision: millis
+//	datetime_utc: true
+//	datetime_location: Asia/Jakarta
+//	datetime_lazy: true
+//	locale: id
+//	array_separator: ";"
+//	strict: true
+//	messages:
+//	  must not be empty: wajib diisi
+//	message_templates:
+//	  datetime.invalid_format: "{value} is not a {layout} time"
+//
+// Keys that are left out keep their current value.
+type TypeConfig struct {
+	DateTimeLayouts   []string          `json:"datetime_layouts" yaml:"datetime_layouts"`
+	DateTimePrecision string            `json:"datetime_precision" yaml:"datetime_precision"`
+	DateTimeUTC       *bool             `json:"datetime_utc" yaml:"datetime_utc"`
+	DateTimeLocation  string            `json:"datetime_location" yaml:"datetime_location"`
+	DateTimeLazy      *bool             `json:"datetime_lazy" yaml:"datetime_lazy"`
+	Locale            string            `json:"locale" yaml:"locale"`
+	ArraySeparator    *string           `json:"array_separator" yaml:"array_separator"`
+	Strict            *bool             `json:"strict" yaml:"strict"`
+	Messages          map[string]string `json:"messages" yaml:"messages"`
+	MessageTemplates  map[string]string `json:"message_templates" yaml:"message_templates"`
+}
+
+// LoadConfig reads a TypeConfig from path (".json" files as JSON, anything
+// else as YAML), validates it and applies it. Nothing is applied when the
+// file is invalid.
+func LoadConfig(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var cfg TypeConfig
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		decoder := json.NewDecoder(bytes.NewReader(b))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&cfg)
+	} else {
+		err = unmarshalYAMLStrict(b, &cfg)
+	}
+	if err != nil {
+		return fmt.Errorf("config %s: %w", path, err)
+	}
+
+	if err := ApplyConfig(cfg); err != nil {
+		return fmt.Errorf("config %s: %w", path, err)
+	}
+	return nil
+}
+
+// ApplyConfig validates cfg and copies the keys it sets into the package
+// defaults.
+func ApplyConfig(cfg TypeConfig) error {
+	for _, layout := range cfg.DateTimeLayouts {
+		// A layout without any reference-time element would accept only
+		// itself; that is always a typo.
+		if time.Date(2017, 11, 28, 21, 35, 47, 0, time.UTC).Format(layout) == layout {
+			return fmt.Errorf("datetime_layouts: %q is not a Go time layout", layout)
+		}
+	}
+	var precision DateTimePrecision
+	if cfg.DateTimePrecision != "" {
+		var err error
+		if precision, err = ParseDateTimePrecision(cfg.DateTimePrecision); err != nil {
+			return fmt.Errorf("datetime_precision: %w", err)
+		}
+	}
+	var location *time.Location
+	if cfg.DateTimeLocation != "" {
+		var err error
+		if location, err = time.LoadLocation(cfg.DateTimeLocation); err != nil {
+			return fmt.Errorf("datetime_location: %w", err)
+		}
+	}
+	var locale language.Tag
+	if cfg.Locale != "" {
+		var err error
+		if locale, err = language.Parse(cfg.Locale); err != nil {
+			return fmt.Errorf("locale: %w", err)
+		}
+	}
+	for code := range cfg.MessageTemplates {
+		if _, ok := LookupErrorCode(code); !ok {
+			return fmt.Errorf("message_templates: %q is not a registered error code", code)
+		}
+	}
+	if cfg.ArraySeparator != nil && *cfg.ArraySeparator == "" {
+		return errors.New("array_separator: must not be empty")
+	}
+
+	if len(cfg.DateTimeLayouts) > 0 {
+		DateTimeLayouts = cfg.DateTimeLayouts
+	}
+	if precision != 0 {
+		DefaultDateTimePrecision = precision
+	}
+	if cfg.DateTimeUTC != nil {
+		DateTimeOutputUTC = *cfg.DateTimeUTC
+	}
+	if location != nil {
+		DateTimeDefaultLocation = location
+	}
+	if cfg.DateTimeLazy != nil {
+		DateTimeLazyParsing = *cfg.DateTimeLazy
+	}
+	if cfg.Locale != "" {
+		DisplayLocale = locale
+	}
+	if cfg.ArraySeparator != nil {
+		ArrayStringSeparator = *cfg.ArraySeparator
+	}
+	if cfg.Strict != nil {
+		StrictParsing = *cfg.Strict
+	}
+	if len(cfg.Messages) > 0 {
+		messages := map[string]string{}
+		for message, override := range MessageOverrides.Get() {
+			messages[message] = override
+		}
+		for message, override := range cfg.Messages {
+			messages[message] = override
+		}
+		MessageOverrides.Swap(messages)
+	}
+	if len(cfg.MessageTemplates) > 0 {
+		templates := map[string]string{}
+		for code, template := range MessageTemplates.Get() {
+			templates[code] = template
+		}
+		for code, template := range cfg.MessageTemplates {
+			templates[code] = template
+		}
+		MessageTemplates.Swap(templates)
+	}
+	return nil
+}
+
+// clientMessage applies MessageOverrides to a message about to be sent.
+func clientMessage(message string) string {
+	if override, ok := MessageOverrides.Get()[message]; ok {
+		return override
+	}
+	return message
+}
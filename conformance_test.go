@@ -0,0 +1,197 @@
+package main
+
+import (
+	"testing"
+
+	"myapp/typetest"
+)
+
+// allowBadRequest accepts the BadRequestError panics the types still
+// reject input with.
+func allowBadRequest(recovered interface{}) bool {
+	_, ok := recovered.(BadRequestError)
+	return ok
+}
+
+var (
+	dateTimeCodec = typetest.Codec[DateTime]{
+		Valid:      []string{`"2020-01-01T02:02:05+07:00"`, `"2020-01-01T02:02:05Z"`, `"2020-01-01T02:02:05.123+07:00"`},
+		Invalid:    []string{`""`, `true`, `"2020-01-01"`, `"wrong-format"`, `"2020-02-30T00:00:00Z"`},
+		AllowPanic: allowBadRequest,
+	}
+	nullDateTimeCodec = typetest.Codec[NullDateTime]{
+		Valid:      []string{`"2020-01-01T02:02:05+07:00"`, `"2020-01-01T02:02:05Z"`, `null`},
+		Invalid:    []string{`true`, `"2020-01-01"`, `"wrong-format"`},
+		AllowPanic: allowBadRequest,
+	}
+	dateCodec = typetest.Codec[Date]{
+		Valid:      []string{`"2024-03-08"`, `"2024-02-29"`},
+		Invalid:    []string{`""`, `1`, `"2023-02-29"`, `"08-03-2024"`},
+		AllowPanic: allowBadRequest,
+	}
+	arrayStringCodec = typetest.Codec[ArrayString]{
+		Valid:      []string{`"a,b,c"`, `"gift"`},
+		Invalid:    []string{`""`, `true`, `[]`, `[1]`},
+		AllowPanic: allowBadRequest,
+	}
+	durationCodec = typetest.Codec[Duration]{
+		Valid:      []string{`"1h30m"`, `"90s"`, `"0s"`},
+		Invalid:    []string{`""`, `true`, `"soon"`, `1e300`, `9.3e9`, `-9.3e9`},
+		AllowPanic: allowBadRequest,
+	}
+	periodCodec = typetest.Codec[Period]{
+		Valid:      []string{`"P1DT2H30M"`, `"P1Y2M"`, `"PT0S"`},
+		Invalid:    []string{`""`, `true`, `"1 day"`, `"P"`, `"P99999999999999999999D"`},
+		AllowPanic: allowBadRequest,
+	}
+	stringInt64Codec = typetest.Codec[StringInt64]{
+		Valid:      []string{`"9007199254740993"`, `"-42"`, `42`},
+		Invalid:    []string{`""`, `true`, `"4.2"`, `"9223372036854775808"`},
+		AllowPanic: allowBadRequest,
+	}
+	nullStringCodec = typetest.Codec[NullString]{
+		Valid:      []string{`"Devi"`, `""`, `null`},
+		Invalid:    []string{`true`, `1`},
+		AllowPanic: allowBadRequest,
+	}
+	nullInt64Codec = typetest.Codec[NullInt64]{
+		Valid:      []string{`42`, `-1`, `null`},
+		Invalid:    []string{`"42"`, `4.2`, `true`},
+		AllowPanic: allowBadRequest,
+	}
+	flexibleBoolCodec = typetest.Codec[FlexibleBool]{
+		Valid:      []string{`true`, `"yes"`, `"0"`, `1`},
+		Invalid:    []string{`""`, `"maybe"`, `2`},
+		AllowPanic: allowBadRequest,
+	}
+	byteSizeCodec = typetest.Codec[ByteSize]{
+		Valid:      []string{`"1.5MiB"`, `"10KB"`, `1024`},
+		Invalid:    []string{`""`, `true`, `"lots"`, `-1`},
+		AllowPanic: allowBadRequest,
+	}
+	percentageCodec = typetest.Codec[Percentage]{
+		Valid:      []string{`"12.5%"`, `12.5`, `0`},
+		Invalid:    []string{`""`, `true`, `"-1%"`, `"101%"`},
+		AllowPanic: allowBadRequest,
+	}
+	ipAddressCodec = typetest.Codec[IPAddress]{
+		Valid:      []string{`"10.1.2.3"`, `"2001:db8::1"`},
+		Invalid:    []string{`""`, `true`, `"10.1.2"`, `"localhost"`},
+		AllowPanic: allowBadRequest,
+	}
+	timezoneCodec = typetest.Codec[Timezone]{
+		Valid:      []string{`"Asia/Jakarta"`, `"UTC"`},
+		Invalid:    []string{`""`, `true`, `"Mars/Olympus"`},
+		AllowPanic: allowBadRequest,
+	}
+	semverCodec = typetest.Codec[Semver]{
+		Valid:      []string{`"1.2.3"`, `"1.0.0-rc.1+build.5"`},
+		Invalid:    []string{`""`, `true`, `"1.2"`, `"01.2.3"`},
+		AllowPanic: allowBadRequest,
+	}
+	orderStatusCodec = typetest.Codec[OrderStatus]{
+		Valid:      []string{`"active"`, `"closed"`},
+		Invalid:    []string{`""`, `true`, `"archived"`},
+		AllowPanic: allowBadRequest,
+	}
+	// Priority is generated by cmd/enumgen and returns its errors.
+	priorityCodec = typetest.Codec[Priority]{
+		Valid:   []string{`"low"`, `"urgent"`},
+		Invalid: []string{`""`, `true`, `"asap"`},
+	}
+	obfuscatedIDCodec = typetest.Codec[ObfuscatedID]{
+		Valid:      []string{`"BEAsrn5Q"`},
+		Invalid:    []string{`""`, `42`, `"42"`, `"BEAsrn5R"`},
+		AllowPanic: allowBadRequest,
+	}
+	trimmedStringCodec = typetest.Codec[TrimmedString]{
+		Valid:      []string{`"  SKU-001\n"`, `"SKU-001"`},
+		Invalid:    []string{`true`},
+		AllowPanic: allowBadRequest,
+	}
+	geoPointCodec = typetest.Codec[GeoPoint]{
+		Valid:      []string{`{"lat":-6.2,"lng":106.8}`, `"-6.2,106.8"`},
+		Invalid:    []string{`""`, `true`, `{"lat":91,"lng":0}`},
+		AllowPanic: allowBadRequest,
+	}
+)
+
+func TestDateTimeConformance(t *testing.T) {
+	typetest.Conformance(t, dateTimeCodec)
+}
+
+func TestNullDateTimeConformance(t *testing.T) {
+	typetest.Conformance(t, nullDateTimeCodec)
+}
+
+func TestDateConformance(t *testing.T) {
+	typetest.Conformance(t, dateCodec)
+}
+
+func TestArrayStringConformance(t *testing.T) {
+	typetest.Conformance(t, arrayStringCodec)
+}
+
+func TestDurationConformance(t *testing.T) {
+	typetest.Conformance(t, durationCodec)
+}
+
+func TestPeriodConformance(t *testing.T) {
+	typetest.Conformance(t, periodCodec)
+}
+
+func TestStringInt64Conformance(t *testing.T) {
+	typetest.Conformance(t, stringInt64Codec)
+}
+
+func TestNullStringConformance(t *testing.T) {
+	typetest.Conformance(t, nullStringCodec)
+}
+
+func TestNullInt64Conformance(t *testing.T) {
+	typetest.Conformance(t, nullInt64Codec)
+}
+
+func TestFlexibleBoolConformance(t *testing.T) {
+	typetest.Conformance(t, flexibleBoolCodec)
+}
+
+func TestByteSizeConformance(t *testing.T) {
+	typetest.Conformance(t, byteSizeCodec)
+}
+
+func TestPercentageConformance(t *testing.T) {
+	typetest.Conformance(t, percentageCodec)
+}
+
+func TestIPAddressConformance(t *testing.T) {
+	typetest.Conformance(t, ipAddressCodec)
+}
+
+func TestTimezoneConformance(t *testing.T) {
+	typetest.Conformance(t, timezoneCodec)
+}
+
+func TestSemverConformance(t *testing.T) {
+	typetest.Conformance(t, semverCodec)
+}
+
+func TestOrderStatusConformance(t *testing.T) {
+	typetest.Conformance(t, orderStatusCodec)
+}
+
+func TestPriorityConformance(t *testing.T) {
+	typetest.Conformance(t, priorityCodec)
+}
+
+func TestObfuscatedIDConformance(t *testing.T) {
+	typetest.Conformance(t, obfuscatedIDCodec)
+}
+
+func TestTrimmedStringConformance(t *testing.T) {
+	typetest.Conformance(t, trimmedStringCodec)
+}
+
+func TestGeoPointConformance(t *testing.T) {
+	typetest.Conformance(t, geoPointCodec)
+}
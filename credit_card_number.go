@@ -0,0 +1,180 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrCodeCreditCardNotString = RegisterErrorCode(ErrorCode{
+		Code: "credit_card.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeCreditCardEmpty = RegisterErrorCode(ErrorCode{
+		Code: "credit_card.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeCreditCardLength = RegisterErrorCode(ErrorCode{
+		Code: "credit_card.length", Status: http.StatusBadRequest,
+		Message: "must be between 12 and 19 digits", Example: "must be between 12 and 19 digits",
+	})
+	ErrCodeCreditCardInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "credit_card.invalid_format", Status: http.StatusBadRequest,
+		Message: "must only contain digits, spaces or dashes", Example: "must only contain digits, spaces or dashes",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeCreditCardChecksum = RegisterErrorCode(ErrorCode{
+		Code: "credit_card.checksum", Status: http.StatusBadRequest,
+		Message: "must be a valid card number", Example: "must be a valid card number",
+	})
+)
+
+type CardBrand string
+
+const (
+	CardBrandUnknown    CardBrand = "unknown"
+	CardBrandVisa       CardBrand = "visa"
+	CardBrandMastercard CardBrand = "mastercard"
+	CardBrandAmex       CardBrand = "amex"
+	CardBrandDiscover   CardBrand = "discover"
+	CardBrandJCB        CardBrand = "jcb"
+	CardBrandDiners     CardBrand = "diners"
+	CardBrandUnionPay   CardBrand = "unionpay"
+)
+
+var cardNumberMask = MaskGrouped(4, 4, "-")
+
+// CreditCardNumber keeps the digits of a card number (spaces and dashes are
+// stripped on input) and is only ever marshaled or printed masked.
+type CreditCardNumber struct {
+	digits string
+}
+
+func ParseCreditCardNumber(s string) (CreditCardNumber, error) {
+	digits := strings.NewReplacer(" ", "", "-", "").Replace(s)
+	if len(digits) < 12 || len(digits) > 19 {
+		return CreditCardNumber{}, ErrCodeCreditCardLength.Err(nil)
+	}
+	for i := 0; i < len(digits); i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return CreditCardNumber{}, ErrCodeCreditCardInvalidFormat.Err(nil)
+		}
+	}
+	if !luhnValid(digits) {
+		return CreditCardNumber{}, ErrCodeCreditCardChecksum.Err(nil)
+	}
+	return CreditCardNumber{digits: digits}, nil
+}
+
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// Brand detects the card network from the IIN prefix.
+func (cc CreditCardNumber) Brand() CardBrand {
+	d := cc.digits
+	if len(d) < 6 {
+		return CardBrandUnknown
+	}
+	prefix := func(n int) int {
+		v, _ := strconv.Atoi(d[:n])
+		return v
+	}
+
+	switch p2, p3, p4 := prefix(2), prefix(3), prefix(4); {
+	case d[0] == '4':
+		return CardBrandVisa
+	case p2 >= 51 && p2 <= 55, p4 >= 2221 && p4 <= 2720:
+		return CardBrandMastercard
+	case p2 == 34 || p2 == 37:
+		return CardBrandAmex
+	case p4 == 6011, p2 == 65, p3 >= 644 && p3 <= 649:
+		return CardBrandDiscover
+	case p4 >= 3528 && p4 <= 3589:
+		return CardBrandJCB
+	case p2 == 36, p2 == 38, p2 == 39, p3 >= 300 && p3 <= 305:
+		return CardBrandDiners
+	case p2 == 62:
+		return CardBrandUnionPay
+	}
+	return CardBrandUnknown
+}
+
+func (cc CreditCardNumber) Last4() string {
+	if len(cc.digits) < 4 {
+		return ""
+	}
+	return cc.digits[len(cc.digits)-4:]
+}
+
+// UnsafeNumber returns the full card number, e.g. to hand to a payment
+// gateway. It is the only accessor that does so.
+func (cc CreditCardNumber) UnsafeNumber() string {
+	return cc.digits
+}
+
+func (cc CreditCardNumber) String() string {
+	if cc.digits == "" {
+		return ""
+	}
+	return cardNumberMask(cc.digits)
+}
+
+func (cc CreditCardNumber) GoString() string {
+	return cc.String()
+}
+
+func (cc CreditCardNumber) IsZero() bool {
+	return cc.digits == ""
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (cc CreditCardNumber) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cc.String())
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (cc *CreditCardNumber) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeCreditCardNotString.Err(nil)
+	}
+	if s == "" {
+		return ErrCodeCreditCardEmpty.Err(nil)
+	}
+	parsed, err := ParseCreditCardNumber(s)
+	if err != nil {
+		return err
+	}
+
+	*cc = parsed
+
+	return nil
+}
@@ -0,0 +1,307 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrCodeCronNotString = RegisterErrorCode(ErrorCode{
+		Code: "cron.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeCronEmpty = RegisterErrorCode(ErrorCode{
+		Code: "cron.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeCronFieldCount = RegisterErrorCode(ErrorCode{
+		Code: "cron.field_count", Status: http.StatusBadRequest,
+		Message: "must have 5 fields (minute hour day month weekday) or 6 with leading seconds, got {count}", Params: []string{"count", "value"}, Example: "must have 5 fields (minute hour day month weekday) or 6 with leading seconds, got 4",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeCronStep = RegisterErrorCode(ErrorCode{
+		Code: "cron.step", Status: http.StatusBadRequest,
+		Message: "step must be a positive number", Example: "step must be a positive number",
+	})
+	ErrCodeCronReversedRange = RegisterErrorCode(ErrorCode{
+		Code: "cron.reversed_range", Status: http.StatusBadRequest,
+		Message: "range start {start} is after end {end}", Params: []string{"start", "end"}, Example: "range start 5 is after end 1",
+	})
+	ErrCodeCronNotNumber = RegisterErrorCode(ErrorCode{
+		Code: "cron.not_number", Status: http.StatusBadRequest,
+		Message: "{value} is not a number", Params: []string{"value"}, Example: "\"x\" is not a number",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeCronOutOfRange = RegisterErrorCode(ErrorCode{
+		Code: "cron.out_of_range", Status: http.StatusBadRequest,
+		Message: "value {value} is out of range {min}-{max}", Params: []string{"value", "min", "max"}, Example: "value 24 is out of range 0-23",
+	})
+)
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names []string
+}
+
+var (
+	cronSecond  = cronField{name: "second", min: 0, max: 59}
+	cronMinute  = cronField{name: "minute", min: 0, max: 59}
+	cronHour    = cronField{name: "hour", min: 0, max: 23}
+	cronDay     = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth   = cronField{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}}
+	cronWeekday = cronField{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}}
+)
+
+const cronEveryHour = 1<<24 - 1
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// CronExpression is a standard 5-field cron schedule ("*/15 9-17 * * MON-FRI")
+// or a 6-field one with a leading seconds field. Month and weekday names and
+// the @daily style macros are accepted; the source is marshaled unchanged.
+type CronExpression struct {
+	source string
+
+	// Each field is a bit set of allowed values.
+	second, minute, hour, day, month, weekday uint64
+
+	// Cron matches either day field when both are restricted, e.g.
+	// "0 0 1 * MON" runs on the 1st and on every Monday.
+	dayStar, weekdayStar bool
+}
+
+func ParseCronExpression(s string) (CronExpression, error) {
+	expr := strings.TrimSpace(s)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return CronExpression{}, ErrCodeCronFieldCount.Err(map[string]string{"count": strconv.Itoa(len(fields)), "value": s})
+	}
+
+	c := CronExpression{source: s}
+	specs := []struct {
+		field cronField
+		bits  *uint64
+	}{
+		{cronSecond, &c.second},
+		{cronMinute, &c.minute},
+		{cronHour, &c.hour},
+		{cronDay, &c.day},
+		{cronMonth, &c.month},
+		{cronWeekday, &c.weekday},
+	}
+	for i, spec := range specs {
+		b, err := parseCronField(fields[i], spec.field)
+		if err != nil {
+			return CronExpression{}, prefixed(fmt.Sprintf("%s field %q:", spec.field.name, fields[i]), err)
+		}
+		*spec.bits = b
+	}
+
+	// 7 is an alias of Sunday.
+	if c.weekday&(1<<7) != 0 {
+		c.weekday = c.weekday&^(1<<7) | 1
+	}
+	c.dayStar = strings.HasPrefix(fields[3], "*") || fields[3] == "?"
+	c.weekdayStar = strings.HasPrefix(fields[5], "*") || fields[5] == "?"
+	return c, nil
+}
+
+func parseCronField(s string, field cronField) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(s, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n < 1 {
+				return 0, ErrCodeCronStep.Err(nil)
+			}
+			step = n
+		}
+
+		lo, hi := field.min, field.max
+		switch {
+		case rangePart == "*" || rangePart == "?":
+		case strings.Contains(rangePart, "-"):
+			loStr, hiStr, _ := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = parseCronValue(loStr, field); err != nil {
+				return 0, err
+			}
+			if hi, err = parseCronValue(hiStr, field); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, ErrCodeCronReversedRange.Err(map[string]string{"start": strconv.Itoa(lo), "end": strconv.Itoa(hi)})
+			}
+		default:
+			var err error
+			if lo, err = parseCronValue(rangePart, field); err != nil {
+				return 0, err
+			}
+			// "5/15" means from 5 to the end, every 15.
+			if !hasStep {
+				hi = lo
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+	return set, nil
+}
+
+func parseCronValue(s string, field cronField) (int, error) {
+	for i, name := range field.names {
+		if strings.EqualFold(s, name) {
+			return i + field.min, nil
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, ErrCodeCronNotNumber.Err(map[string]string{"value": strconv.Quote(s)})
+	}
+	if n < field.min || n > field.max {
+		return 0, ErrCodeCronOutOfRange.Err(map[string]string{"value": strconv.Itoa(n), "min": strconv.Itoa(field.min), "max": strconv.Itoa(field.max)})
+	}
+	return n, nil
+}
+
+func (c CronExpression) matchesDay(t time.Time) bool {
+	dayMatch := c.day&(1<<uint(t.Day())) != 0
+	weekdayMatch := c.weekday&(1<<uint(t.Weekday())) != 0
+	if c.dayStar || c.weekdayStar {
+		return dayMatch && weekdayMatch
+	}
+	return dayMatch || weekdayMatch
+}
+
+// Next returns the first time strictly after the given one that matches,
+// in after's location. It returns the zero time when nothing matches within
+// five years (e.g. "0 0 30 2 *"). Like cron itself, a job whose time falls
+// in a spring-forward gap is skipped that day, and one inside the repeated
+// fall-back hour only fires in its first pass unless it runs every hour.
+func (c CronExpression) Next(after time.Time) time.Time {
+	loc := after.Location()
+	t := after.Truncate(time.Second).Add(time.Second)
+	limit := t.AddDate(5, 0, 0)
+
+	// startOf resolves the start of an hour like AddDaysWallClock does, so a
+	// gap never sends t backwards.
+	startOf := func(year int, month time.Month, day int, hour int) time.Time {
+		return resolveWallClock(year, month, day, hour, 0, 0, 0, loc)
+	}
+	// step moves t to next, skipping the second pass of a repeated hour.
+	step := func(next time.Time) {
+		if back := wallClockOf(t).Sub(wallClockOf(next)); back > 0 && c.hour != cronEveryHour {
+			next = next.Add(back)
+		}
+		t = next
+	}
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = startOf(t.Year(), t.Month()+1, 1, 0)
+			continue
+		}
+		if !c.matchesDay(t) {
+			t = startOf(t.Year(), t.Month(), t.Day()+1, 0)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = startOf(t.Year(), t.Month(), t.Day(), t.Hour()+1)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			step(t.Truncate(time.Minute).Add(time.Minute))
+			continue
+		}
+		if c.second&(1<<uint(t.Second())) == 0 {
+			step(t.Add(time.Second))
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// NextN returns up to n upcoming run times, for schedule previews.
+func (c CronExpression) NextN(after time.Time, n int) []time.Time {
+	times := make([]time.Time, 0, n)
+	for len(times) < n {
+		after = c.Next(after)
+		if after.IsZero() {
+			break
+		}
+		times = append(times, after)
+	}
+	return times
+}
+
+func (c CronExpression) String() string {
+	return c.source
+}
+
+func (c CronExpression) IsZero() bool {
+	return c.source == ""
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (c CronExpression) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.source)
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (c *CronExpression) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeCronNotString.Err(map[string]string{"value": string(b)})
+	}
+	if s == "" {
+		return ErrCodeCronEmpty.Err(nil)
+	}
+	parsed, err := ParseCronExpression(s)
+	if err != nil {
+		return err
+	}
+
+	*c = parsed
+
+	return nil
+}
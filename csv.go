@@ -0,0 +1,217 @@
+package main
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The types implement gocarina/gocsv's TypeMarshaller and TypeUnmarshaller,
+// so a report struct marshals with gocsv, and WriteCSV and ReadCSV below do
+// the same with encoding/csv alone. A cell holds the text form, the JSON
+// value without quotes, and is read with the validation and messages of a
+// JSON body:
+//
+//	type ReportShipment struct {
+//		ID       StringInt64 `csv:"id"`
+//		ShipAt   DateTime    `csv:"ship_at"`
+//		Tags     ArrayString `csv:"tags"`
+//		Weight   ByteSize    `csv:"weight"`
+//		Customer string      `csv:"customer"`
+//	}
+//
+// ArrayString's separator is a comma as well; encoding/csv quotes the cell.
+
+/*
+	This part implements gocsv's `TypeMarshaller`
+	type TypeMarshaller interface {
+		MarshalCSV() (string, error)
+	}
+*/
+func (dt DateTime) MarshalCSV() (string, error) { return marshalCSVAsText(dt) }
+
+/*
+	This part implements gocsv's `TypeUnmarshaller`
+	type TypeUnmarshaller interface {
+		UnmarshalCSV(string) error
+	}
+*/
+func (dt *DateTime) UnmarshalCSV(s string) error { return unmarshalTextAsJSON(dt, []byte(s)) }
+
+func (d Date) MarshalCSV() (string, error)  { return marshalCSVAsText(d) }
+func (d *Date) UnmarshalCSV(s string) error { return unmarshalTextAsJSON(d, []byte(s)) }
+
+func (d Duration) MarshalCSV() (string, error)  { return marshalCSVAsText(d) }
+func (d *Duration) UnmarshalCSV(s string) error { return unmarshalTextAsJSON(d, []byte(s)) }
+
+func (dt ArrayString) MarshalCSV() (string, error)  { return marshalCSVAsText(dt) }
+func (dt *ArrayString) UnmarshalCSV(s string) error { return unmarshalTextAsJSON(dt, []byte(s)) }
+
+func (si StringInt64) MarshalCSV() (string, error)  { return marshalCSVAsText(si) }
+func (si *StringInt64) UnmarshalCSV(s string) error { return unmarshalTextAsJSON(si, []byte(s)) }
+
+func (id ObfuscatedID) MarshalCSV() (string, error)  { return marshalCSVAsText(id) }
+func (id *ObfuscatedID) UnmarshalCSV(s string) error { return unmarshalTextAsJSON(id, []byte(s)) }
+
+func (bs ByteSize) MarshalCSV() (string, error)  { return marshalCSVAsText(bs) }
+func (bs *ByteSize) UnmarshalCSV(s string) error { return unmarshalTextAsJSON(bs, []byte(s)) }
+
+func (p Percentage) MarshalCSV() (string, error)  { return marshalCSVAsText(p) }
+func (p *Percentage) UnmarshalCSV(s string) error { return unmarshalTextAsJSON(p, []byte(s)) }
+
+func (bp BasisPoints) MarshalCSV() (string, error)  { return marshalCSVAsText(bp) }
+func (bp *BasisPoints) UnmarshalCSV(s string) error { return unmarshalTextAsJSON(bp, []byte(s)) }
+
+func (tz Timezone) MarshalCSV() (string, error)  { return marshalCSVAsText(tz) }
+func (tz *Timezone) UnmarshalCSV(s string) error { return unmarshalTextAsJSON(tz, []byte(s)) }
+
+func (v Semver) MarshalCSV() (string, error)  { return marshalCSVAsText(v) }
+func (v *Semver) UnmarshalCSV(s string) error { return unmarshalTextAsJSON(v, []byte(s)) }
+
+func (ip IPAddress) MarshalCSV() (string, error)  { return marshalCSVAsText(ip) }
+func (ip *IPAddress) UnmarshalCSV(s string) error { return unmarshalTextAsJSON(ip, []byte(s)) }
+
+func marshalCSVAsText(v encoding.TextMarshaler) (string, error) {
+	b, err := v.MarshalText()
+	return string(b), err
+}
+
+type csvMarshaler interface {
+	MarshalCSV() (string, error)
+}
+
+// csvColumn is a top-level exported field and its `csv` tag name.
+type csvColumn struct {
+	index int
+	name  string
+}
+
+func csvColumns(t reflect.Type) []csvColumn {
+	var columns []csvColumn
+	for i := 0; i < t.NumField(); i++ {
+		spec := t.Field(i)
+		name, _, _ := strings.Cut(spec.Tag.Get("csv"), ",")
+		if name == "-" || !spec.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = spec.Name
+		}
+		columns = append(columns, csvColumn{index: i, name: name})
+	}
+	return columns
+}
+
+// WriteCSV writes a header of the `csv` tag names, or the field names,
+// followed by one record per row. A nil pointer is an empty cell.
+func WriteCSV[T any](w io.Writer, rows []T) error {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		panic("WriteCSV needs a slice of structs, got " + t.String())
+	}
+	columns := csvColumns(t)
+
+	cw := csv.NewWriter(w)
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		record[i] = column.name
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		v := reflect.ValueOf(row)
+		for i, column := range columns {
+			cell, err := csvCell(v.Field(column.index))
+			if err != nil {
+				return fmt.Errorf("%s: %w", column.name, err)
+			}
+			record[i] = cell
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvCell(field reflect.Value) (string, error) {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return "", nil
+		}
+		field = field.Elem()
+	}
+	if m, ok := field.Interface().(csvMarshaler); ok {
+		return m.MarshalCSV()
+	}
+	return formatText(field)
+}
+
+// ReadCSV reads a header and binds every record by its `csv` tags the way
+// BindQuery binds a query string: the custom types are decoded as JSON
+// strings, with ctype tags, and the struct is validated. Columns are
+// matched by name, and an empty cell leaves the field unset.
+//
+// Every bad record is reported, in one FieldErrors whose Field is the line
+// and column, e.g. "3.ship_at"; the good rows are returned with it. A
+// malformed file stops at the *csv.ParseError.
+func ReadCSV[T any](r io.Reader) ([]T, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		panic("ReadCSV needs a struct type, got " + t.String())
+	}
+
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []T
+	var fe FieldErrors
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return rows, err
+		}
+		line, _ := cr.FieldPos(0)
+
+		values := make(map[string][]string, len(header))
+		for i, name := range header {
+			if record[i] != "" {
+				values[name] = []string{record[i]}
+			}
+		}
+		var row T
+		if err := bindValues(values, "csv", &row); err != nil {
+			var rowErrs FieldErrors
+			if !errors.As(TranslateValidationErrors(err), &rowErrs) {
+				fe = append(fe, newFieldError(strconv.Itoa(line), err))
+				continue
+			}
+			for _, e := range rowErrs {
+				e.Field = strconv.Itoa(line) + "." + e.Field
+				fe = append(fe, e)
+			}
+			continue
+		}
+		rows = append(rows, row)
+	}
+	if len(fe) > 0 {
+		return rows, fe
+	}
+	return rows, nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Bind is ctx.ShouldBind for JSON bodies that also reads `ctype` struct
+// tags, which set type options for a single field:
+//
+//	type RequestContentReport struct {
+//		Day  DateTime    `json:"day" ctype:"layout=2006-01-02,loc=Asia/Jakarta"`
+//		Tags ArrayString `json:"tags" ctype:"sep=;,trim"`
+//	}
+//
+// DateTime takes layout (replacing DateTimeLayouts for input and output),
+// loc (see DateTime.WithDefaultLocation) and precision (seconds, millis,
+// micros or nanos). ArrayString takes sep and trim; sep cannot be a comma.
+// Tags are read on top-level fields. An invalid tag is a programming error
+// and panics. Bind is ctx.ShouldBindWith(obj, JSONBinding), so every invalid
+// field is reported at once.
+func Bind(ctx *gin.Context, obj interface{}) error {
+	return ctx.ShouldBindWith(obj, JSONBinding)
+}
+
+// ctypeOptions splits "layout=2006-01-02,trim" into {"layout": "2006-01-02",
+// "trim": ""}.
+func ctypeOptions(tag string) map[string]string {
+	options := map[string]string{}
+	for _, option := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(option, "=")
+		options[strings.TrimSpace(key)] = value
+	}
+	return options
+}
+
+func applyCtype(field reflect.Value, spec reflect.StructField, raw json.RawMessage) error {
+	options := ctypeOptions(spec.Tag.Get("ctype"))
+	invalid := func(format string, args ...interface{}) {
+		panic(fmt.Sprintf("ctype: field %s: ", spec.Name) + fmt.Sprintf(format, args...))
+	}
+
+	switch target := field.Addr().Interface().(type) {
+	case *DateTime:
+		preset := *target
+		for key, value := range options {
+			switch key {
+			case "layout":
+				preset.layout = value
+			case "loc":
+				loc, err := time.LoadLocation(value)
+				if err != nil {
+					invalid("loc: %v", err)
+				}
+				preset.location = loc
+			case "precision":
+				precision, err := ParseDateTimePrecision(value)
+				if err != nil {
+					invalid("precision %s", err)
+				}
+				preset.precision = precision
+			default:
+				invalid("unknown option %q for DateTime", key)
+			}
+		}
+		*target = preset
+		return target.UnmarshalJSON(raw)
+
+	case *ArrayString:
+		sep, trim := ArrayStringSeparator, false
+		for key, value := range options {
+			switch key {
+			case "sep":
+				if value == "" {
+					invalid("sep must not be empty")
+				}
+				sep = value
+			case "trim":
+				trim = true
+			default:
+				invalid("unknown option %q for ArrayString", key)
+			}
+		}
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return ErrCodeArrayNotString.Err(map[string]string{"value": string(raw)})
+		}
+		if s == "" {
+			return ErrCodeArrayEmpty.Err(nil)
+		}
+		list := strings.Split(s, sep)
+		if trim {
+			for i := range list {
+				list[i] = strings.TrimSpace(list[i])
+			}
+		}
+		*target = list
+
+	default:
+		invalid("ctype tags are not supported on %s", spec.Type)
+	}
+	return nil
+}
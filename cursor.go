@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrCodeCursorNotString = RegisterErrorCode(ErrorCode{
+		Code: "cursor.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeCursorInvalid = RegisterErrorCode(ErrorCode{
+		Code: "cursor.invalid", Status: http.StatusBadRequest,
+		Message: "must be a cursor returned by a previous response", Example: "must be a cursor returned by a previous response",
+		Kind: ErrInvalidFormat,
+	})
+)
+
+// Cursor is an opaque pagination token: URL-safe base64 of a JSON position
+// chosen by the endpoint, e.g. {"after":42}. Clients only pass it back.
+type Cursor string
+
+func NewCursor(position interface{}) Cursor {
+	b, err := json.Marshal(position)
+	if err != nil {
+		panic(err)
+	}
+	return Cursor(base64.RawURLEncoding.EncodeToString(b))
+}
+
+func ParseCursor(s string) (Cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || !json.Valid(b) {
+		return "", ErrCodeCursorInvalid.Err(nil)
+	}
+	return Cursor(s), nil
+}
+
+// CursorFromQuery reads ?cursor=, returning "" when it is absent.
+func CursorFromQuery(ctx *gin.Context) Cursor {
+	s := ctx.Query("cursor")
+	if s == "" {
+		return ""
+	}
+	c, err := ParseCursor(s)
+	if err != nil {
+		panic(prefixed("cursor", err))
+	}
+	return c
+}
+
+func (c Cursor) IsZero() bool {
+	return c == ""
+}
+
+// Decode unpacks the position into v. The zero Cursor leaves v untouched.
+func (c Cursor) Decode(v interface{}) error {
+	if c == "" {
+		return nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(string(c))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+func (c Cursor) String() string {
+	return string(c)
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (c *Cursor) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeCursorNotString.Err(map[string]string{"value": string(b)})
+	}
+	if s == "" {
+		*c = ""
+		return nil
+	}
+	parsed, err := ParseCursor(s)
+	if err != nil {
+		return err
+	}
+
+	*c = parsed
+
+	return nil
+}
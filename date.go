@@ -0,0 +1,267 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+var (
+	ErrCodeDateNotString = RegisterErrorCode(ErrorCode{
+		Code: "date.not_string", Status: http.StatusBadRequest,
+		Message: "not a valid string", Params: []string{"value"}, Example: "not a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeDateEmpty = RegisterErrorCode(ErrorCode{
+		Code: "date.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeDateInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "date.invalid_format", Status: http.StatusBadRequest,
+		Message: "format must be {layout}", Params: []string{"layout", "value"}, Example: "format must be YYYY-MM-DD",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeDateDayOfYear = RegisterErrorCode(ErrorCode{
+		Code: "date.day_of_year", Status: http.StatusBadRequest,
+		Message: "day of year must be between 001 and {max}", Params: []string{"max", "value"}, Example: "day of year must be between 001 and 365",
+	})
+	ErrCodeDateWeek = RegisterErrorCode(ErrorCode{
+		Code: "date.week", Status: http.StatusBadRequest,
+		Message: "week must be between 01 and {max}", Params: []string{"max", "value"}, Example: "week must be between 01 and 52",
+	})
+)
+
+var (
+	ordinalDatePattern = regexp.MustCompile(`^(\d{4})-(\d{3})$`)
+	weekDatePattern    = regexp.MustCompile(`^(\d{4})-W(\d{2})-([1-7])$`)
+)
+
+// DateParseOptions switches on the less common ISO 8601 date forms. Whatever
+// form was accepted, a Date always marshals in calendar form (YYYY-MM-DD).
+var DateParseOptions = struct {
+	// AllowOrdinal accepts year and day-of-year, e.g. "2024-045".
+	AllowOrdinal bool
+	// AllowWeek accepts ISO week dates, e.g. "2024-W05-3" (Wednesday).
+	AllowWeek bool
+}{}
+
+// Date is a calendar date without a time of day or zone.
+type Date struct {
+	time time.Time
+}
+
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+func ParseDate(s string) (Date, error) {
+	if t, err := time.Parse(dateLayout, s); err == nil {
+		return Date{time: t}, nil
+	}
+
+	if DateParseOptions.AllowOrdinal {
+		if m := ordinalDatePattern.FindStringSubmatch(s); m != nil {
+			year, _ := strconv.Atoi(m[1])
+			day, _ := strconv.Atoi(m[2])
+			days := 365
+			if isLeapYear(year) {
+				days = 366
+			}
+			if day < 1 || day > days {
+				return Date{}, ErrCodeDateDayOfYear.Err(map[string]string{"max": strconv.Itoa(days), "value": s})
+			}
+			return NewDate(year, time.January, day), nil
+		}
+	}
+
+	if DateParseOptions.AllowWeek {
+		if m := weekDatePattern.FindStringSubmatch(s); m != nil {
+			year, _ := strconv.Atoi(m[1])
+			week, _ := strconv.Atoi(m[2])
+			weekday, _ := strconv.Atoi(m[3])
+			weeks := isoWeeksInYear(year)
+			if week < 1 || week > weeks {
+				return Date{}, ErrCodeDateWeek.Err(map[string]string{"max": strconv.Itoa(weeks), "value": s})
+			}
+			// Week 1 is the week containing January 4th.
+			jan4 := NewDate(year, time.January, 4).time
+			monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+			return Date{time: monday.AddDate(0, 0, (week-1)*7+weekday-1)}, nil
+		}
+	}
+
+	return Date{}, ErrCodeDateInvalidFormat.Err(map[string]string{"layout": dateFormatHint(), "value": s})
+}
+
+func dateFormatHint() string {
+	hint := "YYYY-MM-DD"
+	if DateParseOptions.AllowOrdinal {
+		hint += ", YYYY-DDD"
+	}
+	if DateParseOptions.AllowWeek {
+		hint += ", YYYY-Www-D"
+	}
+	return hint
+}
+
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// isoWeeksInYear is 53 when December 28th falls in week 53, 52 otherwise.
+func isoWeeksInYear(year int) int {
+	_, week := NewDate(year, time.December, 28).time.ISOWeek()
+	return week
+}
+
+func (d Date) Year() int {
+	return d.time.Year()
+}
+
+func (d Date) Month() time.Month {
+	return d.time.Month()
+}
+
+func (d Date) Day() int {
+	return d.time.Day()
+}
+
+func (d Date) Weekday() time.Weekday {
+	return d.time.Weekday()
+}
+
+func (d Date) IsZero() bool {
+	return d.time.IsZero()
+}
+
+// Time returns midnight UTC of the date.
+func (d Date) Time() time.Time {
+	return d.time
+}
+
+func (d Date) String() string {
+	return d.time.Format(dateLayout)
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (d Date) MarshalJSON() ([]byte, error) {
+	return d.AppendJSON(make([]byte, 0, len(dateLayout)+2)), nil
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (d *Date) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeDateNotString.Err(map[string]string{"value": string(b)})
+	}
+	if s == "" {
+		return ErrCodeDateEmpty.Err(nil)
+	}
+	parsed, err := ParseDate(s)
+	if err != nil {
+		return err
+	}
+
+	*d = parsed
+
+	return nil
+}
+
+/*
+	This part implements `encoding.TextMarshaler`
+	type TextMarshaler interface {
+		MarshalText() (text []byte, err error)
+	}
+*/
+func (d Date) MarshalText() ([]byte, error) {
+	return d.AppendText(make([]byte, 0, len(dateLayout)))
+}
+
+/*
+	This part implements `encoding.TextUnmarshaler`
+	type TextUnmarshaler interface {
+		UnmarshalText(text []byte) error
+	}
+*/
+func (d *Date) UnmarshalText(b []byte) error {
+	return unmarshalTextAsJSON(d, b)
+}
+
+// unixEpochJulianDay is the Julian Day Number of 1970-01-01.
+const unixEpochJulianDay = 2440588
+
+// EpochDays returns the number of days since 1970-01-01 (negative before it).
+// It works on the calendar fields directly, using Howard Hinnant's
+// days_from_civil, so no time zone or midnight arithmetic is involved.
+func (d Date) EpochDays() int64 {
+	y, m, day := int64(d.Year()), int64(d.Month()), int64(d.Day())
+	if m <= 2 {
+		y--
+	}
+	era := y / 400
+	if y < 0 && y%400 != 0 {
+		era--
+	}
+	yoe := y - era*400
+	mp := (m + 9) % 12
+	doy := (153*mp+2)/5 + day - 1
+	doe := yoe*365 + yoe/4 - yoe/100 + doy
+	return era*146097 + doe - 719468
+}
+
+// FromEpochDays is the inverse of Date.EpochDays (Hinnant's civil_from_days).
+func FromEpochDays(n int64) Date {
+	z := n + 719468
+	era := z / 146097
+	if z < 0 && z%146097 != 0 {
+		era--
+	}
+	doe := z - era*146097
+	yoe := (doe - doe/1460 + doe/36524 - doe/146096) / 365
+	doy := doe - (365*yoe + yoe/4 - yoe/100)
+	mp := (5*doy + 2) / 153
+	day := doy - (153*mp+2)/5 + 1
+	month := mp + 3
+	if month > 12 {
+		month -= 12
+	}
+	year := yoe + era*400
+	if month <= 2 {
+		year++
+	}
+	return NewDate(int(year), time.Month(month), int(day))
+}
+
+// JulianDay returns the Julian Day Number, i.e. the JD at noon of this date.
+func (d Date) JulianDay() int64 {
+	return d.EpochDays() + unixEpochJulianDay
+}
+
+func FromJulianDay(jdn int64) Date {
+	return FromEpochDays(jdn - unixEpochJulianDay)
+}
+
+// DaysUntil returns the whole number of days from d to other.
+func (d Date) DaysUntil(other Date) int64 {
+	return other.EpochDays() - d.EpochDays()
+}
+
+// AddDays moves the date by n calendar days.
+func (d Date) AddDays(n int64) Date {
+	return FromEpochDays(d.EpochDays() + n)
+}
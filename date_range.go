@@ -0,0 +1,364 @@
+package main
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrCodeRangeNotObject = RegisterErrorCode(ErrorCode{
+		Code: "range.not_object", Status: http.StatusBadRequest,
+		Message: "must be a {from, to} object", Example: "must be a {from, to} object",
+	})
+	ErrCodeRangeEmpty = RegisterErrorCode(ErrorCode{
+		Code: "range.empty", Status: http.StatusBadRequest,
+		Message: "from and to must not be empty", Example: "from and to must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeDateRangeReversed = RegisterErrorCode(ErrorCode{
+		Code: "date_range.reversed", Status: http.StatusBadRequest,
+		Message: "to must not be before from", Example: "to must not be before from",
+	})
+	ErrCodeDateRangeTooLong = RegisterErrorCode(ErrorCode{
+		Code: "date_range.too_long", Status: http.StatusBadRequest,
+		Message: "must not span more than {max} days", Params: []string{"max"}, Example: "must not span more than 30 days",
+	})
+	ErrCodeTimeRangeReversed = RegisterErrorCode(ErrorCode{
+		Code: "time_range.reversed", Status: http.StatusBadRequest,
+		Message: "to must be after from", Example: "to must be after from",
+	})
+	ErrCodeTimeRangeTooLong = RegisterErrorCode(ErrorCode{
+		Code: "time_range.too_long", Status: http.StatusBadRequest,
+		Message: "must not span more than {max}", Params: []string{"max"}, Example: "must not span more than 4h0m0s",
+	})
+)
+
+// DateRangeMaxDays and TimeRangeMaxDuration cap the span of a DateRange and
+// a TimeRange. Zero disables the check.
+var (
+	DateRangeMaxDays     int64
+	TimeRangeMaxDuration time.Duration
+)
+
+type rangeJSON struct {
+	From *string `json:"from"`
+	To   *string `json:"to"`
+}
+
+// DateRange is an inclusive range of whole days, {"from":"2024-03-01",
+// "to":"2024-03-03"} being three days. From is never after To.
+type DateRange struct {
+	from Date
+	to   Date
+}
+
+func NewDateRange(from Date, to Date) (DateRange, error) {
+	if to.DaysUntil(from) > 0 {
+		return DateRange{}, ErrCodeDateRangeReversed.Err(nil)
+	}
+	if DateRangeMaxDays > 0 && from.DaysUntil(to)+1 > DateRangeMaxDays {
+		return DateRange{}, ErrCodeDateRangeTooLong.Err(map[string]string{"max": strconv.FormatInt(DateRangeMaxDays, 10)})
+	}
+	return DateRange{from: from, to: to}, nil
+}
+
+func (dr DateRange) From() Date {
+	return dr.from
+}
+
+func (dr DateRange) To() Date {
+	return dr.to
+}
+
+// Days is the number of days in the range, counting both ends.
+func (dr DateRange) Days() int64 {
+	return dr.from.DaysUntil(dr.to) + 1
+}
+
+func (dr DateRange) Duration() time.Duration {
+	return time.Duration(dr.Days()) * 24 * time.Hour
+}
+
+func (dr DateRange) Contains(d Date) bool {
+	return dr.from.DaysUntil(d) >= 0 && d.DaysUntil(dr.to) >= 0
+}
+
+// Overlaps reports whether the ranges share at least one day, so a booking
+// ending on the 3rd overlaps one starting on the 3rd.
+func (dr DateRange) Overlaps(other DateRange) bool {
+	return dr.from.DaysUntil(other.to) >= 0 && other.from.DaysUntil(dr.to) >= 0
+}
+
+func (dr DateRange) String() string {
+	return dr.from.String() + "/" + dr.to.String()
+}
+
+func (dr DateRange) IsZero() bool {
+	return dr.from.IsZero() && dr.to.IsZero()
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (dr DateRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]Date{"from": dr.from, "to": dr.to})
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (dr *DateRange) UnmarshalJSON(b []byte) error {
+	var raw rangeJSON
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return codedOr(err, ErrCodeRangeNotObject.Err(nil))
+	}
+	if raw.From == nil || raw.To == nil {
+		return ErrCodeRangeEmpty.Err(nil)
+	}
+	from, err := ParseDate(*raw.From)
+	if err != nil {
+		return prefixed("from", err)
+	}
+	to, err := ParseDate(*raw.To)
+	if err != nil {
+		return prefixed("to", err)
+	}
+	parsed, err := NewDateRange(from, to)
+	if err != nil {
+		return err
+	}
+
+	*dr = parsed
+
+	return nil
+}
+
+/*
+	This part implements `sql.Scanner`
+	type Scanner interface {
+		Scan(src any) error
+	}
+
+	Postgres returns a daterange in canonical form, "[2024-03-01,2024-03-04)",
+	with the upper bound exclusive.
+*/
+func (dr *DateRange) Scan(src interface{}) error {
+	lower, upper, err := scanRangeBounds(src, "DateRange")
+	if err != nil {
+		return err
+	}
+	if lower == "" {
+		*dr = DateRange{}
+		return nil
+	}
+	from, err := ParseDate(lower)
+	if err != nil {
+		return fmt.Errorf("cannot scan %q into DateRange", lower)
+	}
+	to, err := ParseDate(upper)
+	if err != nil {
+		return fmt.Errorf("cannot scan %q into DateRange", upper)
+	}
+	scanned, err := NewDateRange(from, to.AddDays(-1))
+	if err != nil {
+		return fmt.Errorf("cannot scan into DateRange: %w", err)
+	}
+	*dr = scanned
+	return nil
+}
+
+/*
+	This part implements `driver.Valuer`
+	type Valuer interface {
+		Value() (Value, error)
+	}
+*/
+func (dr DateRange) Value() (driver.Value, error) {
+	if dr.IsZero() {
+		return nil, nil
+	}
+	return "[" + dr.from.String() + "," + dr.to.AddDays(1).String() + ")", nil
+}
+
+// TimeRange is a half-open range of instants: it contains From but not To,
+// so back-to-back slots such as 10:00-11:00 and 11:00-12:00 do not overlap.
+// To is always after From.
+type TimeRange struct {
+	from DateTime
+	to   DateTime
+}
+
+func NewTimeRange(from DateTime, to DateTime) (TimeRange, error) {
+	if !to.Time().After(from.Time()) {
+		return TimeRange{}, ErrCodeTimeRangeReversed.Err(nil)
+	}
+	if TimeRangeMaxDuration > 0 && to.Time().Sub(from.Time()) > TimeRangeMaxDuration {
+		return TimeRange{}, ErrCodeTimeRangeTooLong.Err(map[string]string{"max": TimeRangeMaxDuration.String()})
+	}
+	return TimeRange{from: from, to: to}, nil
+}
+
+func (tr TimeRange) From() DateTime {
+	return tr.from
+}
+
+func (tr TimeRange) To() DateTime {
+	return tr.to
+}
+
+func (tr TimeRange) Duration() time.Duration {
+	return tr.to.Time().Sub(tr.from.Time())
+}
+
+func (tr TimeRange) Contains(dt DateTime) bool {
+	return !dt.Time().Before(tr.from.Time()) && dt.Time().Before(tr.to.Time())
+}
+
+func (tr TimeRange) Overlaps(other TimeRange) bool {
+	return tr.from.Time().Before(other.to.Time()) && other.from.Time().Before(tr.to.Time())
+}
+
+func (tr TimeRange) String() string {
+	return tr.from.String() + "/" + tr.to.String()
+}
+
+func (tr TimeRange) IsZero() bool {
+	return tr.from.IsZero() && tr.to.IsZero()
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (tr TimeRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]DateTime{"from": tr.from, "to": tr.to})
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (tr *TimeRange) UnmarshalJSON(b []byte) error {
+	var raw rangeJSON
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return codedOr(err, ErrCodeRangeNotObject.Err(nil))
+	}
+	if raw.From == nil || raw.To == nil {
+		return ErrCodeRangeEmpty.Err(nil)
+	}
+	from, err := ParseDateTime(*raw.From)
+	if err != nil {
+		return prefixed("from", err)
+	}
+	to, err := ParseDateTime(*raw.To)
+	if err != nil {
+		return prefixed("to", err)
+	}
+	parsed, err := NewTimeRange(from, to)
+	if err != nil {
+		return err
+	}
+
+	*tr = parsed
+
+	return nil
+}
+
+// Postgres prints timestamptz in the session time zone, with an hour-only
+// offset when it has no minutes. RFC 3339 is what Value writes.
+var tstzrangeLayouts = []string{
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	time.RFC3339Nano,
+}
+
+/*
+	This part implements `sql.Scanner`
+	type Scanner interface {
+		Scan(src any) error
+	}
+
+	Reads a tstzrange such as ["2024-03-01 10:00:00+07","2024-03-01 11:00:00+07").
+*/
+func (tr *TimeRange) Scan(src interface{}) error {
+	lower, upper, err := scanRangeBounds(src, "TimeRange")
+	if err != nil {
+		return err
+	}
+	if lower == "" {
+		*tr = TimeRange{}
+		return nil
+	}
+	var bounds [2]time.Time
+	for i, s := range []string{lower, upper} {
+		s = strings.Trim(s, `"`)
+		for _, layout := range tstzrangeLayouts {
+			if bounds[i], err = time.Parse(layout, s); err == nil {
+				break
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("cannot scan %q into TimeRange", s)
+		}
+	}
+	scanned, err := NewTimeRange(DateTime{time: bounds[0]}, DateTime{time: bounds[1]})
+	if err != nil {
+		return fmt.Errorf("cannot scan into TimeRange: %w", err)
+	}
+	*tr = scanned
+	return nil
+}
+
+/*
+	This part implements `driver.Valuer`
+	type Valuer interface {
+		Value() (Value, error)
+	}
+*/
+func (tr TimeRange) Value() (driver.Value, error) {
+	if tr.IsZero() {
+		return nil, nil
+	}
+	return `["` + tr.from.Time().Format(time.RFC3339Nano) + `","` + tr.to.Time().Format(time.RFC3339Nano) + `")`, nil
+}
+
+// scanRangeBounds splits a Postgres range literal. Only the canonical
+// "[lower,upper)" form with both bounds set is supported, since neither
+// range type can represent an empty or unbounded range. A NULL column gives
+// two empty bounds, for the zero range.
+func scanRangeBounds(src interface{}, typeName string) (string, string, error) {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		return "", "", nil
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return "", "", fmt.Errorf("cannot scan %T into %s", src, typeName)
+	}
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, ")") {
+		return "", "", fmt.Errorf("cannot scan %q into %s: want a [lower,upper) range", s, typeName)
+	}
+	lower, upper, ok := strings.Cut(s[1:len(s)-1], ",")
+	if !ok || lower == "" || upper == "" {
+		return "", "", fmt.Errorf("cannot scan %q into %s: want a [lower,upper) range", s, typeName)
+	}
+	return lower, upper, nil
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrCodeDateTimeNotFuture = RegisterErrorCode(ErrorCode{
+		Code: "datetime.not_future", Status: http.StatusBadRequest,
+		Message: "must be in the future", Example: "must be in the future",
+	})
+	ErrCodeDateTimeNotPast = RegisterErrorCode(ErrorCode{
+		Code: "datetime.not_past", Status: http.StatusBadRequest,
+		Message: "must be in the past", Example: "must be in the past",
+	})
+	ErrCodeDateTimeNotWithinLast = RegisterErrorCode(ErrorCode{
+		Code: "datetime.not_within_last", Status: http.StatusBadRequest,
+		Message: "must be within the last {span}", Params: []string{"span"}, Example: "must be within the last 30 days",
+	})
+	ErrCodeDateTimeNotWithinNext = RegisterErrorCode(ErrorCode{
+		Code: "datetime.not_within_next", Status: http.StatusBadRequest,
+		Message: "must be within the next {span}", Params: []string{"span"}, Example: "must be within the next 90 days",
+	})
+	ErrCodeDateTimeTooEarly = RegisterErrorCode(ErrorCode{
+		Code: "datetime.too_early", Status: http.StatusBadRequest,
+		Message: "must not be before {min}", Params: []string{"min"}, Example: "must not be before 2024-01-01T00:00:00Z",
+	})
+	ErrCodeDateTimeTooLate = RegisterErrorCode(ErrorCode{
+		Code: "datetime.too_late", Status: http.StatusBadRequest,
+		Message: "must not be after {max}", Params: []string{"max"}, Example: "must not be after 2024-12-31T23:59:59Z",
+	})
+	ErrCodeDateTimeConstraint = RegisterErrorCode(ErrorCode{
+		Code: "datetime.constraint", Status: http.StatusBadRequest,
+		Message: "{reason}", Params: []string{"reason"}, Example: "must be on a weekday",
+	})
+)
+
+// DateTimeNow is the clock DateTimeConstraints compare against.
+var DateTimeNow = time.Now
+
+// DateTimeConstraint checks a parsed value against now; the error message
+// is sent back to the client as a 400. Attach constraints to a request
+// field before binding:
+//
+//	request := RequestContentSchedule{From: DateTime{}.WithConstraints(InFuture(), WithinNext(90 * 24 * time.Hour))}
+//	err := ctx.ShouldBind(&request)
+type DateTimeConstraint func(t time.Time, now time.Time) error
+
+func InFuture() DateTimeConstraint {
+	return func(t time.Time, now time.Time) error {
+		if !t.After(now) {
+			return ErrCodeDateTimeNotFuture.Err(nil)
+		}
+		return nil
+	}
+}
+
+func InPast() DateTimeConstraint {
+	return func(t time.Time, now time.Time) error {
+		if !t.Before(now) {
+			return ErrCodeDateTimeNotPast.Err(nil)
+		}
+		return nil
+	}
+}
+
+// WithinLast accepts times from d ago up to now.
+func WithinLast(d time.Duration) DateTimeConstraint {
+	return func(t time.Time, now time.Time) error {
+		if t.Before(now.Add(-d)) || t.After(now) {
+			return ErrCodeDateTimeNotWithinLast.Err(map[string]string{"span": describeSpan(d)})
+		}
+		return nil
+	}
+}
+
+// WithinNext accepts times from now up to d ahead.
+func WithinNext(d time.Duration) DateTimeConstraint {
+	return func(t time.Time, now time.Time) error {
+		if t.Before(now) || t.After(now.Add(d)) {
+			return ErrCodeDateTimeNotWithinNext.Err(map[string]string{"span": describeSpan(d)})
+		}
+		return nil
+	}
+}
+
+func NotBefore(min DateTime) DateTimeConstraint {
+	return func(t time.Time, now time.Time) error {
+		if t.Before(min.Time()) {
+			return ErrCodeDateTimeTooEarly.Err(map[string]string{"min": min.String()})
+		}
+		return nil
+	}
+}
+
+func NotAfter(max DateTime) DateTimeConstraint {
+	return func(t time.Time, now time.Time) error {
+		if t.After(max.Time()) {
+			return ErrCodeDateTimeTooLate.Err(map[string]string{"max": max.String()})
+		}
+		return nil
+	}
+}
+
+// describeSpan prints whole days as "30 days" and anything else the way
+// time.Duration does.
+func describeSpan(d time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case d == day:
+		return "day"
+	case d > 0 && d%day == 0:
+		return strconv.FormatInt(int64(d/day), 10) + " days"
+	}
+	return d.String()
+}
+
+// WithConstraints returns dt set to check c, in order, whenever it is
+// parsed from JSON. Like WithPrecision, set it on a request field before
+// binding.
+func (dt DateTime) WithConstraints(c ...DateTimeConstraint) DateTime {
+	// A pointer keeps DateTime comparable with ==.
+	constraints := append([]DateTimeConstraint(nil), c...)
+	dt.constraints = &constraints
+	return dt
+}
+
+// checkConstraints runs the constraints dt was configured with.
+func (dt DateTime) checkConstraints() error {
+	if dt.constraints == nil {
+		return nil
+	}
+	now := DateTimeNow()
+	for _, check := range *dt.constraints {
+		if err := check(dt.Time(), now); err != nil {
+			return codedOr(err, ErrCodeDateTimeConstraint.Err(map[string]string{"reason": err.Error()}))
+		}
+	}
+	return nil
+}
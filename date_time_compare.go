@@ -0,0 +1,65 @@
+package main
+
+import (
+	"time"
+)
+
+// The helpers below compare instants, so the same moment sent with
+// different offsets is Equal. Results keep dt's precision and default
+// location.
+
+func (dt DateTime) IsZero() bool {
+	// Only text in year 0 or 1 can be the zero instant; anything later
+	// need not be parsed to tell.
+	if dt.lazy != nil && dt.lazy.raw >= "0002" {
+		return false
+	}
+	return dt.Time().IsZero()
+}
+
+func (dt DateTime) Before(other DateTime) bool {
+	return dt.Time().Before(other.Time())
+}
+
+func (dt DateTime) After(other DateTime) bool {
+	return dt.Time().After(other.Time())
+}
+
+func (dt DateTime) Equal(other DateTime) bool {
+	return dt.Time().Equal(other.Time())
+}
+
+func (dt DateTime) Add(d time.Duration) DateTime {
+	return dt.withTime(dt.Time().Add(d))
+}
+
+func (dt DateTime) Sub(other DateTime) time.Duration {
+	return dt.Time().Sub(other.Time())
+}
+
+// Truncate rounds down to a multiple of d since the zero time, as
+// time.Time.Truncate does; for whole days in dt's zone use StartOfDay.
+func (dt DateTime) Truncate(d time.Duration) DateTime {
+	return dt.withTime(dt.Time().Truncate(d))
+}
+
+// StartOfDay returns the first instant of dt's calendar day in dt's zone.
+// That is midnight except where a DST change skips it, e.g. 01:00 in
+// America/Santiago on the day clocks jump from 00:00.
+func (dt DateTime) StartOfDay() DateTime {
+	t := dt.Time()
+	year, month, day := t.Date()
+	return dt.withTime(startOfDay(year, month, day, t.Location()))
+}
+
+// EndOfDay returns the last nanosecond of dt's calendar day in dt's zone,
+// so that a range over [StartOfDay, EndOfDay] covers the whole day.
+func (dt DateTime) EndOfDay() DateTime {
+	t := dt.Time()
+	year, month, day := t.Date()
+	return dt.withTime(startOfDay(year, month, day+1, t.Location()).Add(-time.Nanosecond))
+}
+
+func startOfDay(year int, month time.Month, day int, loc *time.Location) time.Time {
+	return resolveWallClock(year, month, day, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// With DateTimeLazyParsing a DateTime decoded from RFC 3339 text keeps the
+// text and defers time.ParseInLocation to the first Time (or anything that
+// needs the instant: comparisons, SQL, constraints, ...). Marshaling writes
+// the text back unparsed whenever that is also what formatting the instant
+// would print: the output layout is time.RFC3339, the text has no
+// fractional seconds, and its offset survives DateTimeOutputUTC.
+//
+// Input that needs more than a well-formedness check is parsed right away
+// as before: a field with its own layout or constraints, StrictParsing
+// with fractional seconds, other DateTimeLayouts, or text that is not RFC
+// 3339. Either way the result and the errors are the same.
+
+type lazyDateTime struct {
+	raw string
+	// unit and loc are the truncation and location parseDateTime would
+	// have used when the value was decoded.
+	unit time.Duration
+	loc  *time.Location
+
+	once   sync.Once
+	parsed time.Time
+}
+
+func (l *lazyDateTime) instant() time.Time {
+	l.once.Do(func() {
+		// scanRFC3339 accepted raw, so it parses.
+		t, _ := time.ParseInLocation(time.RFC3339, l.raw, l.loc)
+		l.parsed = t.Truncate(l.unit)
+	})
+	return l.parsed
+}
+
+// withTime returns dt holding t, dropping any text it was decoded from.
+func (dt DateTime) withTime(t time.Time) DateTime {
+	dt.time, dt.lazy = t, nil
+	return dt
+}
+
+// unmarshalLazy keeps b's text in dt when lazy parsing applies to it, and
+// reports whether it did; otherwise UnmarshalJSON parses as usual.
+func (dt *DateTime) unmarshalLazy(b []byte) bool {
+	if dt.layout != "" || dt.constraints != nil || DateTimeLayouts[0] != time.RFC3339 {
+		return false
+	}
+	if len(b) < 2 || b[0] != '"' || b[len(b)-1] != '"' {
+		return false
+	}
+	text := b[1 : len(b)-1]
+	fraction, ok := scanRFC3339(text)
+	if !ok || StrictParsing && fraction {
+		return false
+	}
+
+	loc := dt.location
+	if loc == nil {
+		loc = DateTimeDefaultLocation
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	dt.time = time.Time{}
+	dt.lazy = &lazyDateTime{raw: string(text), unit: dt.precision.unit(), loc: loc}
+	return true
+}
+
+// lazyText is the text dt was decoded from, when formatting its instant
+// would print exactly that.
+func (dt DateTime) lazyText() (string, bool) {
+	if dt.lazy == nil || dt.format() != time.RFC3339 {
+		return "", false
+	}
+	raw := dt.lazy.raw
+	if strings.IndexByte(raw, '.') >= 0 {
+		return "", false
+	}
+	// time prints a zero offset as Z.
+	switch offset := raw[len("2006-01-02T15:04:05"):]; {
+	case offset == "+00:00" || offset == "-00:00":
+		return "", false
+	case DateTimeOutputUTC && offset != "Z":
+		return "", false
+	}
+	return raw, true
+}
+
+// scanRFC3339 reports whether time.Parse(time.RFC3339, ...) accepts b, and
+// whether b has fractional seconds, without building the time.
+func scanRFC3339(b []byte) (fraction bool, ok bool) {
+	const date = len("2006-01-02T15:04:05")
+	if len(b) < date+1 || b[4] != '-' || b[7] != '-' || b[10] != 'T' || b[13] != ':' || b[16] != ':' {
+		return false, false
+	}
+	year, ok1 := scanDigits(b[0:4])
+	month, ok2 := scanDigits(b[5:7])
+	day, ok3 := scanDigits(b[8:10])
+	hour, ok4 := scanDigits(b[11:13])
+	minute, ok5 := scanDigits(b[14:16])
+	second, ok6 := scanDigits(b[17:19])
+	if !(ok1 && ok2 && ok3 && ok4 && ok5 && ok6) ||
+		month < 1 || month > 12 || day < 1 || day > daysIn(time.Month(month), year) ||
+		hour > 23 || minute > 59 || second > 59 {
+		return false, false
+	}
+
+	rest := b[date:]
+	if rest[0] == '.' {
+		n := 1
+		for n < len(rest) && '0' <= rest[n] && rest[n] <= '9' {
+			n++
+		}
+		if n == 1 {
+			return false, false
+		}
+		fraction, rest = true, rest[n:]
+	}
+	switch {
+	case len(rest) == 1 && rest[0] == 'Z':
+		return fraction, true
+	case len(rest) == 6 && (rest[0] == '+' || rest[0] == '-') && rest[3] == ':':
+		hours, okH := scanDigits(rest[1:3])
+		minutes, okM := scanDigits(rest[4:6])
+		return fraction, okH && okM && hours < 24 && minutes < 60
+	}
+	return false, false
+}
+
+func scanDigits(b []byte) (int, bool) {
+	n := 0
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+	return n, true
+}
+
+func daysIn(month time.Month, year int) int {
+	switch month {
+	case time.February:
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			return 29
+		}
+		return 28
+	case time.April, time.June, time.September, time.November:
+		return 30
+	}
+	return 31
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DateTimePrecision is how many fractional second digits a DateTime keeps.
+// The zero value means DefaultDateTimePrecision.
+type DateTimePrecision int
+
+const (
+	DateTimeSeconds DateTimePrecision = iota + 1
+	DateTimeMillis
+	DateTimeMicros
+	DateTimeNanos
+)
+
+var dateTimePrecisionNames = map[DateTimePrecision]string{
+	DateTimeSeconds: "seconds",
+	DateTimeMillis:  "millis",
+	DateTimeMicros:  "micros",
+	DateTimeNanos:   "nanos",
+}
+
+func ParseDateTimePrecision(s string) (DateTimePrecision, error) {
+	for p, name := range dateTimePrecisionNames {
+		if strings.EqualFold(s, name) {
+			return p, nil
+		}
+	}
+	return 0, errors.New("must be one of seconds, millis, micros, nanos")
+}
+
+func (p DateTimePrecision) resolve() DateTimePrecision {
+	if p == 0 {
+		return DefaultDateTimePrecision
+	}
+	return p
+}
+
+func (p DateTimePrecision) String() string {
+	return dateTimePrecisionNames[p.resolve()]
+}
+
+func (p DateTimePrecision) unit() time.Duration {
+	switch p.resolve() {
+	case DateTimeMillis:
+		return time.Millisecond
+	case DateTimeMicros:
+		return time.Microsecond
+	case DateTimeNanos:
+		return time.Nanosecond
+	}
+	return time.Second
+}
+
+// layout adds a fixed number of fractional digits after the seconds of
+// layout, unless it already spells them out.
+func (p DateTimePrecision) layout(layout string) string {
+	var digits int
+	switch p = p.resolve(); p {
+	case DateTimeMillis:
+		digits = 3
+	case DateTimeMicros:
+		digits = 6
+	case DateTimeNanos:
+		digits = 9
+	}
+	if digits == 0 || strings.Contains(layout, "05.") || strings.Contains(layout, "05,") {
+		return layout
+	}
+	if cached, ok := precisionLayouts[p].Load().(precisionLayout); ok && cached.layout == layout {
+		return cached.withDigits
+	}
+	withDigits := strings.Replace(layout, "05", "05."+strings.Repeat("0", digits), 1)
+	precisionLayouts[p].Store(precisionLayout{layout: layout, withDigits: withDigits})
+	return withDigits
+}
+
+// precisionLayouts keeps the last layout built for each precision, so
+// formatting a DateTime does not allocate one every time.
+var precisionLayouts [DateTimeNanos + 1]atomic.Value
+
+type precisionLayout struct {
+	layout     string
+	withDigits string
+}
+
+// WithPrecision returns dt formatted with p instead of
+// DefaultDateTimePrecision. Set it on a request field before binding to
+// also parse that field with p:
+//
+//	request := RequestContentDateTime{TimeAt: DateTime{}.WithPrecision(DateTimeMillis)}
+//	err := ctx.ShouldBind(&request)
+func (dt DateTime) WithPrecision(p DateTimePrecision) DateTime {
+	dt.precision = p
+	return dt
+}
+
+func (dt DateTime) Precision() DateTimePrecision {
+	return dt.precision.resolve()
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"time"
+)
+
+// AddDaysWallClock moves dt by n calendar days in loc while keeping the
+// local wall-clock time, so a 09:00 job stays at 09:00 across a DST change,
+// where dt.time.Add(24*time.Hour) would drift to 08:00 or 10:00.
+//
+// When the wall-clock time does not exist on the target day (it falls in a
+// spring-forward gap) it is pushed forward by the size of the gap, e.g.
+// 02:30 becomes 03:30. When it exists twice (fall-back), the earlier of the
+// two instants is used.
+func (dt DateTime) AddDaysWallClock(n int, loc *time.Location) DateTime {
+	t := dt.Time().In(loc)
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+
+	next := resolveWallClock(year, month, day+n, hour, min, sec, t.Nanosecond(), loc)
+	return DateTime{time: next, precision: dt.precision}
+}
+
+// SameWallClockNextDay is AddDaysWallClock(1, loc).
+func (dt DateTime) SameWallClockNextDay(loc *time.Location) DateTime {
+	return dt.AddDaysWallClock(1, loc)
+}
+
+// resolveWallClock is time.Date with the DST rules of AddDaysWallClock: a
+// wall time in a gap is pushed forward by the size of the gap, and one that
+// exists twice is the earlier instant.
+func resolveWallClock(year int, month time.Month, day int, hour int, min int, sec int, nsec int, loc *time.Location) time.Time {
+	next := time.Date(year, month, day, hour, min, sec, 0, loc)
+	wanted := wallClock(year, month, day, hour, min, sec)
+
+	// time.Date makes no promise about which side of a gap or an overlap it
+	// picks. Try the offsets in force half a day either side, and keep the
+	// earliest instant that reads back as the wanted wall time.
+	var earliest time.Time
+	found := false
+	for _, probe := range []time.Time{next.Add(-12 * time.Hour), next, next.Add(12 * time.Hour)} {
+		_, offset := probe.Zone()
+		candidate := wanted.Add(-time.Duration(offset) * time.Second).In(loc)
+		if wallClockOf(candidate).Equal(wanted) && (!found || candidate.Before(earliest)) {
+			earliest, found = candidate, true
+		}
+	}
+
+	switch got := wallClockOf(next); {
+	case found:
+		next = earliest
+	case got.Before(wanted):
+		// In a gap time.Date in practice applies the offset from before
+		// the transition, which reads back as an earlier wall time (01:30
+		// for a missing 02:30). Shift such results forward by the
+		// difference.
+		next = next.Add(wanted.Sub(got))
+	}
+	return next.Add(time.Duration(nsec))
+}
+
+// wallClock pins a wall-clock reading to UTC so two readings can be compared
+// without any zone rules getting in the way.
+func wallClock(year int, month time.Month, day int, hour int, min int, sec int) time.Time {
+	return time.Date(year, month, day, hour, min, sec, 0, time.UTC)
+}
+
+func wallClockOf(t time.Time) time.Time {
+	year, month, day := t.Date()
+	hour, min, sec := t.Clock()
+	return wallClock(year, month, day, hour, min, sec)
+}
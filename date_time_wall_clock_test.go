@@ -0,0 +1,86 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("no time zone data for %s: %v", name, err)
+	}
+	return loc
+}
+
+func TestAddDaysWallClockDST(t *testing.T) {
+	newYork := loadLocation(t, "America/New_York")
+	london := loadLocation(t, "Europe/London")
+
+	for _, tc := range []struct {
+		name string
+		from string
+		days int
+		loc  *time.Location
+		want string
+	}{
+		// New York springs forward at 02:00 on 10 March 2024 and falls back
+		// at 02:00 on 3 November 2024.
+		{"before the gap", "2024-03-09T09:00:00-05:00", 1, newYork, "2024-03-10T09:00:00-04:00"},
+		{"into the gap", "2024-03-09T02:30:00-05:00", 1, newYork, "2024-03-10T03:30:00-04:00"},
+		{"into the gap backwards", "2024-03-11T02:30:00-04:00", -1, newYork, "2024-03-10T03:30:00-04:00"},
+		{"start of the gap", "2024-03-09T02:00:00-05:00", 1, newYork, "2024-03-10T03:00:00-04:00"},
+		{"end of the gap", "2024-03-09T03:00:00-05:00", 1, newYork, "2024-03-10T03:00:00-04:00"},
+		{"out of the gap", "2024-03-10T03:30:00-04:00", 1, newYork, "2024-03-11T03:30:00-04:00"},
+		{"across the overlap", "2024-11-02T09:00:00-04:00", 1, newYork, "2024-11-03T09:00:00-05:00"},
+		{"into the overlap", "2024-11-02T01:30:00-04:00", 1, newYork, "2024-11-03T01:30:00-04:00"},
+		{"into the overlap backwards", "2024-11-04T01:30:00-05:00", -1, newYork, "2024-11-03T01:30:00-04:00"},
+		{"second pass of the overlap", "2024-11-03T01:30:00-05:00", 1, newYork, "2024-11-04T01:30:00-05:00"},
+		{"a week across the gap", "2024-03-06T09:00:00-05:00", 7, newYork, "2024-03-13T09:00:00-04:00"},
+		{"instant in another zone", "2024-03-09T14:00:00Z", 1, newYork, "2024-03-10T09:00:00-04:00"},
+		// London springs forward at 01:00 on 31 March 2024 and falls back
+		// at 02:00 on 27 October 2024.
+		{"London gap", "2024-03-30T01:30:00Z", 1, london, "2024-03-31T02:30:00+01:00"},
+		{"London overlap", "2024-10-26T01:30:00+01:00", 1, london, "2024-10-27T01:30:00+01:00"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			from := MustParseDateTime(tc.from)
+			got := from.AddDaysWallClock(tc.days, tc.loc)
+			if text := got.Time().Format(time.RFC3339); text != tc.want {
+				t.Errorf("AddDaysWallClock(%d) of %s = %s, want %s", tc.days, tc.from, text, tc.want)
+			}
+		})
+	}
+}
+
+func TestSameWallClockNextDay(t *testing.T) {
+	newYork := loadLocation(t, "America/New_York")
+
+	// A daily 09:00 job over the spring-forward weekend keeps its time;
+	// adding 24 hours would move it to 10:00.
+	run := MustParseDateTime("2024-03-08T09:00:00-05:00")
+	for _, want := range []string{"2024-03-09T09:00:00-05:00", "2024-03-10T09:00:00-04:00", "2024-03-11T09:00:00-04:00"} {
+		run = run.SameWallClockNextDay(newYork)
+		if text := run.Time().Format(time.RFC3339); text != want {
+			t.Fatalf("next run %s, want %s", text, want)
+		}
+	}
+	if got := run.Sub(MustParseDateTime("2024-03-08T09:00:00-05:00")); got != 71*time.Hour {
+		t.Errorf("three days over the gap took %s, want 71h", got)
+	}
+}
+
+func TestStartOfDayDST(t *testing.T) {
+	santiago := loadLocation(t, "America/Santiago")
+
+	// Santiago moves its clocks from 00:00 to 01:00 on 8 September 2024, so
+	// that day has no midnight.
+	day := NewDateTime(time.Date(2024, time.September, 8, 12, 0, 0, 0, santiago))
+	if got := day.StartOfDay().Time().Format(time.RFC3339); got != "2024-09-08T01:00:00-03:00" {
+		t.Errorf("StartOfDay = %s, want 2024-09-08T01:00:00-03:00", got)
+	}
+	if got := day.EndOfDay().Time().Format(time.RFC3339Nano); got != "2024-09-08T23:59:59.999999999-03:00" {
+		t.Errorf("EndOfDay = %s, want 2024-09-08T23:59:59.999999999-03:00", got)
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// DegradationPolicy decides what a validator that depends on external data
+// (currency tables, holiday calendars, tenant config) does when that data
+// cannot be fetched.
+type DegradationPolicy int
+
+const (
+	// FailClosed rejects the request with a 503: nothing is accepted that
+	// could not be checked.
+	FailClosed DegradationPolicy = iota
+	// FailOpen skips the check and reports a warning through
+	// DegradationHook.
+	FailOpen
+	// UseStale checks against the last value fetched successfully, as long
+	// as it is no older than MaxStale, and fails closed after that.
+	UseStale
+)
+
+func (dp DegradationPolicy) String() string {
+	switch dp {
+	case FailClosed:
+		return "fail-closed"
+	case FailOpen:
+		return "fail-open"
+	case UseStale:
+		return "use-stale"
+	}
+	return "unknown"
+}
+
+// ServiceUnavailableError is answered with 503 by the recovery middleware,
+// the same way BadRequestError is answered with 400.
+type ServiceUnavailableError string
+
+func (e ServiceUnavailableError) Error() string {
+	return string(e)
+}
+
+// DegradationHook is told every time a validator degrades, i.e. a check was
+// skipped or ran against stale data. It defaults to logging.
+var DegradationHook = func(name string, policy DegradationPolicy, err error) {
+	log.Printf("validator %s degraded (%s): %v", name, policy, err)
+}
+
+type ExternalDataOptions struct {
+	Policy DegradationPolicy
+	// TTL is how long a fetched value is used before fetching again.
+	TTL time.Duration
+	// MaxStale bounds UseStale; zero means stale data is used indefinitely.
+	MaxStale time.Duration
+	// Backoff is how long a failed fetch is not retried, so an outage does
+	// not turn every request into a call; zero means one second.
+	Backoff time.Duration
+}
+
+// ExternalData caches what a validator fetches from another service and
+// applies the configured DegradationPolicy when fetching fails. One fetch
+// runs at a time; concurrent checks wait for it, or under UseStale check
+// the stale value meanwhile.
+type ExternalData[T any] struct {
+	name  string
+	fetch func() (T, error)
+	opts  ExternalDataOptions
+
+	mu        sync.Mutex
+	value     T
+	fetchedAt time.Time
+	hasValue  bool
+	// fetching is closed when the fetch in flight, if any, is done.
+	fetching chan struct{}
+	err      error
+	failedAt time.Time
+}
+
+func NewExternalData[T any](name string, fetch func() (T, error), opts ExternalDataOptions) *ExternalData[T] {
+	return &ExternalData[T]{name: name, fetch: fetch, opts: opts}
+}
+
+// Check runs check against current data. Errors from check (typically a
+// BadRequestError) are returned unchanged; when the data is unavailable
+// Check returns nil, a ServiceUnavailableError, or check's result on stale
+// data, depending on the policy.
+func (ed *ExternalData[T]) Check(check func(data T) error) error {
+	value, err := ed.load()
+	if err == nil {
+		return check(value)
+	}
+
+	switch ed.opts.Policy {
+	case FailOpen:
+		DegradationHook(ed.name, FailOpen, err)
+		return nil
+	case UseStale:
+		ed.mu.Lock()
+		stale, hasValue, age := ed.value, ed.hasValue, time.Since(ed.fetchedAt)
+		ed.mu.Unlock()
+		if hasValue && (ed.opts.MaxStale == 0 || age <= ed.opts.MaxStale) {
+			DegradationHook(ed.name, UseStale, err)
+			return check(stale)
+		}
+	}
+	return ServiceUnavailableError(ed.name + " is temporarily unavailable, try again later")
+}
+
+// load returns the cached value while it is fresh, and fetches it again
+// otherwise. A failed fetch is returned again until the backoff is over.
+func (ed *ExternalData[T]) load() (T, error) {
+	ed.mu.Lock()
+	for {
+		if ed.hasValue && time.Since(ed.fetchedAt) < ed.opts.TTL {
+			value := ed.value
+			ed.mu.Unlock()
+			return value, nil
+		}
+		if ed.err != nil && time.Since(ed.failedAt) < ed.backoff() {
+			err := ed.err
+			ed.mu.Unlock()
+			var zero T
+			return zero, err
+		}
+		if ed.fetching == nil {
+			break
+		}
+		if ed.opts.Policy == UseStale && ed.hasValue && (ed.opts.MaxStale == 0 || time.Since(ed.fetchedAt) <= ed.opts.MaxStale) {
+			value := ed.value
+			ed.mu.Unlock()
+			return value, nil
+		}
+		fetching := ed.fetching
+		ed.mu.Unlock()
+		<-fetching
+		ed.mu.Lock()
+	}
+	fetching := make(chan struct{})
+	ed.fetching = fetching
+	ed.mu.Unlock()
+
+	// Deferred, so the waiters are let go even if fetch panics.
+	defer func() {
+		ed.mu.Lock()
+		ed.fetching = nil
+		ed.mu.Unlock()
+		close(fetching)
+	}()
+	value, err := ed.fetch()
+
+	ed.mu.Lock()
+	if err != nil {
+		ed.err, ed.failedAt = err, time.Now()
+	} else {
+		ed.value, ed.fetchedAt, ed.hasValue, ed.err = value, time.Now(), true, nil
+	}
+	ed.mu.Unlock()
+	return value, err
+}
+
+func (ed *ExternalData[T]) backoff() time.Duration {
+	if ed.opts.Backoff > 0 {
+		return ed.opts.Backoff
+	}
+	return time.Second
+}
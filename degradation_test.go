@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExternalDataFetchesOnce(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	ed := NewExternalData("test", func() (int, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return 42, nil
+	}, ExternalDataOptions{Policy: FailClosed, TTL: time.Minute})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := ed.Check(func(n int) error {
+				if n != 42 {
+					t.Errorf("checked %d, want 42", n)
+				}
+				return nil
+			}); err != nil {
+				t.Errorf("Check: %v", err)
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("fetched %d times, want 1", calls)
+	}
+}
+
+func TestExternalDataBacksOff(t *testing.T) {
+	var calls int32
+	ed := NewExternalData("test", func() (int, error) {
+		atomic.AddInt32(&calls, 1)
+		return 0, errors.New("connection refused")
+	}, ExternalDataOptions{Policy: FailClosed, Backoff: time.Minute})
+
+	for i := 0; i < 3; i++ {
+		var unavailable ServiceUnavailableError
+		if err := ed.Check(func(int) error { return nil }); !errors.As(err, &unavailable) {
+			t.Errorf("Check: %v, want a ServiceUnavailableError", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("fetched %d times during the backoff, want 1", calls)
+	}
+}
+
+func TestExternalDataServesStaleWhileFetching(t *testing.T) {
+	fetched := make(chan struct{})
+	release := make(chan struct{})
+	first := true
+	ed := NewExternalData("test", func() (int, error) {
+		if first {
+			first = false
+			return 1, nil
+		}
+		close(fetched)
+		<-release
+		return 2, nil
+	}, ExternalDataOptions{Policy: UseStale})
+
+	if err := ed.Check(func(int) error { return nil }); err != nil {
+		t.Fatalf("first Check: %v", err)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ed.Check(func(int) error { return nil })
+	}()
+	<-fetched
+
+	var got int
+	if err := ed.Check(func(n int) error { got = n; return nil }); err != nil {
+		t.Errorf("Check during the fetch: %v", err)
+	}
+	if got != 1 {
+		t.Errorf("checked %d during the fetch, want the stale 1", got)
+	}
+	close(release)
+	<-done
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrCodeDelimitedMapEmpty = RegisterErrorCode(ErrorCode{
+		Code: "delimited_map.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeDelimitedMapInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "delimited_map.invalid_format", Status: http.StatusBadRequest,
+		Message: "must be an object or a delimited string", Example: "must be an object or a delimited string",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeDelimitedMapPair = RegisterErrorCode(ErrorCode{
+		Code: "delimited_map.pair", Status: http.StatusBadRequest,
+		Message: "{pair} must be a key and value separated by {separator}", Params: []string{"pair", "separator"}, Example: "\"team\" must be a key and value separated by \":\"",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeDelimitedMapKeyEmpty = RegisterErrorCode(ErrorCode{
+		Code: "delimited_map.key_empty", Status: http.StatusBadRequest,
+		Message: "keys must not be empty", Example: "keys must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeDelimitedMapRepeatedKey = RegisterErrorCode(ErrorCode{
+		Code: "delimited_map.repeated_key", Status: http.StatusBadRequest,
+		Message: "key {key} must not be repeated", Params: []string{"key"}, Example: "key \"team\" must not be repeated",
+	})
+	ErrCodeDelimitedMapValueNotString = RegisterErrorCode(ErrorCode{
+		Code: "delimited_map.value_not_string", Status: http.StatusBadRequest,
+		Message: "value of {key} must be a valid string", Params: []string{"key"}, Example: "value of \"team\" must be a valid string",
+		Kind: ErrNotAString,
+	})
+)
+
+// DelimitedMapPairSeparator splits a DelimitedMap string into pairs and
+// DelimitedMapKeySeparator splits each pair into key and value.
+var (
+	DelimitedMapPairSeparator = ","
+	DelimitedMapKeySeparator  = ":"
+)
+
+type MapEntry struct {
+	Key   string
+	Value string
+}
+
+// DelimitedMap is a string map that keeps the order it was sent in. It
+// accepts a JSON object of strings or a delimited string such as
+// "status:paid,city:Jakarta", the form used in query parameters, and
+// rejects duplicate keys in both. It marshals as a JSON object in the same
+// order.
+//
+// Keys and values cannot contain the separators in the string form; send an
+// object when they might.
+type DelimitedMap struct {
+	entries []MapEntry
+	index   map[string]int
+}
+
+func ParseDelimitedMap(s string) (DelimitedMap, error) {
+	var dm DelimitedMap
+	for _, pair := range strings.Split(s, DelimitedMapPairSeparator) {
+		key, value, ok := strings.Cut(pair, DelimitedMapKeySeparator)
+		if !ok {
+			return DelimitedMap{}, ErrCodeDelimitedMapPair.Err(map[string]string{"pair": strconv.Quote(pair), "separator": strconv.Quote(DelimitedMapKeySeparator)})
+		}
+		if err := dm.add(strings.TrimSpace(key), strings.TrimSpace(value)); err != nil {
+			return DelimitedMap{}, err
+		}
+	}
+	return dm, nil
+}
+
+func (dm *DelimitedMap) add(key string, value string) error {
+	if key == "" {
+		return ErrCodeDelimitedMapKeyEmpty.Err(nil)
+	}
+	if _, ok := dm.index[key]; ok {
+		return ErrCodeDelimitedMapRepeatedKey.Err(map[string]string{"key": strconv.Quote(key)})
+	}
+	if dm.index == nil {
+		dm.index = map[string]int{}
+	}
+	dm.index[key] = len(dm.entries)
+	dm.entries = append(dm.entries, MapEntry{Key: key, Value: value})
+	return nil
+}
+
+func (dm DelimitedMap) Get(key string) (string, bool) {
+	i, ok := dm.index[key]
+	if !ok {
+		return "", false
+	}
+	return dm.entries[i].Value, true
+}
+
+func (dm DelimitedMap) Len() int {
+	return len(dm.entries)
+}
+
+// Entries returns the pairs in the order they were sent.
+func (dm DelimitedMap) Entries() []MapEntry {
+	return append([]MapEntry(nil), dm.entries...)
+}
+
+func (dm DelimitedMap) Keys() []string {
+	keys := make([]string, len(dm.entries))
+	for i, entry := range dm.entries {
+		keys[i] = entry.Key
+	}
+	return keys
+}
+
+func (dm DelimitedMap) Map() map[string]string {
+	m := make(map[string]string, len(dm.entries))
+	for _, entry := range dm.entries {
+		m[entry.Key] = entry.Value
+	}
+	return m
+}
+
+func (dm DelimitedMap) String() string {
+	pairs := make([]string, len(dm.entries))
+	for i, entry := range dm.entries {
+		pairs[i] = entry.Key + DelimitedMapKeySeparator + entry.Value
+	}
+	return strings.Join(pairs, DelimitedMapPairSeparator)
+}
+
+func (dm DelimitedMap) IsZero() bool {
+	return len(dm.entries) == 0
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (dm DelimitedMap) MarshalJSON() ([]byte, error) {
+	buf := getBuffer()
+	buf.WriteByte('{')
+	for i, entry := range dm.entries {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeJSONString(buf, entry.Key)
+		buf.WriteByte(':')
+		writeJSONString(buf, entry.Value)
+	}
+	buf.WriteByte('}')
+	return bufferBytes(buf), nil
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+
+	Objects are read token by token, since decoding into a Go map would
+	lose the order and silently keep the last of a repeated key.
+*/
+func (dm *DelimitedMap) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		if s == "" {
+			return ErrCodeDelimitedMapEmpty.Err(nil)
+		}
+		parsed, err := ParseDelimitedMap(s)
+		if err != nil {
+			return err
+		}
+		*dm = parsed
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return ErrCodeDelimitedMapInvalidFormat.Err(nil)
+	}
+	var parsed DelimitedMap
+	for decoder.More() {
+		// Keys are always strings inside a valid object.
+		token, _ := decoder.Token()
+		key := token.(string)
+		var value string
+		if err := decoder.Decode(&value); err != nil {
+			return ErrCodeDelimitedMapValueNotString.Err(map[string]string{"key": strconv.Quote(key)})
+		}
+		if err := parsed.add(key, value); err != nil {
+			return err
+		}
+	}
+
+	*dm = parsed
+
+	return nil
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrCodeDurationWrongType = RegisterErrorCode(ErrorCode{
+		Code: "duration.wrong_type", Status: http.StatusBadRequest,
+		Message: "must be a valid duration string or number of seconds", Params: []string{"value"}, Example: "must be a valid duration string or number of seconds",
+	})
+	ErrCodeDurationEmpty = RegisterErrorCode(ErrorCode{
+		Code: "duration.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeDurationInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "duration.invalid_format", Status: http.StatusBadRequest,
+		Message: "format must be a duration like 1h30m or 90s", Params: []string{"value"}, Example: "format must be a duration like 1h30m or 90s",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeDurationOutOfRange = RegisterErrorCode(ErrorCode{
+		Code: "duration.out_of_range", Status: http.StatusBadRequest,
+		Message: "value out of range", Params: []string{"value"}, Example: "value out of range",
+		Kind: ErrInvalidFormat,
+	})
+)
+
+type Duration struct {
+	duration time.Duration
+}
+
+func (d Duration) Duration() time.Duration {
+	return d.duration
+}
+
+/*
+	This receiver function overwrite `fmt.Stringer` which use to print the output
+	type Stringer interface {
+		String() string
+	}
+
+	`time.Duration` prints "1h30m0s", the trailing zero units are dropped here
+	so the value round-trips as "1h30m".
+*/
+func (d Duration) String() string {
+	s := d.duration.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = s[:len(s)-2]
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = s[:len(s)-2]
+	}
+	return s
+}
+
+func (d Duration) IsZero() bool {
+	return d.duration == 0
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+
+	Accepts Go duration syntax ("1h30m", "90s") or a bare JSON number of seconds.
+*/
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if len(b) > 0 && b[0] != '"' {
+		var seconds float64
+		if err := json.Unmarshal(b, &seconds); err != nil {
+			return ErrCodeDurationWrongType.Err(map[string]string{"value": string(b)})
+		}
+		t, ok := secondsDuration(seconds)
+		if !ok {
+			return ErrCodeDurationOutOfRange.Err(map[string]string{"value": string(b)})
+		}
+		d.duration = t
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeDurationWrongType.Err(map[string]string{"value": string(b)})
+	}
+	if s == "" {
+		return ErrCodeDurationEmpty.Err(nil)
+	}
+	t, err := time.ParseDuration(s)
+	if err != nil {
+		return ErrCodeDurationInvalidFormat.Err(map[string]string{"value": s})
+	}
+
+	d.duration = t
+
+	return nil
+}
+
+/*
+	This part implements `encoding.TextMarshaler`
+	type TextMarshaler interface {
+		MarshalText() (text []byte, err error)
+	}
+*/
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+/*
+	This part implements `encoding.TextUnmarshaler`
+	type TextUnmarshaler interface {
+		UnmarshalText(text []byte) error
+	}
+*/
+func (d *Duration) UnmarshalText(b []byte) error {
+	return unmarshalTextAsJSON(d, b)
+}
+
+/*
+	This part implements `yaml.Marshaler`
+	type Marshaler interface {
+		MarshalYAML() (interface{}, error)
+	}
+*/
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return d.String(), nil
+}
+
+/*
+	This part implements `yaml.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalYAML(value *yaml.Node) error
+	}
+*/
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshalYAMLAsJSON(d, value)
+}
+
+/*
+	This part implements BurntSushi/toml's `toml.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalTOML(interface{}) error
+	}
+*/
+func (d *Duration) UnmarshalTOML(value interface{}) error {
+	return unmarshalValueAsJSON(d, value)
+}
+
+/*
+	This part implements `sql.Scanner`
+	type Scanner interface {
+		Scan(src any) error
+	}
+
+	Integers are read as nanoseconds, strings either as Go duration syntax or
+	as a Postgres interval ("1 day 02:30:00").
+*/
+func (d *Duration) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		d.duration = 0
+		return nil
+	case int64:
+		d.duration = time.Duration(v)
+		return nil
+	case []byte:
+		return d.scanString(string(v))
+	case string:
+		return d.scanString(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Duration", src)
+	}
+}
+
+func (d *Duration) scanString(s string) error {
+	if t, err := time.ParseDuration(s); err == nil {
+		d.duration = t
+		return nil
+	}
+	t, err := parseInterval(s)
+	if err != nil {
+		return err
+	}
+	d.duration = t
+	return nil
+}
+
+/*
+	This part implements `driver.Valuer`
+	type Valuer interface {
+		Value() (driver.Value, error)
+	}
+*/
+func (d Duration) Value() (driver.Value, error) {
+	return int64(d.duration), nil
+}
+
+// parseInterval reads the Postgres "postgres" interval style limited to days
+// and a clock part, e.g. "2 days 01:30:00.5" or "-00:00:10".
+func parseInterval(s string) (time.Duration, error) {
+	invalid := errors.New("invalid interval " + strconv.Quote(s))
+	outOfRange := errors.New("interval " + strconv.Quote(s) + " out of range")
+
+	var total int64
+	fields := strings.Fields(s)
+	if len(fields) >= 2 && strings.HasPrefix(fields[1], "day") {
+		days, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return 0, invalid
+		}
+		var ok bool
+		if total, ok = multiplyInt64(int64(days), int64(24*time.Hour)); !ok {
+			return 0, outOfRange
+		}
+		fields = fields[2:]
+	}
+	if len(fields) == 0 {
+		return time.Duration(total), nil
+	}
+	if len(fields) != 1 {
+		return 0, invalid
+	}
+
+	clock := fields[0]
+	negative := strings.HasPrefix(clock, "-")
+	clock = strings.TrimLeft(clock, "+-")
+	parts := strings.Split(clock, ":")
+	if len(parts) != 3 {
+		return 0, invalid
+	}
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, invalid
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, invalid
+	}
+	seconds, err := strconv.ParseFloat(parts[2], 64)
+	if err != nil {
+		return 0, invalid
+	}
+	h, hOK := multiplyInt64(int64(hours), int64(time.Hour))
+	m, mOK := multiplyInt64(int64(minutes), int64(time.Minute))
+	sec, secOK := secondsDuration(seconds)
+	part, ok := addInt64(h, m)
+	if ok {
+		part, ok = addInt64(part, int64(sec))
+	}
+	if !hOK || !mOK || !secOK || !ok {
+		return 0, outOfRange
+	}
+	if negative {
+		part = -part
+	}
+	if total, ok = addInt64(total, part); !ok {
+		return 0, outOfRange
+	}
+	return time.Duration(total), nil
+}
+
+// secondsDuration converts a number of seconds to a time.Duration, reporting
+// false if it does not fit, instead of the wrapped value a plain conversion
+// gives.
+func secondsDuration(seconds float64) (time.Duration, bool) {
+	ns := seconds * float64(time.Second)
+	if math.IsNaN(ns) || ns >= math.MaxInt64 || ns < math.MinInt64 {
+		return 0, false
+	}
+	return time.Duration(ns), true
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"strconv"
+	"time"
+)
+
+// Timer holds a start reading taken with time.Now, which carries the
+// monotonic clock, so Elapsed is not affected by NTP steps or manual clock
+// changes while a request is being handled.
+type Timer struct {
+	start time.Time
+}
+
+func StartTimer() Timer {
+	return Timer{start: time.Now()}
+}
+
+// Elapsed returns the time since StartTimer. The zero Timer reports 0.
+func (t Timer) Elapsed() Elapsed {
+	if t.start.IsZero() {
+		return 0
+	}
+	return Elapsed(time.Since(t.start))
+}
+
+// Elapsed is a measured processing time. It marshals as milliseconds with
+// microsecond precision, e.g. 12.345.
+type Elapsed time.Duration
+
+func (e Elapsed) Duration() time.Duration {
+	return time.Duration(e)
+}
+
+func (e Elapsed) Milliseconds() float64 {
+	return float64(e) / float64(time.Millisecond)
+}
+
+func (e Elapsed) String() string {
+	return time.Duration(e).String()
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (e Elapsed) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatFloat(e.Milliseconds(), 'f', 3, 64)), nil
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrCodeEnumNotString = RegisterErrorCode(ErrorCode{
+		Code: "enum.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeEnumEmpty = RegisterErrorCode(ErrorCode{
+		Code: "enum.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeEnumInvalidValue = RegisterErrorCode(ErrorCode{
+		Code: "enum.invalid_value", Status: http.StatusBadRequest,
+		Message: "must be one of {values}", Params: []string{"values", "value"}, Example: "must be one of pending, active, closed",
+	})
+)
+
+/*
+	EnumSpec declares the allowed values of an `Enum` once:
+
+	type orderStatus struct{}
+
+	func (orderStatus) Values() []string {
+		return []string{"pending", "active", "closed"}
+	}
+
+	type OrderStatus = Enum[orderStatus]
+
+	A spec may also implement `EnumCaseInsensitive` to accept "ACTIVE" as
+	"active"; the declared spelling is always the one stored and marshaled.
+*/
+type EnumSpec interface {
+	Values() []string
+}
+
+type EnumCaseInsensitive interface {
+	CaseInsensitive() bool
+}
+
+type Enum[T EnumSpec] struct {
+	value string
+}
+
+func ParseEnum[T EnumSpec](s string) (Enum[T], error) {
+	var spec T
+	caseInsensitive := false
+	if ci, ok := interface{}(spec).(EnumCaseInsensitive); ok {
+		caseInsensitive = ci.CaseInsensitive()
+	}
+
+	// Read once: a data-driven spec may return a new list after a reload.
+	values := spec.Values()
+	for _, v := range values {
+		if v == s || (caseInsensitive && strings.EqualFold(v, s)) {
+			return Enum[T]{value: v}, nil
+		}
+	}
+	return Enum[T]{}, ErrCodeEnumInvalidValue.Err(map[string]string{"values": strings.Join(values, ", "), "value": s})
+}
+
+func MustParseEnum[T EnumSpec](s string) Enum[T] {
+	e, err := ParseEnum[T](s)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+func (e Enum[T]) Values() []string {
+	var spec T
+	return spec.Values()
+}
+
+func (e Enum[T]) IsValid() bool {
+	return e.value != ""
+}
+
+func (e Enum[T]) String() string {
+	return e.value
+}
+
+func (e Enum[T]) IsZero() bool {
+	return e.value == ""
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (e Enum[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.value)
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (e *Enum[T]) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeEnumNotString.Err(map[string]string{"value": string(b)})
+	}
+	if s == "" {
+		return ErrCodeEnumEmpty.Err(nil)
+	}
+	parsed, err := ParseEnum[T](s)
+	if err != nil {
+		return err
+	}
+
+	*e = parsed
+
+	return nil
+}
+
+/*
+	This part implements `sql.Scanner`
+	type Scanner interface {
+		Scan(src any) error
+	}
+*/
+func (e *Enum[T]) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		e.value = ""
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Enum", src)
+	}
+
+	parsed, err := ParseEnum[T](s)
+	if err != nil {
+		return fmt.Errorf("cannot scan %q into Enum: %w", s, err)
+	}
+	*e = parsed
+	return nil
+}
+
+/*
+	This part implements `driver.Valuer`
+	type Valuer interface {
+		Value() (driver.Value, error)
+	}
+*/
+func (e Enum[T]) Value() (driver.Value, error) {
+	if e.value == "" {
+		return nil, nil
+	}
+	return e.value, nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/caarlos0/env/v6"
+	"github.com/kelseyhightower/envconfig"
+)
+
+// envSettings has both libraries' tags, so the same variables feed either.
+type envSettings struct {
+	CacheTTL       Duration     `env:"CACHE_TTL" envconfig:"CACHE_TTL"`
+	AllowedOrigins ArrayString  `env:"ALLOWED_ORIGINS" envconfig:"ALLOWED_ORIGINS"`
+	Zone           Timezone     `env:"ZONE" envconfig:"ZONE"`
+	MaxBody        ByteSize     `env:"MAX_BODY" envconfig:"MAX_BODY"`
+	Since          DateTime     `env:"SINCE" envconfig:"SINCE"`
+	Day            Date         `env:"DAY" envconfig:"DAY"`
+	Version        Semver       `env:"VERSION" envconfig:"VERSION"`
+	OwnerID        ObfuscatedID `env:"OWNER_ID" envconfig:"OWNER_ID"`
+	Sample         Percentage   `env:"SAMPLE" envconfig:"SAMPLE"`
+}
+
+func (s envSettings) summary() string {
+	return strings.Join([]string{
+		s.CacheTTL.String(), strings.Join(s.AllowedOrigins.List(), "|"), s.Zone.String(), s.MaxBody.String(),
+		s.Since.String(), s.Day.String(), s.Version.String(), s.OwnerID.String(), s.Sample.String(),
+	}, " ")
+}
+
+var envVariables = map[string]string{
+	"CACHE_TTL":       "1h30m",
+	"ALLOWED_ORIGINS": "a.com,b.com",
+	"ZONE":            "Asia/Jakarta",
+	"MAX_BODY":        "8MiB",
+	"SINCE":           "2020-01-01T02:02:05+07:00",
+	"DAY":             "2024-03-08",
+	"VERSION":         "1.2.3",
+	"OWNER_ID":        "BEAsrn5Q",
+	"SAMPLE":          "12.5%",
+}
+
+const envSummary = "1h30m a.com|b.com Asia/Jakarta 8MiB 2020-01-01T02:02:05+07:00 2024-03-08 1.2.3 BEAsrn5Q 12.5%"
+
+func TestEnvDecode(t *testing.T) {
+	var fromEnv envSettings
+	if err := env.Parse(&fromEnv, env.Options{Environment: envVariables}); err != nil {
+		t.Fatalf("caarlos0/env: %v", err)
+	}
+	if got := fromEnv.summary(); got != envSummary {
+		t.Errorf("caarlos0/env decoded %s, want %s", got, envSummary)
+	}
+
+	for name, value := range envVariables {
+		t.Setenv(name, value)
+	}
+	var fromEnvconfig envSettings
+	if err := envconfig.Process("", &fromEnvconfig); err != nil {
+		t.Fatalf("envconfig: %v", err)
+	}
+	if got := fromEnvconfig.summary(); got != envSummary {
+		t.Errorf("envconfig decoded %s, want %s", got, envSummary)
+	}
+}
+
+func TestEnvDecodeErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		value   string
+		message string
+	}{
+		{"CACHE_TTL", "soon", "format must be a duration like 1h30m or 90s"},
+		{"MAX_BODY", "lots", "format must be a number followed by a unit like MB or GiB"},
+		{"ZONE", "Mars/Olympus", "must be an IANA name like Asia/Jakarta"},
+		{"SINCE", "yesterday", "format must be YYYY-MM-DDTHH:mm:ssZ"},
+		{"OWNER_ID", "42", "must be a valid ID"},
+	} {
+		var fromEnv envSettings
+		err := env.Parse(&fromEnv, env.Options{Environment: map[string]string{tc.name: tc.value}})
+		if err == nil || !strings.Contains(err.Error(), tc.message) {
+			t.Errorf("caarlos0/env %s=%s: error %v, want %q", tc.name, tc.value, err, tc.message)
+		}
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(tc.name, tc.value)
+			var fromEnvconfig envSettings
+			err := envconfig.Process("", &fromEnvconfig)
+			if err == nil || !strings.Contains(err.Error(), tc.message) {
+				t.Errorf("envconfig %s=%s: error %v, want %q", tc.name, tc.value, err, tc.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrorCode documents one kind of error response. Message may contain
+// {placeholders} that are filled in per request, from Params; Example shows
+// a rendered message so client teams can see what users get.
+type ErrorCode struct {
+	Code    string   `json:"code"`
+	Status  int      `json:"status"`
+	Message string   `json:"message"`
+	Params  []string `json:"params,omitempty"`
+	Example string   `json:"example,omitempty"`
+	// Kind is the sentinel error a CodedError with this code wraps.
+	Kind error `json:"-"`
+}
+
+var (
+	errorCodesMu sync.RWMutex
+	errorCodes   = map[string]ErrorCode{}
+)
+
+// RegisterErrorCode adds a code to the catalog served at /_errors. Codes are
+// meant to be registered from package-level vars, so a duplicate panics at
+// startup rather than silently replacing the documented one.
+func RegisterErrorCode(ec ErrorCode) ErrorCode {
+	if ec.Code == "" || ec.Status < 400 || ec.Status > 599 {
+		panic(fmt.Errorf("error code %q: code and a 4xx/5xx status are required", ec.Code))
+	}
+
+	errorCodesMu.Lock()
+	defer errorCodesMu.Unlock()
+
+	if _, ok := errorCodes[ec.Code]; ok {
+		panic(fmt.Errorf("error code %q registered twice", ec.Code))
+	}
+	errorCodes[ec.Code] = ec
+	return ec
+}
+
+// LookupErrorCode returns the registered code named code.
+func LookupErrorCode(code string) (ErrorCode, bool) {
+	errorCodesMu.RLock()
+	defer errorCodesMu.RUnlock()
+
+	ec, ok := errorCodes[code]
+	return ec, ok
+}
+
+// ErrorCatalog returns every registered code, sorted by code.
+func ErrorCatalog() []ErrorCode {
+	errorCodesMu.RLock()
+	defer errorCodesMu.RUnlock()
+
+	catalog := make([]ErrorCode, 0, len(errorCodes))
+	for _, ec := range errorCodes {
+		catalog = append(catalog, ec)
+	}
+	sort.Slice(catalog, func(i, j int) bool { return catalog[i].Code < catalog[j].Code })
+	return catalog
+}
+
+// WriteErrorCatalog writes the catalog as indented JSON, the same document
+// served at /_errors, for checking into client repositories.
+func WriteErrorCatalog(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(errorCatalogDocument())
+}
+
+func errorCatalogDocument() map[string]interface{} {
+	return map[string]interface{}{"errors": ErrorCatalog()}
+}
+
+// Codes shared by most types. The value.* codes are for the helpers that do
+// not know which type failed, e.g. a YAML or CBOR scalar or the validator's
+// required rule; a type that produces an error itself uses its own code, so
+// clients can tell which type failed.
+var (
+	ErrCodeNotString = RegisterErrorCode(ErrorCode{
+		Code: "value.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeEmpty = RegisterErrorCode(ErrorCode{
+		Code: "value.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "value.invalid_format", Status: http.StatusBadRequest,
+		Message: "format must be {layout}", Params: []string{"layout", "value"}, Example: "format must be YYYY-MM-DD",
+		Kind: ErrInvalidFormat,
+	})
+	// DateTime keeps the message it has always had for a non-string.
+	ErrCodeDateTimeNotString = RegisterErrorCode(ErrorCode{
+		Code: "datetime.not_string", Status: http.StatusBadRequest,
+		Message: "not a valid string", Params: []string{"value"}, Example: "not a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeDateTimeEmpty = RegisterErrorCode(ErrorCode{
+		Code: "datetime.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeDateTimeInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "datetime.invalid_format", Status: http.StatusBadRequest,
+		Message: "format must be {layout}", Params: []string{"layout", "value"}, Example: "format must be YYYY-MM-DDTHH:mm:ssZ",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeDateTimeTooPrecise = RegisterErrorCode(ErrorCode{
+		Code: "datetime.too_precise", Status: http.StatusBadRequest,
+		Message: "must not be more precise than {precision}", Params: []string{"precision", "value"}, Example: "must not be more precise than seconds",
+	})
+	ErrCodeArrayNotString = RegisterErrorCode(ErrorCode{
+		Code: "array.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeArrayEmpty = RegisterErrorCode(ErrorCode{
+		Code: "array.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeInvalidFields = RegisterErrorCode(ErrorCode{
+		Code: "request.invalid_fields", Status: http.StatusBadRequest,
+		Message: "request has invalid fields", Example: "request has invalid fields",
+	})
+	ErrCodeInternal = RegisterErrorCode(ErrorCode{
+		Code: "internal", Status: http.StatusInternalServerError,
+		Message: "internal server error", Example: "internal server error",
+	})
+)
+
+// CodedError is a client error carrying its catalog code, so clients can
+// branch on "code" instead of matching the message. ErrorMiddleware answers
+// it with Code.Status.
+type CodedError struct {
+	Code    ErrorCode
+	Message string
+	// Params filled the placeholders, for translations, see localize.
+	Params map[string]string
+}
+
+func (e CodedError) Error() string {
+	return e.Message
+}
+
+// Err renders ec's message, or its MessageTemplates entry, with params
+// filled into the {placeholders}:
+//
+//	ErrCodeDateTimeInvalidFormat.Err(map[string]string{"layout": "YYYY-MM-DD", "value": s})
+func (ec ErrorCode) Err(params map[string]string) CodedError {
+	template, ok := MessageTemplates.Get()[ec.Code]
+	if !ok {
+		template = ec.Message
+	}
+	return CodedError{Code: ec, Message: fillPlaceholders(template, params), Params: params}
+}
+
+// fillPlaceholders replaces in one pass, so a value containing "{layout}"
+// is left as sent.
+func fillPlaceholders(template string, params map[string]string) string {
+	pairs := make([]string, 0, 2*len(params))
+	for key, value := range params {
+		pairs = append(pairs, "{"+key+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(template)
+}
+
+// errorCodeOf returns the code and params carried by err, or "" for an
+// uncoded error.
+func errorCodeOf(err error) (string, map[string]string) {
+	var coded CodedError
+	if errors.As(err, &coded) {
+		return coded.Code.Code, coded.Params
+	}
+	return "", nil
+}
+
+// codedOr returns err if it carries a code, as the error of a nested type
+// does, and fallback otherwise. Object types use it when their fields fail
+// to decode.
+func codedOr(err error, fallback CodedError) error {
+	if code, _ := errorCodeOf(err); code != "" {
+		return err
+	}
+	return fallback
+}
+
+// prefixed puts prefix, such as a header or parameter name, in front of
+// err's message. A coded error keeps its code; a translation of the code
+// stands for the whole message.
+func prefixed(prefix string, err error) error {
+	var coded CodedError
+	if errors.As(err, &coded) {
+		coded.Message = prefix + " " + coded.Message
+		return coded
+	}
+	return NewBadRequestError(sentinelOf(err), prefix+" "+err.Error())
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// Each type reports its own code, so a client can tell which field's type
+// failed without reading the message.
+func TestPerTypeErrorCodes(t *testing.T) {
+	var request struct {
+		Since DateTime    `json:"since"`
+		Tags  ArrayString `json:"tags"`
+		IDs   ArrayString `json:"ids"`
+		Note  string      `json:"note"`
+	}
+	err := JSONBinding.BindBody([]byte(`{"since":"","tags":"","ids":42,"note":false}`), &request)
+
+	var fields FieldErrors
+	if !errors.As(err, &fields) {
+		t.Fatalf("error %v, want FieldErrors", err)
+	}
+	var got []string
+	for _, fe := range fields {
+		got = append(got, fmt.Sprintf("%s=%s", fe.Field, fe.Code))
+	}
+	want := "since=datetime.empty tags=array.empty ids=array.not_string note=string.not_string"
+	if strings.Join(got, " ") != want {
+		t.Errorf("codes %s, want %s", strings.Join(got, " "), want)
+	}
+}
+
+// Every type's errors carry a code, and the code keeps the sentinel the
+// plain error had.
+func TestErrorCodesAcrossTypes(t *testing.T) {
+	var request struct {
+		Server  IPv4Address `json:"server"`
+		Share   Percentage  `json:"share"`
+		Day     Weekday     `json:"day"`
+		Country CountryCode `json:"country"`
+		Rows    IntRange    `json:"rows"`
+	}
+	body := `{"server":"::1","share":"half","day":"someday","country":"ZZ","rows":"9-1"}`
+	err := JSONBinding.BindBody([]byte(body), &request)
+
+	var fields FieldErrors
+	if !errors.As(err, &fields) {
+		t.Fatalf("error %v, want FieldErrors", err)
+	}
+	var got []string
+	for _, fe := range fields {
+		got = append(got, fmt.Sprintf("%s=%s", fe.Field, fe.Code))
+	}
+	want := "server=ip_address.not_ipv4 share=percentage.invalid_format day=weekday.invalid_value country=country_code.unknown rows=int_range.reversed"
+	if strings.Join(got, " ") != want {
+		t.Errorf("codes %s, want %s", strings.Join(got, " "), want)
+	}
+	if !errors.Is(fields[0], ErrInvalidFormat) {
+		t.Errorf("%v is not ErrInvalidFormat", fields[0])
+	}
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrorMiddlewareOptions tune ErrorMiddleware; the zero value gives the
+// default answers.
+type ErrorMiddlewareOptions struct {
+	// Status maps an application error to a status code, which is sent with
+	// err.Error() as the message. Returning 0 leaves err to the defaults.
+	Status func(err error) int
+	// LogError is told about panics that are not client errors, before the
+	// 500 is sent. It defaults to log.Printf.
+	LogError func(ctx *gin.Context, err error)
+	// OmitFieldDetails answers FieldErrors without the "fields" object.
+	OmitFieldDetails bool
+	// ProblemDetails answers with an RFC 7807 application/problem+json
+	// document, see Problem, instead of {"error": "..."}.
+	ProblemDetails bool
+}
+
+// ErrorMiddleware answers the package's errors, whether a handler panics
+// with them or passes them to ctx.Error:
+//
+//	CodedError              Code.Status {"error": "...", "code": "..."}
+//	BadRequestError         400 {"error": "..."}
+//	FieldErrors             400 {"error": "...", "code": "request.invalid_fields",
+//	                             "fields": {"name": "..."}, "field_codes": {"name": "..."}}
+//	ServiceUnavailableError 503 {"error": "..."}
+//
+// Messages are translated for the client's Accept-Language, see
+// RegisterTranslations, and otherwise go through MessageOverrides; codes
+// come from the catalog and are left out for uncoded errors. encoding/json
+// and validator errors are translated first, see TranslateJSONError and
+// TranslateValidationErrors. Any other panic is logged and answered with
+// 500; any other ctx.Error is left to the handler.
+func ErrorMiddleware(opts ErrorMiddlewareOptions) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			if bad, ok := r.(BadRequestError); ok {
+				recordLegacyPanic(bad.message)
+			}
+			err, ok := r.(error)
+			if !ok {
+				err = fmt.Errorf("panic: %v", r)
+			}
+			if opts.respond(ctx, err) {
+				return
+			}
+			if opts.LogError != nil {
+				opts.LogError(ctx, err)
+			} else {
+				log.Printf("error: %s %s: %v", ctx.Request.Method, ctx.Request.URL.Path, err)
+			}
+			opts.write(ctx, errorResponse{status: ErrCodeInternal.Status, code: ErrCodeInternal.Code, message: ErrCodeInternal.Message})
+		}()
+
+		ctx.Next()
+
+		if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+			return
+		}
+		opts.respond(ctx, ctx.Errors.Last().Err)
+	}
+}
+
+// errorResponse is an answer before it is localized and rendered.
+type errorResponse struct {
+	status  int
+	code    string
+	message string
+	params  map[string]string
+	fields  FieldErrors
+}
+
+// respond writes the answer for err and reports whether it knew how.
+func (opts ErrorMiddlewareOptions) respond(ctx *gin.Context, err error) bool {
+	if opts.Status != nil {
+		if status := opts.Status(err); status != 0 {
+			code, params := errorCodeOf(err)
+			opts.write(ctx, errorResponse{status: status, code: code, message: err.Error(), params: params})
+			return true
+		}
+	}
+
+	err = TranslateValidationErrors(TranslateJSONError(err))
+	var (
+		coded       CodedError
+		badRequest  BadRequestError
+		unavailable ServiceUnavailableError
+		fields      FieldErrors
+	)
+	switch {
+	case errors.As(err, &fields):
+		if opts.OmitFieldDetails {
+			fields = nil
+		}
+		opts.write(ctx, errorResponse{status: ErrCodeInvalidFields.Status, code: ErrCodeInvalidFields.Code, message: ErrCodeInvalidFields.Message, fields: fields})
+	case errors.As(err, &coded):
+		opts.write(ctx, errorResponse{status: coded.Code.Status, code: coded.Code.Code, message: coded.Message, params: coded.Params})
+	case errors.As(err, &badRequest):
+		opts.write(ctx, errorResponse{status: http.StatusBadRequest, message: badRequest.message})
+	case errors.As(err, &unavailable):
+		opts.write(ctx, errorResponse{status: http.StatusServiceUnavailable, message: string(unavailable)})
+	default:
+		return false
+	}
+	return true
+}
+
+// write answers in the request's locale, see requestLocale.
+func (opts ErrorMiddlewareOptions) write(ctx *gin.Context, r errorResponse) {
+	locale := requestLocale(ctx)
+	message := localize(locale, r.code, r.message, r.params)
+	ctx.Header("Content-Language", locale.String())
+
+	if opts.ProblemDetails {
+		problem := NewProblem(ctx, r.status, r.message, r.fields)
+		problem.Detail = message
+		problem.Code = r.code
+		WriteProblem(ctx, problem)
+		return
+	}
+
+	body := gin.H{
+		"error": message,
+	}
+	if r.code != "" {
+		body["code"] = r.code
+	}
+	if len(r.fields) > 0 {
+		details := make(map[string]string, len(r.fields))
+		codes := map[string]string{}
+		for _, e := range r.fields {
+			details[e.Field] = localize(locale, e.Code, e.Message, e.Params)
+			if e.Code != "" {
+				codes[e.Field] = e.Code
+			}
+		}
+		body["fields"] = details
+		if len(codes) > 0 {
+			body["field_codes"] = codes
+		}
+	}
+	ctx.AbortWithStatusJSON(r.status, body)
+}
@@ -0,0 +1,59 @@
+package main
+
+import "errors"
+
+// Sentinel errors for the usual causes of a client error, so callers can
+// tell them apart without comparing messages:
+//
+//	if errors.Is(err, ErrEmptyValue) { ... }
+//
+// Coded errors wrap the sentinel of their ErrorCode.Kind. A
+// BadRequestError, and FieldErrors containing one, matches the sentinel it
+// was built with.
+var (
+	ErrEmptyValue    = errors.New("must not be empty")
+	ErrNotAString    = errors.New("must be a valid string")
+	ErrInvalidFormat = errors.New("invalid format")
+	// ErrWrongType is a JSON value of the wrong type, e.g. a string sent
+	// for an integer field.
+	ErrWrongType = errors.New("wrong type")
+)
+
+// Unwrap returns the sentinel of the error's code, if it has one.
+func (e CodedError) Unwrap() error {
+	return e.Code.Kind
+}
+
+func (e BadRequestError) Is(target error) bool {
+	return target != nil && e.kind == target
+}
+
+// sentinelOf returns the sentinel err matches, or nil.
+func sentinelOf(err error) error {
+	for _, kind := range []error{ErrEmptyValue, ErrNotAString, ErrInvalidFormat, ErrWrongType} {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+	return nil
+}
+
+// Is reports whether any field's error matches target.
+func (fe FieldErrors) Is(target error) bool {
+	for _, e := range fe {
+		if errors.Is(e, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first field error that matches target.
+func (fe FieldErrors) As(target interface{}) bool {
+	for _, e := range fe {
+		if errors.As(e, target) {
+			return true
+		}
+	}
+	return false
+}
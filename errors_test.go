@@ -0,0 +1,30 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBadRequestErrorIs(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  error
+		want error
+	}{
+		// The sentinel comes from construction, not from the wording.
+		{"kind", NewBadRequestError(ErrEmptyValue, "is required"), ErrEmptyValue},
+		{"no kind", NewBadRequestError(nil, "must not be empty"), nil},
+		{"from a coded error", badRequestFrom(ErrCodeInvalidFormat.Err(map[string]string{"layout": "YYYY"})), ErrInvalidFormat},
+		{"from a parse error", badRequestFrom(func() error { _, err := ParseByteSize("lots"); return err }()), ErrInvalidFormat},
+		{"from a plain error", badRequestFrom(errors.New("format must be anything")), nil},
+		{"field error", JSONBinding.BindBody([]byte(`{"value":""}`), &struct {
+			Value Duration `json:"value"`
+		}{}), ErrEmptyValue},
+	} {
+		for _, sentinel := range []error{ErrEmptyValue, ErrNotAString, ErrInvalidFormat} {
+			if got := errors.Is(tc.err, sentinel); got != (sentinel == tc.want) {
+				t.Errorf("%s: errors.Is(%q, %v) = %v", tc.name, tc.err, sentinel, got)
+			}
+		}
+	}
+}
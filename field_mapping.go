@@ -0,0 +1,258 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrCodeFieldMappingNotList = RegisterErrorCode(ErrorCode{
+		Code: "field_mapping.not_list", Status: http.StatusBadRequest,
+		Message: "must be a list of {source, target, transform} objects", Example: "must be a list of {source, target, transform} objects",
+	})
+	ErrCodeFieldMappingEmpty = RegisterErrorCode(ErrorCode{
+		Code: "field_mapping.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeFieldMappingRuleEmpty = RegisterErrorCode(ErrorCode{
+		Code: "field_mapping.rule_empty", Status: http.StatusBadRequest,
+		Message: "mapping {index}: source and target must not be empty", Params: []string{"index"}, Example: "mapping 0: source and target must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeFieldMappingUnknownTransform = RegisterErrorCode(ErrorCode{
+		Code: "field_mapping.unknown_transform", Status: http.StatusBadRequest,
+		Message: "mapping {index}: transform must be one of {transforms}", Params: []string{"index", "transforms"}, Example: "mapping 0: transform must be one of lower, to_bool, to_number, to_string, trim, upper",
+	})
+	ErrCodeFieldMappingUnknownSource = RegisterErrorCode(ErrorCode{
+		Code: "field_mapping.unknown_source", Status: http.StatusBadRequest,
+		Message: "mapping {index}: source field {field} does not exist", Params: []string{"index", "field"}, Example: "mapping 0: source field \"nmae\" does not exist",
+	})
+	ErrCodeFieldMappingUnknownTarget = RegisterErrorCode(ErrorCode{
+		Code: "field_mapping.unknown_target", Status: http.StatusBadRequest,
+		Message: "mapping {index}: target field {field} does not exist", Params: []string{"index", "field"}, Example: "mapping 0: target field \"nmae\" does not exist",
+	})
+	ErrCodeFieldMappingRepeatedTarget = RegisterErrorCode(ErrorCode{
+		Code: "field_mapping.repeated_target", Status: http.StatusBadRequest,
+		Message: "mapping {index}: target field {field} is mapped more than once", Params: []string{"index", "field"}, Example: "mapping 1: target field \"name\" is mapped more than once",
+	})
+	ErrCodeFieldMappingTransformKind = RegisterErrorCode(ErrorCode{
+		Code: "field_mapping.transform_kind", Status: http.StatusBadRequest,
+		Message: "mapping {index}: transform {transform} expects {want} but gets {kind}", Params: []string{"index", "transform", "want", "kind"}, Example: "mapping 0: transform \"trim\" expects string but gets number",
+	})
+	ErrCodeFieldMappingTargetKind = RegisterErrorCode(ErrorCode{
+		Code: "field_mapping.target_kind", Status: http.StatusBadRequest,
+		Message: "mapping {index}: produces {kind} but target field {field} is {want}", Params: []string{"index", "kind", "field", "want"}, Example: "mapping 0: produces string but target field \"age\" is number",
+	})
+	ErrCodeFieldMappingValueKind = RegisterErrorCode(ErrorCode{
+		Code: "field_mapping.value_kind", Status: http.StatusBadRequest,
+		Message: "{field}: must be a {kind}", Params: []string{"field", "kind"}, Example: "age: must be a string",
+	})
+	ErrCodeFieldMappingTransformFailed = RegisterErrorCode(ErrorCode{
+		Code: "field_mapping.transform_failed", Status: http.StatusBadRequest,
+		Message: "{field}: {reason}", Params: []string{"field", "reason"}, Example: "active: \"maybe\" is not a boolean",
+	})
+)
+
+type FieldKind string
+
+const (
+	FieldKindString FieldKind = "string"
+	FieldKindNumber FieldKind = "number"
+	FieldKindBool   FieldKind = "bool"
+	// FieldKindAny is only used by transforms that accept every input kind.
+	FieldKindAny FieldKind = "any"
+)
+
+// FieldSchema lists the fields of one side of a mapping and their kinds.
+type FieldSchema map[string]FieldKind
+
+type MappingTransform struct {
+	In  FieldKind
+	Out FieldKind
+	Fn  func(v interface{}) (interface{}, error)
+}
+
+// MappingTransforms is the allow-list of transform names a FieldMapping may
+// reference. Register additional ones at startup.
+var MappingTransforms = map[string]MappingTransform{
+	"trim": {In: FieldKindString, Out: FieldKindString, Fn: func(v interface{}) (interface{}, error) {
+		return strings.TrimSpace(v.(string)), nil
+	}},
+	"upper": {In: FieldKindString, Out: FieldKindString, Fn: func(v interface{}) (interface{}, error) {
+		return strings.ToUpper(v.(string)), nil
+	}},
+	"lower": {In: FieldKindString, Out: FieldKindString, Fn: func(v interface{}) (interface{}, error) {
+		return strings.ToLower(v.(string)), nil
+	}},
+	"to_number": {In: FieldKindString, Out: FieldKindNumber, Fn: func(v interface{}) (interface{}, error) {
+		return strconv.ParseFloat(strings.TrimSpace(v.(string)), 64)
+	}},
+	"to_bool": {In: FieldKindString, Out: FieldKindBool, Fn: func(v interface{}) (interface{}, error) {
+		b, ok := parseFlexibleBool(v.(string))
+		if !ok {
+			return nil, fmt.Errorf("%q is not a boolean", v)
+		}
+		return b, nil
+	}},
+	"to_string": {In: FieldKindAny, Out: FieldKindString, Fn: func(v interface{}) (interface{}, error) {
+		return fmt.Sprint(v), nil
+	}},
+}
+
+type FieldMappingRule struct {
+	Source    string   `json:"source"`
+	Target    string   `json:"target"`
+	Transform []string `json:"transform,omitempty"`
+}
+
+// FieldMapping is a source→target mapping document:
+//
+//	[{"source":"first_name","target":"given_name","transform":["trim"]}]
+//
+// Unmarshaling checks the document shape and transform names; Compile
+// checks it against concrete schemas and produces a Mapper.
+type FieldMapping struct {
+	rules []FieldMappingRule
+}
+
+// Mapper converts one source record into a target record.
+type Mapper func(record map[string]interface{}) (map[string]interface{}, error)
+
+func (fm FieldMapping) Rules() []FieldMappingRule {
+	return fm.rules
+}
+
+func (fm FieldMapping) Compile(source FieldSchema, target FieldSchema) (Mapper, error) {
+	type step struct {
+		source string
+		kind   FieldKind
+		target string
+		fns    []func(interface{}) (interface{}, error)
+	}
+
+	steps := make([]step, 0, len(fm.rules))
+	targets := map[string]bool{}
+	for i, rule := range fm.rules {
+		kind, ok := source[rule.Source]
+		if !ok {
+			return nil, ErrCodeFieldMappingUnknownSource.Err(map[string]string{"index": strconv.Itoa(i), "field": strconv.Quote(rule.Source)})
+		}
+		want, ok := target[rule.Target]
+		if !ok {
+			return nil, ErrCodeFieldMappingUnknownTarget.Err(map[string]string{"index": strconv.Itoa(i), "field": strconv.Quote(rule.Target)})
+		}
+		if targets[rule.Target] {
+			return nil, ErrCodeFieldMappingRepeatedTarget.Err(map[string]string{"index": strconv.Itoa(i), "field": strconv.Quote(rule.Target)})
+		}
+		targets[rule.Target] = true
+
+		s := step{source: rule.Source, kind: kind, target: rule.Target}
+		for _, name := range rule.Transform {
+			t := MappingTransforms[name]
+			if t.In != FieldKindAny && t.In != kind {
+				return nil, ErrCodeFieldMappingTransformKind.Err(map[string]string{"index": strconv.Itoa(i), "transform": strconv.Quote(name), "want": string(t.In), "kind": string(kind)})
+			}
+			kind = t.Out
+			s.fns = append(s.fns, t.Fn)
+		}
+		if kind != want {
+			return nil, ErrCodeFieldMappingTargetKind.Err(map[string]string{"index": strconv.Itoa(i), "kind": string(kind), "field": strconv.Quote(rule.Target), "want": string(want)})
+		}
+		steps = append(steps, s)
+	}
+
+	return func(record map[string]interface{}) (map[string]interface{}, error) {
+		out := make(map[string]interface{}, len(steps))
+		for _, s := range steps {
+			v, ok := record[s.source]
+			if !ok || v == nil {
+				continue
+			}
+			if fieldKindOf(v) != s.kind {
+				return nil, ErrCodeFieldMappingValueKind.Err(map[string]string{"field": s.source, "kind": string(s.kind)})
+			}
+			var err error
+			for _, fn := range s.fns {
+				if v, err = fn(v); err != nil {
+					return nil, ErrCodeFieldMappingTransformFailed.Err(map[string]string{"field": s.source, "reason": err.Error()})
+				}
+			}
+			out[s.target] = v
+		}
+		return out, nil
+	}, nil
+}
+
+func (fm FieldMapping) IsZero() bool {
+	return len(fm.rules) == 0
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (fm FieldMapping) MarshalJSON() ([]byte, error) {
+	if fm.rules == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(fm.rules)
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (fm *FieldMapping) UnmarshalJSON(b []byte) error {
+	var rules []FieldMappingRule
+	if err := json.Unmarshal(b, &rules); err != nil {
+		return codedOr(err, ErrCodeFieldMappingNotList.Err(nil))
+	}
+	if len(rules) == 0 {
+		return ErrCodeFieldMappingEmpty.Err(nil)
+	}
+
+	for i, rule := range rules {
+		if rule.Source == "" || rule.Target == "" {
+			return ErrCodeFieldMappingRuleEmpty.Err(map[string]string{"index": strconv.Itoa(i)})
+		}
+		for _, name := range rule.Transform {
+			if _, ok := MappingTransforms[name]; !ok {
+				return ErrCodeFieldMappingUnknownTransform.Err(map[string]string{"index": strconv.Itoa(i), "transforms": strings.Join(mappingTransformNames(), ", ")})
+			}
+		}
+	}
+
+	fm.rules = rules
+
+	return nil
+}
+
+func fieldKindOf(v interface{}) FieldKind {
+	switch v.(type) {
+	case string:
+		return FieldKindString
+	case float64, json.Number:
+		return FieldKindNumber
+	case bool:
+		return FieldKindBool
+	}
+	return FieldKindAny
+}
+
+func mappingTransformNames() []string {
+	names := make([]string, 0, len(MappingTransforms))
+	for name := range MappingTransforms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,52 @@
+package main
+
+// DateTime, Duration, ArrayString and ByteSize implement flag.Value, and
+// pflag's Type, so CLI tools built next to the API take the same input
+// with the same validation:
+//
+//	var since DateTime
+//	var tags ArrayString
+//	flag.Var(&since, "since", "report start, e.g. 2020-01-01T00:00:00Z")
+//	flag.Var(&tags, "tags", "comma separated tags")
+//
+// A flag given twice keeps the last value, as with the built-in flags.
+
+/*
+	This part implements `flag.Value`
+	type Value interface {
+		String() string
+		Set(string) error
+	}
+*/
+func (dt *DateTime) Set(s string) error {
+	return unmarshalTextAsJSON(dt, []byte(s))
+}
+
+// Type names the value in pflag's usage output.
+func (dt *DateTime) Type() string {
+	return "datetime"
+}
+
+func (d *Duration) Set(s string) error {
+	return unmarshalTextAsJSON(d, []byte(s))
+}
+
+func (d *Duration) Type() string {
+	return "duration"
+}
+
+func (dt *ArrayString) Set(s string) error {
+	return unmarshalTextAsJSON(dt, []byte(s))
+}
+
+func (dt *ArrayString) Type() string {
+	return "list"
+}
+
+func (bs *ByteSize) Set(s string) error {
+	return unmarshalTextAsJSON(bs, []byte(s))
+}
+
+func (bs *ByteSize) Type() string {
+	return "bytesize"
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrCodeFlexibleBoolNotBool = RegisterErrorCode(ErrorCode{
+		Code: "flexible_bool.not_bool", Status: http.StatusBadRequest,
+		Message: "must be a valid boolean", Params: []string{"value"}, Example: "must be a valid boolean",
+	})
+	ErrCodeFlexibleBoolInvalidValue = RegisterErrorCode(ErrorCode{
+		Code: "flexible_bool.invalid_value", Status: http.StatusBadRequest,
+		Message: flexibleBoolExpected, Params: []string{"value"}, Example: flexibleBoolExpected,
+	})
+)
+
+// FlexibleBool accepts the boolean spellings legacy clients send (true, 1,
+// "true", "1", "yes", "on", ...) and always marshals a plain JSON boolean.
+type FlexibleBool bool
+
+func (fb FlexibleBool) Bool() bool {
+	return bool(fb)
+}
+
+// flexibleBoolSpellings pairs each accepted true spelling with its false
+// one; the error message lists them all.
+var flexibleBoolSpellings = [][2]string{
+	{"true", "false"}, {"1", "0"}, {"yes", "no"}, {"on", "off"}, {"y", "n"}, {"t", "f"},
+}
+
+var flexibleBoolExpected = func() string {
+	spellings := make([]string, 0, 2*len(flexibleBoolSpellings))
+	for _, pair := range flexibleBoolSpellings {
+		spellings = append(spellings, pair[0], pair[1])
+	}
+	return "must be one of " + strings.Join(spellings, ", ")
+}()
+
+func parseFlexibleBool(s string) (bool, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, pair := range flexibleBoolSpellings {
+		switch s {
+		case pair[0]:
+			return true, true
+		case pair[1]:
+			return false, true
+		}
+	}
+	return false, false
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (fb FlexibleBool) MarshalJSON() ([]byte, error) {
+	return json.Marshal(bool(fb))
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (fb *FlexibleBool) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+
+	var s string
+	switch {
+	case StrictParsing && !bytes.Equal(b, []byte("true")) && !bytes.Equal(b, []byte("false")):
+		return ErrCodeFlexibleBoolNotBool.Err(map[string]string{"value": string(b)})
+	case len(b) > 0 && b[0] == '"':
+		if err := json.Unmarshal(b, &s); err != nil {
+			return ErrCodeFlexibleBoolNotBool.Err(map[string]string{"value": string(b)})
+		}
+	case bytes.Equal(b, []byte("true")), bytes.Equal(b, []byte("false")):
+		s = string(b)
+	default:
+		var n json.Number
+		if err := json.Unmarshal(b, &n); err != nil {
+			return ErrCodeFlexibleBoolNotBool.Err(map[string]string{"value": string(b)})
+		}
+		s = n.String()
+	}
+
+	v, ok := parseFlexibleBool(s)
+	if !ok {
+		return ErrCodeFlexibleBoolInvalidValue.Err(map[string]string{"value": s})
+	}
+
+	*fb = FlexibleBool(v)
+
+	return nil
+}
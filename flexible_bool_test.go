@@ -0,0 +1,41 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFlexibleBoolSpellings(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		want  bool
+	}{
+		{`true`, true}, {`false`, false}, {`1`, true}, {`0`, false},
+		{`"TRUE"`, true}, {`"False"`, false}, {`"yes"`, true}, {`"no"`, false},
+		{`"on"`, true}, {`"off"`, false}, {`"Y"`, true}, {`"n"`, false},
+		{`"t"`, true}, {`" f "`, false},
+	} {
+		var v FlexibleBool
+		if err := unmarshalBinaryJSON(&v, []byte(tc.input)); err != nil || v.Bool() != tc.want {
+			t.Errorf("%s = %v, %v, want %v", tc.input, v, err, tc.want)
+		}
+	}
+}
+
+// The message names every spelling the decoder takes, so a client told
+// its value is wrong is not left guessing that "on" works.
+func TestFlexibleBoolMessage(t *testing.T) {
+	const want = "must be one of true, false, 1, 0, yes, no, on, off, y, n, t, f"
+	var v FlexibleBool
+	if err := unmarshalBinaryJSON(&v, []byte(`"maybe"`)); err == nil || err.Error() != want {
+		t.Errorf("error %v, want %q", err, want)
+	}
+	for _, pair := range flexibleBoolSpellings {
+		for _, spelling := range pair {
+			b, _ := json.Marshal(spelling)
+			if err := unmarshalBinaryJSON(&v, b); err != nil {
+				t.Errorf("listed spelling %q rejected: %v", spelling, err)
+			}
+		}
+	}
+}
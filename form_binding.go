@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// gin's form binding decodes a struct-typed field by passing the raw text to
+// json.Unmarshal, which fails for ?time_at=2020-01-01T02:02:05Z, and sets
+// string-based types such as Enum without validating them. BindQuery,
+// BindForm and BindURI hand those fields the value as a JSON string
+// instead, so they are validated exactly as in a JSON body and reported
+// together as FieldErrors. Plain fields (string, int, bool, ...) are still
+// bound by gin.
+//
+//	type RequestContentScheduleSearch struct {
+//		From DateTime    `form:"from"`
+//		Tags ArrayString `form:"tags" ctype:"sep=;"`
+//		Page int         `form:"page"`
+//	}
+//
+// Only top-level fields are handled, and a repeated parameter uses its
+// first value.
+
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// BindQuery binds the query string using `form` tags.
+func BindQuery(ctx *gin.Context, obj interface{}) error {
+	return bindValues(ctx.Request.URL.Query(), "form", obj)
+}
+
+// BindForm binds an urlencoded or multipart body, plus the query string,
+// using `form` tags.
+func BindForm(ctx *gin.Context, obj interface{}) error {
+	if err := ctx.Request.ParseMultipartForm(32 << 20); err != nil && err != http.ErrNotMultipart {
+		return err
+	}
+	return bindValues(ctx.Request.Form, "form", obj)
+}
+
+// BindURI binds route parameters using `uri` tags.
+func BindURI(ctx *gin.Context, obj interface{}) error {
+	values := map[string][]string{}
+	for _, param := range ctx.Params {
+		values[param.Key] = []string{param.Value}
+	}
+	return bindValues(values, "uri", obj)
+}
+
+func bindValues(values map[string][]string, tag string, obj interface{}) error {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		panic("BindQuery, BindForm and BindURI need a pointer to a struct, got " + v.Type().String())
+	}
+	v = v.Elem()
+
+	plain := make(map[string][]string, len(values))
+	for key, value := range values {
+		plain[key] = value
+	}
+	type formField struct {
+		index  int
+		name   string
+		custom bool
+	}
+	var fields []formField
+	for i := 0; i < v.NumField(); i++ {
+		spec := v.Type().Field(i)
+		name, _, _ := strings.Cut(spec.Tag.Get(tag), ",")
+		if name == "-" || !spec.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = spec.Name
+		}
+		custom := isCustomType(spec.Type)
+		if custom {
+			delete(plain, name)
+		}
+		fields = append(fields, formField{index: i, name: name, custom: custom})
+	}
+
+	if err := binding.MapFormWithTag(obj, plain, tag); err != nil {
+		return err
+	}
+
+	var fe FieldErrors
+	for _, field := range fields {
+		value, ok := values[field.name]
+		if !ok || len(value) == 0 {
+			continue
+		}
+		if field.custom {
+			raw, _ := json.Marshal(value[0])
+			if err := decodeField(v.Field(field.index), v.Type().Field(field.index), raw); err != nil {
+				fe = append(fe, newFieldError(field.name, err))
+				continue
+			}
+		}
+		if err := validateField(v.Field(field.index)); err != nil {
+			fe = append(fe, newFieldError(field.name, err))
+		}
+	}
+	if len(fe) > 0 {
+		return fe
+	}
+	if err := validateRequest(obj); err != nil {
+		return err
+	}
+
+	if binding.Validator == nil {
+		return nil
+	}
+	return binding.Validator.ValidateStruct(obj)
+}
+
+// isCustomType reports whether t decodes itself from JSON or text, which
+// is what sets the package's types apart from plain Go values. time.Time is
+// left to gin, which reads its time_format tag.
+func isCustomType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(time.Time{}) {
+		return false
+	}
+	p := reflect.PtrTo(t)
+	return p.Implements(jsonUnmarshalerType) || p.Implements(textUnmarshalerType)
+}
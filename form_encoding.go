@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// EncodeQuery is the inverse of BindQuery: it turns a struct into
+// url.Values by its `form` tags, each field in its text form, so a client
+// builds the same filters a handler binds:
+//
+//	query, err := EncodeQuery(RequestContentScheduleSearch{
+//		From: from,
+//		Tags: ArrayString{"a", "b"},
+//	})
+//	req.URL.RawQuery = query.Encode() // from=2020-01-01T02%3A02%3A05Z&page=0&tags=a%3Bb
+//
+// A DateTime's ctype layout and an ArrayString's ctype sep are applied, so
+// the value binds back into the same field. A plain slice is sent as a
+// repeated parameter. A nil pointer is left out, as is a field tagged
+// `omitempty` whose value is zero or empty. Only top-level fields are
+// encoded.
+func EncodeQuery(obj interface{}) (url.Values, error) {
+	v := reflect.ValueOf(obj)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		panic("EncodeQuery needs a struct, got " + reflect.TypeOf(obj).String())
+	}
+
+	values := url.Values{}
+	for i := 0; i < v.NumField(); i++ {
+		spec := v.Type().Field(i)
+		name, opts, _ := strings.Cut(spec.Tag.Get("form"), ",")
+		if name == "-" || !spec.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = spec.Name
+		}
+		field := v.Field(i)
+		if field.Kind() == reflect.Ptr && field.IsNil() || omitField(field, opts) {
+			continue
+		}
+		for field.Kind() == reflect.Ptr {
+			field = field.Elem()
+		}
+
+		if field.Kind() == reflect.Slice && !marshalsItself(field.Type()) {
+			for j := 0; j < field.Len(); j++ {
+				s, err := formatText(field.Index(j))
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", name, err)
+				}
+				values.Add(name, s)
+			}
+			continue
+		}
+		s, err := formatQueryField(field, spec)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		values.Set(name, s)
+	}
+	return values, nil
+}
+
+func formatQueryField(field reflect.Value, spec reflect.StructField) (string, error) {
+	options := ctypeOptions(spec.Tag.Get("ctype"))
+	switch value := field.Interface().(type) {
+	case DateTime:
+		if layout, ok := options["layout"]; ok {
+			value.layout = layout
+			return value.String(), nil
+		}
+	case ArrayString:
+		if sep, ok := options["sep"]; ok {
+			return strings.Join(value, sep), nil
+		}
+	}
+	return formatText(field)
+}
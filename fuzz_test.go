@@ -0,0 +1,234 @@
+package main
+
+import (
+	"testing"
+
+	"myapp/typetest"
+)
+
+// One fuzz target per type. Plain go test replays the seeds, typetest.Corpus
+// and testdata/fuzz; fuzz one type with
+//
+//	go test -run '^$' -fuzz '^FuzzDateTimeUnmarshal$' -fuzztime 1m
+//
+// and commit any input it saves to testdata/fuzz along with the fix.
+
+func FuzzArrayStringUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, arrayStringCodec)
+}
+
+func FuzzBase64BytesUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[Base64Bytes]{Valid: []string{`"aGVsbG8_Pz8"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzBasisPointsUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[BasisPoints]{Valid: []string{`125`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzBitStringUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[BitString]{Valid: []string{`"10110010"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzUsernameUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[Username]{Valid: []string{`"devi"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzBreakerConfigUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[BreakerConfig]{Valid: []string{`{"window":"30s"}`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzByteSizeUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, byteSizeCodec)
+}
+
+func FuzzCIDRUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[CIDR]{Valid: []string{`"10.0.0.0/8"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzCellRangeUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[CellRange]{Valid: []string{`"'Q1 Report'!b2:d10"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzChannelUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[Channel]{Valid: []string{`{"type":"email","address":"ops@example.com"}`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzCompositeKeyUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[CompositeKey]{Valid: []string{`"acme:invoice:INV-42"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzCountryCodeUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[CountryCode]{Valid: []string{`"de"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzCreditCardNumberUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[CreditCardNumber]{Valid: []string{`"4111 1111 1111 1111"`}, AllowPanic: allowBadRequest, WriteOnly: true})
+}
+
+func FuzzCronExpressionUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[CronExpression]{Valid: []string{`"*/30 9-10 * * MON-FRI"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzCurrencyCodeUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[CurrencyCode]{Valid: []string{`"eur"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzDateUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, dateCodec)
+}
+
+func FuzzDateRangeUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[DateRange]{Valid: []string{`{"from":"2024-03-05","to":"2024-03-08"}`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzDateTimeUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, dateTimeCodec)
+}
+
+func FuzzDelimitedMapUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[DelimitedMap]{Valid: []string{`"team:payments, env:prod, tier:1"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzDurationUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, durationCodec)
+}
+
+func FuzzOrderStatusUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, orderStatusCodec)
+}
+
+func FuzzFieldMappingUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[FieldMapping]{Valid: []string{`[{"source":"First Name","target":"given_name","transform":["trim"]}]`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzFlexibleBoolUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, flexibleBoolCodec)
+}
+
+func FuzzGeoPointUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, geoPointCodec)
+}
+
+func FuzzHTTPDateUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[HTTPDate]{Valid: []string{`"Sun, 06 Nov 1994 08:49:37 GMT"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzHealthCheckUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[HealthCheck]{Valid: []string{`{"url":"https://example.com/healthz","interval":"30s","timeout":"5s"}`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzHexColorUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[HexColor]{Valid: []string{`"#1E90FF"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzIPAddressUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, ipAddressCodec)
+}
+
+func FuzzIntRangeUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[IntRange]{Valid: []string{`"100-500"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzJSONRawUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[JSONRaw]{Valid: []string{`{"a":[1,2,{"b":null}]}`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzLanguageTagUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[LanguageTag]{Valid: []string{`"en-US"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzMaskedStringUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[MaskedString]{Valid: []string{`"3171234567890001"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzMonthUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[Month]{Valid: []string{`"AUG"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzNonEmptyStringUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[NonEmptyString]{Valid: []string{`"Devi"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzNormalizedStringUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[NormalizedString]{Valid: []string{`" José \t  Díaz "`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzNullDateTimeUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, nullDateTimeCodec)
+}
+
+func FuzzNullInt64Unmarshal(f *testing.F) {
+	typetest.Fuzz(f, nullInt64Codec)
+}
+
+func FuzzNullStringUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, nullStringCodec)
+}
+
+func FuzzObfuscatedIDUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, obfuscatedIDCodec)
+}
+
+func FuzzPasswordUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[Password]{Valid: []string{`"correct horse 1"`}, AllowPanic: allowBadRequest, WriteOnly: true})
+}
+
+func FuzzPercentageUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, percentageCodec)
+}
+
+func FuzzPeriodUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, periodCodec)
+}
+
+func FuzzPriorityUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, priorityCodec)
+}
+
+func FuzzRecurrenceUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[Recurrence]{Valid: []string{`"FREQ=WEEKLY;BYDAY=MO,WE"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzRegexPatternUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[RegexPattern]{Valid: []string{`"^INV-\\d{4}$"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzResourceNameUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[ResourceName]{Valid: []string{`"projects/acme/locations/asia-southeast2/jobs/nightly-export"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzRetryPolicyUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[RetryPolicy]{Valid: []string{`{"max_attempts":5,"backoff":"exponential","base":"200ms","max":"30s"}`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzSemverUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, semverCodec)
+}
+
+func FuzzShortCodeUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[ShortCode]{Valid: []string{`"ABCD1234A"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzSlugUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[Slug]{Valid: []string{`"hello-world"`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzStringInt64Unmarshal(f *testing.F) {
+	typetest.Fuzz(f, stringInt64Codec)
+}
+
+func FuzzTimeRangeUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[TimeRange]{Valid: []string{`{"from":"2024-03-05T11:00:00+07:00","to":"2024-03-05T12:30:00+07:00"}`}, AllowPanic: allowBadRequest})
+}
+
+func FuzzTimezoneUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, timezoneCodec)
+}
+
+func FuzzTrimmedStringUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, trimmedStringCodec)
+}
+
+func FuzzWeekdayUnmarshal(f *testing.F) {
+	typetest.Fuzz(f, typetest.Codec[Weekday]{Valid: []string{`"Sun"`}, AllowPanic: allowBadRequest})
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrCodeGeoPointNotString = RegisterErrorCode(ErrorCode{
+		Code: "geo_point.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeGeoPointWrongType = RegisterErrorCode(ErrorCode{
+		Code: "geo_point.wrong_type", Status: http.StatusBadRequest,
+		Message: "must be a {lat, lng} object or a \"lat,lng\" string", Example: "must be a {lat, lng} object or a \"lat,lng\" string",
+	})
+	ErrCodeGeoPointInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "geo_point.invalid_format", Status: http.StatusBadRequest,
+		Message: "format must be lat,lng", Params: []string{"value"}, Example: "format must be lat,lng",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeGeoPointNotNumbers = RegisterErrorCode(ErrorCode{
+		Code: "geo_point.not_numbers", Status: http.StatusBadRequest,
+		Message: "lat and lng must be numbers", Example: "lat and lng must be numbers",
+	})
+	ErrCodeGeoPointEmpty = RegisterErrorCode(ErrorCode{
+		Code: "geo_point.empty", Status: http.StatusBadRequest,
+		Message: "lat and lng must not be empty", Example: "lat and lng must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeGeoPointLatNotNumber = RegisterErrorCode(ErrorCode{
+		Code: "geo_point.lat_not_number", Status: http.StatusBadRequest,
+		Message: "lat must be a number", Example: "lat must be a number",
+	})
+	ErrCodeGeoPointLngNotNumber = RegisterErrorCode(ErrorCode{
+		Code: "geo_point.lng_not_number", Status: http.StatusBadRequest,
+		Message: "lng must be a number", Example: "lng must be a number",
+	})
+	ErrCodeGeoPointLatRange = RegisterErrorCode(ErrorCode{
+		Code: "geo_point.lat_range", Status: http.StatusBadRequest,
+		Message: "lat must be between -90 and 90", Example: "lat must be between -90 and 90",
+	})
+	ErrCodeGeoPointLngRange = RegisterErrorCode(ErrorCode{
+		Code: "geo_point.lng_range", Status: http.StatusBadRequest,
+		Message: "lng must be between -180 and 180", Example: "lng must be between -180 and 180",
+	})
+)
+
+// earthRadiusMeters is the mean Earth radius used by DistanceTo.
+const earthRadiusMeters = 6371008.8
+
+// GeoPoint accepts {"lat":-6.2,"lng":106.8} or "-6.2,106.8" and always
+// marshals as the object form.
+type GeoPoint struct {
+	lat float64
+	lng float64
+}
+
+type geoPointJSON struct {
+	Lat *float64 `json:"lat"`
+	Lng *float64 `json:"lng"`
+}
+
+func NewGeoPoint(lat float64, lng float64) (GeoPoint, error) {
+	if math.IsNaN(lat) || lat < -90 || lat > 90 {
+		return GeoPoint{}, ErrCodeGeoPointLatRange.Err(nil)
+	}
+	if math.IsNaN(lng) || lng < -180 || lng > 180 {
+		return GeoPoint{}, ErrCodeGeoPointLngRange.Err(nil)
+	}
+	return GeoPoint{lat: lat, lng: lng}, nil
+}
+
+func ParseGeoPoint(s string) (GeoPoint, error) {
+	latStr, lngStr, ok := strings.Cut(s, ",")
+	if !ok {
+		return GeoPoint{}, ErrCodeGeoPointInvalidFormat.Err(map[string]string{"value": s})
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latStr), 64)
+	if err != nil {
+		return GeoPoint{}, ErrCodeGeoPointLatNotNumber.Err(nil)
+	}
+	lng, err := strconv.ParseFloat(strings.TrimSpace(lngStr), 64)
+	if err != nil {
+		return GeoPoint{}, ErrCodeGeoPointLngNotNumber.Err(nil)
+	}
+	return NewGeoPoint(lat, lng)
+}
+
+func (gp GeoPoint) Lat() float64 {
+	return gp.lat
+}
+
+func (gp GeoPoint) Lng() float64 {
+	return gp.lng
+}
+
+// DistanceTo returns the great-circle distance in meters (haversine formula).
+func (gp GeoPoint) DistanceTo(other GeoPoint) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(other.lat - gp.lat)
+	dLng := toRad(other.lng - gp.lng)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(gp.lat))*math.Cos(toRad(other.lat))*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+func (gp GeoPoint) String() string {
+	return strconv.FormatFloat(gp.lat, 'f', -1, 64) + "," + strconv.FormatFloat(gp.lng, 'f', -1, 64)
+}
+
+func (gp GeoPoint) IsZero() bool {
+	return gp == GeoPoint{}
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (gp GeoPoint) MarshalJSON() ([]byte, error) {
+	return json.Marshal(geoPointJSON{Lat: &gp.lat, Lng: &gp.lng})
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (gp *GeoPoint) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+
+	var (
+		point GeoPoint
+		err   error
+	)
+	switch {
+	case len(b) > 0 && b[0] == '"':
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return ErrCodeGeoPointNotString.Err(map[string]string{"value": string(b)})
+		}
+		point, err = ParseGeoPoint(s)
+	case len(b) > 0 && b[0] == '{':
+		var raw geoPointJSON
+		if err := json.Unmarshal(b, &raw); err != nil {
+			return ErrCodeGeoPointNotNumbers.Err(nil)
+		}
+		if raw.Lat == nil || raw.Lng == nil {
+			return ErrCodeGeoPointEmpty.Err(nil)
+		}
+		point, err = NewGeoPoint(*raw.Lat, *raw.Lng)
+	default:
+		return ErrCodeGeoPointWrongType.Err(nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	*gp = point
+
+	return nil
+}
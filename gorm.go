@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// The types tell GORM their column type, so AutoMigrate creates a usable
+// table instead of failing with "unsupported data type": GormDataType
+// gives the portable type ("time", "string", ...) GORM maps per database,
+// and GormDBDataType, where one database needs something else, the exact
+// column type:
+//
+//	type ShipmentRecord struct {
+//		ID        uint
+//		ShippedAt DateTime    // timestamptz
+//		Tags      ArrayString // text[] on Postgres, text elsewhere
+//		Discount  Percentage  // decimal(19,4)
+//		Origin    IPAddress   // inet on Postgres
+//		Spot      GeoPoint    // jsonb on Postgres, json on MySQL
+//	}
+//
+// Values are written and read through Scan and Value, see sql.go. On
+// Postgres ArrayString is written as an array literal, the form a text[]
+// column accepts.
+
+/*
+	This part implements `schema.GormDataTypeInterface`
+	type GormDataTypeInterface interface {
+		GormDataType() string
+	}
+*/
+func (dt DateTime) GormDataType() string { return string(schema.Time) }
+
+func (ndt NullDateTime) GormDataType() string { return string(schema.Time) }
+
+func (d Date) GormDataType() string { return "date" }
+
+func (dt ArrayString) GormDataType() string { return string(schema.String) }
+
+/*
+	This part implements `migrator.GormDataTypeInterface`
+	type GormDataTypeInterface interface {
+		GormDBDataType(*gorm.DB, *schema.Field) string
+	}
+*/
+func (dt ArrayString) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "text[]"
+	}
+	return "text"
+}
+
+/*
+	This part implements `gorm.Valuer`
+	type Valuer interface {
+		GormValue(context.Context, *gorm.DB) clause.Expr
+	}
+*/
+func (dt ArrayString) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
+	if dt != nil && db.Dialector.Name() == "postgres" {
+		return clause.Expr{SQL: "?", Vars: []interface{}{formatPostgresArray(dt)}}
+	}
+	value, _ := dt.Value()
+	return clause.Expr{SQL: "?", Vars: []interface{}{value}}
+}
+
+// Duration is stored as nanoseconds, which overflow an integer column.
+func (d Duration) GormDataType() string { return string(schema.Int) }
+
+func (d Duration) GormDBDataType(db *gorm.DB, field *schema.Field) string { return "bigint" }
+
+// Percentage keeps the 4 decimal places of a percent it is exact to.
+func (p Percentage) GormDataType() string { return "decimal" }
+
+func (p Percentage) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return "decimal(19,4)"
+}
+
+func (dr DateRange) GormDataType() string { return string(schema.String) }
+
+func (dr DateRange) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return gormPostgresType(db, "daterange")
+}
+
+func (tr TimeRange) GormDataType() string { return string(schema.String) }
+
+func (tr TimeRange) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return gormPostgresType(db, "tstzrange")
+}
+
+func (ip IPAddress) GormDataType() string { return string(schema.String) }
+
+func (ip IPAddress) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return gormPostgresType(db, "inet")
+}
+
+func (c CIDR) GormDataType() string { return string(schema.String) }
+
+func (c CIDR) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return gormPostgresType(db, "cidr")
+}
+
+func (jr JSONRaw) GormDataType() string { return "json" }
+
+func (jr JSONRaw) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return gormJSONType(db)
+}
+
+func (bb Base64Bytes) GormDataType() string { return string(schema.Bytes) }
+
+func (e Enum[T]) GormDataType() string { return string(schema.String) }
+
+func (ms MaskedString) GormDataType() string { return string(schema.String) }
+
+func (bs BitString) GormDataType() string        { return string(schema.String) }
+func (bs BoundedString[T]) GormDataType() string { return string(schema.String) }
+func (r CellRange) GormDataType() string         { return string(schema.String) }
+func (ck CompositeKey) GormDataType() string     { return string(schema.String) }
+func (c CronExpression) GormDataType() string    { return string(schema.String) }
+func (c HexColor) GormDataType() string          { return string(schema.String) }
+func (hd HTTPDate) GormDataType() string         { return string(schema.String) }
+func (lt LanguageTag) GormDataType() string      { return string(schema.String) }
+func (p Period) GormDataType() string            { return string(schema.String) }
+func (r Recurrence) GormDataType() string        { return string(schema.String) }
+func (rp RegexPattern) GormDataType() string     { return string(schema.String) }
+func (rn ResourceName) GormDataType() string     { return string(schema.String) }
+func (v Semver) GormDataType() string            { return string(schema.String) }
+func (sc ShortCode) GormDataType() string        { return string(schema.String) }
+func (tz Timezone) GormDataType() string         { return string(schema.String) }
+
+func (bc BreakerConfig) GormDataType() string { return "json" }
+func (c Channel) GormDataType() string        { return "json" }
+func (dm DelimitedMap) GormDataType() string  { return "json" }
+func (fm FieldMapping) GormDataType() string  { return "json" }
+func (gp GeoPoint) GormDataType() string      { return "json" }
+func (hc HealthCheck) GormDataType() string   { return "json" }
+func (ir IntRange) GormDataType() string      { return "json" }
+func (rp RetryPolicy) GormDataType() string   { return "json" }
+
+func (bc BreakerConfig) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return gormJSONType(db)
+}
+
+func (c Channel) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return gormJSONType(db)
+}
+
+func (dm DelimitedMap) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return gormJSONType(db)
+}
+
+func (fm FieldMapping) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return gormJSONType(db)
+}
+
+func (gp GeoPoint) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return gormJSONType(db)
+}
+
+func (hc HealthCheck) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return gormJSONType(db)
+}
+
+func (ir IntRange) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return gormJSONType(db)
+}
+
+func (rp RetryPolicy) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	return gormJSONType(db)
+}
+
+// gormPostgresType is postgresType on Postgres and text elsewhere.
+func gormPostgresType(db *gorm.DB, postgresType string) string {
+	if db.Dialector.Name() == "postgres" {
+		return postgresType
+	}
+	return "text"
+}
+
+func gormJSONType(db *gorm.DB) string {
+	switch db.Dialector.Name() {
+	case "postgres":
+		return "jsonb"
+	case "mysql":
+		return "json"
+	case "sqlserver":
+		return "nvarchar(max)"
+	}
+	return "text"
+}
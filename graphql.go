@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// The types implement gqlgen's graphql.Marshaler and Unmarshaler, so they
+// can back custom scalars directly; see scalars.graphql for the schema part
+// and the gqlgen.yml models mapping. A scalar is written and read as its
+// JSON value, with the validation and messages of a JSON body:
+//
+//	DateTime     "2020-01-01T02:02:05+07:00"
+//	Date         "2020-01-01"
+//	Duration     "1h30m"
+//	ArrayString  "gift,express", and reads a list ["gift", "express"] too
+//	StringInt64  "9007199254740993", and reads an Int too
+//	ObfuscatedID "BEAsrn5Q"
+//	ByteSize     "1.5MiB", and reads an Int of bytes too
+//	Percentage   12.5, see PercentageNumbers
+//	BasisPoints  125
+//	Timezone     "Asia/Jakarta"
+//	Semver       "1.2.3"
+//	IPAddress    "10.0.0.1"
+//	JSONRaw      any JSON value, within JSONRawMaxSize and JSONRawMaxDepth
+//
+// gqlgen reports an UnmarshalGQL error against the argument or variable it
+// came from.
+
+/*
+	This part implements gqlgen's `graphql.Marshaler`
+	type Marshaler interface {
+		MarshalGQL(w io.Writer)
+	}
+*/
+func (dt DateTime) MarshalGQL(w io.Writer) { writeGQL(w, dt) }
+
+/*
+	This part implements gqlgen's `graphql.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalGQL(v interface{}) error
+	}
+*/
+func (dt *DateTime) UnmarshalGQL(v interface{}) error { return unmarshalValueAsJSON(dt, v) }
+
+func (d Date) MarshalGQL(w io.Writer)            { writeGQL(w, d) }
+func (d *Date) UnmarshalGQL(v interface{}) error { return unmarshalValueAsJSON(d, v) }
+
+func (d Duration) MarshalGQL(w io.Writer)            { writeGQL(w, d) }
+func (d *Duration) UnmarshalGQL(v interface{}) error { return unmarshalValueAsJSON(d, v) }
+
+func (dt ArrayString) MarshalGQL(w io.Writer) { writeGQL(w, dt) }
+
+func (dt *ArrayString) UnmarshalGQL(v interface{}) error {
+	if items, ok := v.([]interface{}); ok {
+		return dt.setItems(items)
+	}
+	return unmarshalValueAsJSON(dt, v)
+}
+
+func (si StringInt64) MarshalGQL(w io.Writer)            { writeGQL(w, si) }
+func (si *StringInt64) UnmarshalGQL(v interface{}) error { return unmarshalValueAsJSON(si, v) }
+
+func (id ObfuscatedID) MarshalGQL(w io.Writer)            { writeGQL(w, id) }
+func (id *ObfuscatedID) UnmarshalGQL(v interface{}) error { return unmarshalValueAsJSON(id, v) }
+
+func (bs ByteSize) MarshalGQL(w io.Writer)            { writeGQL(w, bs) }
+func (bs *ByteSize) UnmarshalGQL(v interface{}) error { return unmarshalValueAsJSON(bs, v) }
+
+func (p Percentage) MarshalGQL(w io.Writer)            { writeGQL(w, p) }
+func (p *Percentage) UnmarshalGQL(v interface{}) error { return unmarshalValueAsJSON(p, v) }
+
+func (bp BasisPoints) MarshalGQL(w io.Writer)            { writeGQL(w, bp) }
+func (bp *BasisPoints) UnmarshalGQL(v interface{}) error { return unmarshalValueAsJSON(bp, v) }
+
+func (tz Timezone) MarshalGQL(w io.Writer)            { writeGQL(w, tz) }
+func (tz *Timezone) UnmarshalGQL(v interface{}) error { return unmarshalValueAsJSON(tz, v) }
+
+func (v Semver) MarshalGQL(w io.Writer)                { writeGQL(w, v) }
+func (v *Semver) UnmarshalGQL(value interface{}) error { return unmarshalValueAsJSON(v, value) }
+
+func (ip IPAddress) MarshalGQL(w io.Writer)            { writeGQL(w, ip) }
+func (ip *IPAddress) UnmarshalGQL(v interface{}) error { return unmarshalValueAsJSON(ip, v) }
+
+func (jr JSONRaw) MarshalGQL(w io.Writer)            { writeGQL(w, jr) }
+func (jr *JSONRaw) UnmarshalGQL(v interface{}) error { return unmarshalValueAsJSON(jr, v) }
+
+// writeGQL writes v's JSON form. MarshalGQL cannot fail, so a value that
+// does not marshal is written as null, as gqlgen's own scalars do.
+func writeGQL(w io.Writer, v json.Marshaler) {
+	b, err := v.MarshalJSON()
+	if err != nil {
+		b = []byte("null")
+	}
+	_, _ = w.Write(b)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrCodeHeaderQuality = RegisterErrorCode(ErrorCode{
+		Code: "header.quality", Status: http.StatusBadRequest,
+		Message: "{header} header q must be a number between 0 and 1", Params: []string{"header"}, Example: "Accept-Language header q must be a number between 0 and 1",
+	})
+	ErrCodeHeaderEmptyItem = RegisterErrorCode(ErrorCode{
+		Code: "header.empty_item", Status: http.StatusBadRequest,
+		Message: "{header} header items must not be empty", Params: []string{"header"}, Example: "X-Tags header items must not be empty",
+		Kind: ErrEmptyValue,
+	})
+)
+
+// The helpers below read typed values from request headers. Like
+// HTTPDateFromHeader they return the zero value and no error when the
+// header is absent, and an error naming the header otherwise, so the
+// handler can pass it straight to ctx.Error.
+
+// DateTimeFromHeader reads a header such as X-Request-Start in
+// DateTimeLayouts.
+func DateTimeFromHeader(ctx *gin.Context, name string) (DateTime, error) {
+	s := ctx.GetHeader(name)
+	if s == "" {
+		return DateTime{}, nil
+	}
+	dt, err := ParseDateTime(s)
+	if err != nil {
+		return DateTime{}, prefixed(name+" header", err)
+	}
+	return dt, nil
+}
+
+// LanguageTagsFromHeader reads an Accept-Language style list, "da,
+// en-GB;q=0.8, en;q=0.7", most preferred first. Tags with q=0 and the "*"
+// wildcard are dropped.
+func LanguageTagsFromHeader(ctx *gin.Context, name string) ([]LanguageTag, error) {
+	type weighted struct {
+		tag LanguageTag
+		q   float64
+	}
+	var list []weighted
+	for _, value := range ctx.Request.Header.Values(name) {
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			s, params, _ := strings.Cut(item, ";")
+			q := 1.0
+			if params != "" {
+				params = strings.TrimSpace(params)
+				parsed, err := strconv.ParseFloat(strings.TrimPrefix(params, "q="), 64)
+				if !strings.HasPrefix(params, "q=") || err != nil || parsed < 0 || parsed > 1 {
+					return nil, ErrCodeHeaderQuality.Err(map[string]string{"header": name})
+				}
+				q = parsed
+			}
+			s = strings.TrimSpace(s)
+			if s == "*" || q == 0 {
+				continue
+			}
+			tag, err := ParseLanguageTag(s)
+			if err != nil {
+				return nil, prefixed(name+" header", err)
+			}
+			list = append(list, weighted{tag: tag, q: q})
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].q > list[j].q })
+
+	tags := make([]LanguageTag, len(list))
+	for i, w := range list {
+		tags[i] = w.tag
+	}
+	return tags, nil
+}
+
+// ArrayStringFromHeader reads a header such as X-Tags split on
+// ArrayStringSeparator. Repeated headers are combined and items are trimmed,
+// since "a, b" is the usual way to write a list in a header.
+func ArrayStringFromHeader(ctx *gin.Context, name string) (ArrayString, error) {
+	var list ArrayString
+	for _, value := range ctx.Request.Header.Values(name) {
+		for _, item := range strings.Split(value, ArrayStringSeparator) {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				return nil, ErrCodeHeaderEmptyItem.Err(map[string]string{"header": name})
+			}
+			list = append(list, item)
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrCodeHealthCheckNotObject = RegisterErrorCode(ErrorCode{
+		Code: "health_check.not_object", Status: http.StatusBadRequest,
+		Message: "must be a valid health check object", Example: "must be a valid health check object",
+	})
+	ErrCodeHealthCheckURLEmpty = RegisterErrorCode(ErrorCode{
+		Code: "health_check.url_empty", Status: http.StatusBadRequest,
+		Message: "url must not be empty", Example: "url must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeHealthCheckURL = RegisterErrorCode(ErrorCode{
+		Code: "health_check.url", Status: http.StatusBadRequest,
+		Message: "url must be an absolute http or https URL", Params: []string{"value"}, Example: "url must be an absolute http or https URL",
+	})
+	ErrCodeHealthCheckMethod = RegisterErrorCode(ErrorCode{
+		Code: "health_check.method", Status: http.StatusBadRequest,
+		Message: "method must be one of GET, HEAD, POST", Params: []string{"value"}, Example: "method must be one of GET, HEAD, POST",
+	})
+	ErrCodeHealthCheckIntervalEmpty = RegisterErrorCode(ErrorCode{
+		Code: "health_check.interval_empty", Status: http.StatusBadRequest,
+		Message: "interval must not be empty", Example: "interval must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeHealthCheckTimeoutEmpty = RegisterErrorCode(ErrorCode{
+		Code: "health_check.timeout_empty", Status: http.StatusBadRequest,
+		Message: "timeout must not be empty", Example: "timeout must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeHealthCheckNotPositive = RegisterErrorCode(ErrorCode{
+		Code: "health_check.not_positive", Status: http.StatusBadRequest,
+		Message: "interval and timeout must be positive", Example: "interval and timeout must be positive",
+	})
+	ErrCodeHealthCheckTimeoutTooLong = RegisterErrorCode(ErrorCode{
+		Code: "health_check.timeout_too_long", Status: http.StatusBadRequest,
+		Message: "timeout must be less than interval", Example: "timeout must be less than interval",
+	})
+	ErrCodeHealthCheckExpectedStatus = RegisterErrorCode(ErrorCode{
+		Code: "health_check.expected_status", Status: http.StatusBadRequest,
+		Message: "expected_statuses must contain HTTP status codes between 100 and 599", Params: []string{"value"}, Example: "expected_statuses must contain HTTP status codes between 100 and 599",
+	})
+)
+
+type HealthCheck struct {
+	url              *url.URL
+	method           string
+	interval         Duration
+	timeout          Duration
+	expectedStatuses map[int]struct{}
+}
+
+type healthCheckJSON struct {
+	URL              string    `json:"url"`
+	Method           string    `json:"method"`
+	Interval         *Duration `json:"interval"`
+	Timeout          *Duration `json:"timeout"`
+	ExpectedStatuses []int     `json:"expected_statuses"`
+}
+
+func (hc HealthCheck) URL() *url.URL {
+	return hc.url
+}
+
+func (hc HealthCheck) Method() string {
+	return hc.method
+}
+
+func (hc HealthCheck) Interval() Duration {
+	return hc.interval
+}
+
+func (hc HealthCheck) Timeout() Duration {
+	return hc.timeout
+}
+
+// IsExpectedStatus reports whether a probe response code counts as healthy.
+func (hc HealthCheck) IsExpectedStatus(code int) bool {
+	_, ok := hc.expectedStatuses[code]
+	return ok
+}
+
+func (hc HealthCheck) ExpectedStatuses() []int {
+	codes := make([]int, 0, len(hc.expectedStatuses))
+	for code := range hc.expectedStatuses {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	return codes
+}
+
+func (hc HealthCheck) IsZero() bool {
+	return hc.url == nil
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (hc HealthCheck) MarshalJSON() ([]byte, error) {
+	raw := healthCheckJSON{
+		Method:           hc.method,
+		Interval:         &hc.interval,
+		Timeout:          &hc.timeout,
+		ExpectedStatuses: hc.ExpectedStatuses(),
+	}
+	if hc.url != nil {
+		raw.URL = hc.url.String()
+	}
+	return json.Marshal(raw)
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (hc *HealthCheck) UnmarshalJSON(b []byte) error {
+	var raw healthCheckJSON
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return codedOr(err, ErrCodeHealthCheckNotObject.Err(nil))
+	}
+
+	if raw.URL == "" {
+		return ErrCodeHealthCheckURLEmpty.Err(nil)
+	}
+	u, err := url.Parse(raw.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrCodeHealthCheckURL.Err(map[string]string{"value": raw.URL})
+	}
+
+	method := strings.ToUpper(raw.Method)
+	switch method {
+	case "":
+		method = http.MethodGet
+	case http.MethodGet, http.MethodHead, http.MethodPost:
+	default:
+		return ErrCodeHealthCheckMethod.Err(map[string]string{"value": raw.Method})
+	}
+
+	if raw.Interval == nil {
+		return ErrCodeHealthCheckIntervalEmpty.Err(nil)
+	}
+	if raw.Timeout == nil {
+		return ErrCodeHealthCheckTimeoutEmpty.Err(nil)
+	}
+	if raw.Interval.Duration() <= 0 || raw.Timeout.Duration() <= 0 {
+		return ErrCodeHealthCheckNotPositive.Err(nil)
+	}
+	if raw.Timeout.Duration() >= raw.Interval.Duration() {
+		return ErrCodeHealthCheckTimeoutTooLong.Err(nil)
+	}
+
+	statuses := raw.ExpectedStatuses
+	if len(statuses) == 0 {
+		statuses = []int{http.StatusOK}
+	}
+	expected := make(map[int]struct{}, len(statuses))
+	for _, code := range statuses {
+		if code < 100 || code > 599 {
+			return ErrCodeHealthCheckExpectedStatus.Err(map[string]string{"value": strconv.Itoa(code)})
+		}
+		expected[code] = struct{}{}
+	}
+
+	hc.url = u
+	hc.method = method
+	hc.interval = *raw.Interval
+	hc.timeout = *raw.Timeout
+	hc.expectedStatuses = expected
+
+	return nil
+}
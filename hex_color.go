@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrCodeHexColorNotString = RegisterErrorCode(ErrorCode{
+		Code: "hex_color.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeHexColorEmpty = RegisterErrorCode(ErrorCode{
+		Code: "hex_color.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeHexColorNoHash = RegisterErrorCode(ErrorCode{
+		Code: "hex_color.no_hash", Status: http.StatusBadRequest,
+		Message: "must start with #", Params: []string{"value"}, Example: "must start with #",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeHexColorInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "hex_color.invalid_format", Status: http.StatusBadRequest,
+		Message: "format must be #RGB, #RRGGBB or #RRGGBBAA", Params: []string{"value"}, Example: "format must be #RGB, #RRGGBB or #RRGGBBAA",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeHexColorNotHex = RegisterErrorCode(ErrorCode{
+		Code: "hex_color.not_hex", Status: http.StatusBadRequest,
+		Message: "must only contain hex digits", Params: []string{"value"}, Example: "must only contain hex digits",
+		Kind: ErrInvalidFormat,
+	})
+)
+
+// HexColor accepts "#RGB", "#RRGGBB" and "#RRGGBBAA" in any case. It
+// marshals as lowercase "#rrggbb", adding "aa" only when the color is not
+// fully opaque.
+type HexColor struct {
+	r, g, b, a uint8
+}
+
+func NewHexColor(r uint8, g uint8, b uint8, a uint8) HexColor {
+	return HexColor{r: r, g: g, b: b, a: a}
+}
+
+func ParseHexColor(s string) (HexColor, error) {
+	if !strings.HasPrefix(s, "#") {
+		return HexColor{}, ErrCodeHexColorNoHash.Err(map[string]string{"value": s})
+	}
+	hex := s[1:]
+	switch len(hex) {
+	case 3:
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]}) + "ff"
+	case 6:
+		hex += "ff"
+	case 8:
+	default:
+		return HexColor{}, ErrCodeHexColorInvalidFormat.Err(map[string]string{"value": s})
+	}
+
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return HexColor{}, ErrCodeHexColorNotHex.Err(map[string]string{"value": s})
+	}
+	return HexColor{r: uint8(v >> 24), g: uint8(v >> 16), b: uint8(v >> 8), a: uint8(v)}, nil
+}
+
+// RGBA returns the 8-bit channels; alpha is 255 unless "#RRGGBBAA" was used.
+func (c HexColor) RGBA() (r uint8, g uint8, b uint8, a uint8) {
+	return c.r, c.g, c.b, c.a
+}
+
+func (c HexColor) Red() uint8 {
+	return c.r
+}
+
+func (c HexColor) Green() uint8 {
+	return c.g
+}
+
+func (c HexColor) Blue() uint8 {
+	return c.b
+}
+
+func (c HexColor) Alpha() uint8 {
+	return c.a
+}
+
+func (c HexColor) String() string {
+	if c.a == 0xff {
+		return fmt.Sprintf("#%02x%02x%02x", c.r, c.g, c.b)
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", c.r, c.g, c.b, c.a)
+}
+
+func (c HexColor) IsZero() bool {
+	return c == HexColor{}
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (c HexColor) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (c *HexColor) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeHexColorNotString.Err(map[string]string{"value": string(b)})
+	}
+	if s == "" {
+		return ErrCodeHexColorEmpty.Err(nil)
+	}
+	parsed, err := ParseHexColor(s)
+	if err != nil {
+		return err
+	}
+
+	*c = parsed
+
+	return nil
+}
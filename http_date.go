@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrCodeHTTPDateNotString = RegisterErrorCode(ErrorCode{
+		Code: "http_date.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeHTTPDateEmpty = RegisterErrorCode(ErrorCode{
+		Code: "http_date.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeHTTPDateInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "http_date.invalid_format", Status: http.StatusBadRequest,
+		Message: "must be an HTTP date such as Sun, 06 Nov 1994 08:49:37 GMT", Params: []string{"value"}, Example: "must be an HTTP date such as Sun, 06 Nov 1994 08:49:37 GMT",
+		Kind: ErrInvalidFormat,
+	})
+)
+
+// HTTPDate is the date format of HTTP headers such as Last-Modified and
+// If-Modified-Since, "Sun, 06 Nov 1994 08:49:37 GMT". Parsing also accepts
+// the obsolete RFC 850 and ANSI C asctime forms, as HTTP requires. Values
+// are kept in UTC at whole seconds, the format's precision.
+type HTTPDate struct {
+	time time.Time
+}
+
+func NewHTTPDate(t time.Time) HTTPDate {
+	return HTTPDate{time: t.UTC().Truncate(time.Second)}
+}
+
+func ParseHTTPDate(s string) (HTTPDate, error) {
+	t, err := http.ParseTime(s)
+	if err != nil {
+		return HTTPDate{}, ErrCodeHTTPDateInvalidFormat.Err(map[string]string{"value": s})
+	}
+	return NewHTTPDate(t), nil
+}
+
+// HTTPDateFromHeader reads the named request header. It returns the zero
+// HTTPDate and no error when the header is absent, and a coded error naming
+// the header when it is invalid.
+func HTTPDateFromHeader(ctx *gin.Context, name string) (HTTPDate, error) {
+	s := ctx.GetHeader(name)
+	if s == "" {
+		return HTTPDate{}, nil
+	}
+	d, err := ParseHTTPDate(s)
+	if err != nil {
+		return HTTPDate{}, prefixed(name+" header", err)
+	}
+	return d, nil
+}
+
+// SetHTTPDateHeader sets the named response header, e.g. Last-Modified or
+// Expires. The zero HTTPDate removes it.
+func SetHTTPDateHeader(ctx *gin.Context, name string, d HTTPDate) {
+	if d.IsZero() {
+		ctx.Writer.Header().Del(name)
+		return
+	}
+	ctx.Header(name, d.String())
+}
+
+// NotModified sets Last-Modified and, if the request's If-Modified-Since is
+// not older than lastModified, answers 304 and returns true; the handler
+// should then return without writing a body. An invalid If-Modified-Since
+// is ignored, and so is a valid one next to If-None-Match, as HTTP
+// requires.
+func NotModified(ctx *gin.Context, lastModified HTTPDate) bool {
+	SetHTTPDateHeader(ctx, "Last-Modified", lastModified)
+	if ctx.Request.Method != http.MethodGet && ctx.Request.Method != http.MethodHead {
+		return false
+	}
+	if ctx.GetHeader("If-None-Match") != "" || lastModified.IsZero() {
+		return false
+	}
+	since, err := HTTPDateFromHeader(ctx, "If-Modified-Since")
+	if err != nil || since.IsZero() || lastModified.After(since) {
+		return false
+	}
+	ctx.Status(http.StatusNotModified)
+	// Status alone is only written along with a body.
+	ctx.Writer.WriteHeaderNow()
+	return true
+}
+
+func (hd HTTPDate) Time() time.Time {
+	return hd.time
+}
+
+func (hd HTTPDate) IsZero() bool {
+	return hd.time.IsZero()
+}
+
+func (hd HTTPDate) Before(other HTTPDate) bool {
+	return hd.time.Before(other.time)
+}
+
+func (hd HTTPDate) After(other HTTPDate) bool {
+	return hd.time.After(other.time)
+}
+
+func (hd HTTPDate) String() string {
+	return hd.time.Format(http.TimeFormat)
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (hd HTTPDate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hd.String())
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (hd *HTTPDate) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeHTTPDateNotString.Err(map[string]string{"value": string(b)})
+	}
+	if s == "" {
+		return ErrCodeHTTPDateEmpty.Err(nil)
+	}
+	parsed, err := ParseHTTPDate(s)
+	if err != nil {
+		return err
+	}
+
+	*hd = parsed
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+// MessageLocale is the language the messages are written in. It is the
+// fallback when the client asks for a language without translations.
+var MessageLocale = language.English
+
+const localeKey = "myapp.locale"
+
+var (
+	translationsMu sync.RWMutex
+	translations   = map[language.Tag]map[string]string{}
+)
+
+func init() {
+	RegisterTranslations(language.Indonesian, map[string]string{
+		"value.not_string":        "harus berupa string yang valid",
+		"value.empty":             "tidak boleh kosong",
+		"value.invalid_format":    "format harus {layout}",
+		"value.rule_failed":       "harus memenuhi {rule}",
+		"datetime.not_string":     "bukan string yang valid",
+		"datetime.empty":          "tidak boleh kosong",
+		"datetime.invalid_format": "format harus {layout}",
+		"array.not_string":        "harus berupa string yang valid",
+		"array.empty":             "tidak boleh kosong",
+		"string.not_string":       "harus berupa string yang valid",
+		"request.invalid_fields":  "permintaan memiliki field yang tidak valid",
+		"request.invalid_json":    "isi permintaan harus berupa JSON yang valid",
+		"internal":                "terjadi kesalahan pada server",
+	})
+}
+
+// RegisterTranslations adds messages in tag, keyed by error code
+// ("value.empty") or, for errors without a code, by the English message.
+// A translation may use the code's {placeholders}. Registering a key again
+// replaces it, so consumers can also reword the built-in ones.
+func RegisterTranslations(tag language.Tag, messages map[string]string) {
+	translationsMu.Lock()
+	defer translationsMu.Unlock()
+
+	catalog := translations[tag]
+	if catalog == nil {
+		catalog = map[string]string{}
+		translations[tag] = catalog
+	}
+	for key, message := range messages {
+		catalog[key] = message
+	}
+}
+
+// WithLocale answers the errors of the routes it is used on in lang,
+// whatever the client's Accept-Language says.
+func WithLocale(lang language.Tag) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(localeKey, lang)
+		ctx.Next()
+	}
+}
+
+// requestLocale is the locale set by WithLocale, or the best match for
+// Accept-Language among the registered translations. An unreadable header
+// falls back to MessageLocale.
+func requestLocale(ctx *gin.Context) language.Tag {
+	if lang, ok := ctx.Get(localeKey); ok {
+		return lang.(language.Tag)
+	}
+	accepted, err := LanguageTagsFromHeader(ctx, "Accept-Language")
+	if err != nil || len(accepted) == 0 {
+		return MessageLocale
+	}
+
+	translationsMu.RLock()
+	supported := []language.Tag{MessageLocale}
+	for tag := range translations {
+		if tag != MessageLocale {
+			supported = append(supported, tag)
+		}
+	}
+	translationsMu.RUnlock()
+
+	wanted := make([]language.Tag, len(accepted))
+	for i, tag := range accepted {
+		wanted[i] = tag.Tag()
+	}
+	_, index, confidence := language.NewMatcher(supported).Match(wanted...)
+	if confidence == language.No {
+		return MessageLocale
+	}
+	return supported[index]
+}
+
+// localize returns message in locale, looked up by code first. Without a
+// translation it is the English message after MessageOverrides.
+func localize(locale language.Tag, code string, message string, params map[string]string) string {
+	translationsMu.RLock()
+	catalog := translations[locale]
+	template, ok := catalog[code]
+	if !ok {
+		template, ok = catalog[message]
+	}
+	translationsMu.RUnlock()
+
+	if !ok {
+		return clientMessage(message)
+	}
+	return fillPlaceholders(template, params)
+}
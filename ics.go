@@ -0,0 +1,346 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	icsUTCLayout     = "20060102T150405Z"
+	icsLocalLayout   = "20060102T150405"
+	contentLineLimit = 75
+)
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+// MarshalICS renders each event as a VEVENT inside one VCALENDAR. Events are
+// structs (or pointers to structs) whose fields carry an `ics` tag naming the
+// property, e.g.
+//
+//	type Meeting struct {
+//		ID      string   `ics:"uid"`
+//		Title   string   `ics:"summary"`
+//		StartAt DateTime `ics:"dtstart"`
+//		Length  Duration `ics:"duration"`
+//	}
+//
+// DateTime fields in a named IANA zone are written with a TZID parameter
+// and the calendar gets a VTIMEZONE for the zone, with its rules in the
+// year of the zone's first event; everything else is converted to UTC.
+// Duration fields are written as ISO-8601 durations and other values
+// through their string form.
+func MarshalICS(events ...interface{}) ([]byte, error) {
+	body := getBuffer()
+	defer putBuffer(body)
+
+	stamp := time.Now().UTC().Format(icsUTCLayout)
+	zones := map[string]time.Time{}
+	for _, event := range events {
+		if err := writeICSEvent(body, event, stamp, zones); err != nil {
+			return nil, err
+		}
+	}
+
+	buf := getBuffer()
+	writeContentLine(buf, "BEGIN:VCALENDAR")
+	writeContentLine(buf, "VERSION:2.0")
+	writeContentLine(buf, "PRODID:-//myapp//custom types//EN")
+	names := make([]string, 0, len(zones))
+	for name := range zones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		writeICSTimezone(buf, zones[name])
+	}
+	buf.Write(body.Bytes())
+	writeContentLine(buf, "END:VCALENDAR")
+	return bufferBytes(buf), nil
+}
+
+// writeICSEvent writes one VEVENT, and records in zones the earliest time
+// written with each TZID.
+func writeICSEvent(buf *bytes.Buffer, event interface{}, stamp string, zones map[string]time.Time) error {
+	v := reflect.ValueOf(event)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return errors.New("ics: nil event")
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("ics: event must be a struct, got %s", v.Kind())
+	}
+
+	var lines []string
+	hasUID, hasStart, hasStamp := false, false, false
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name := field.Tag.Get("ics")
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+		name = strings.ToUpper(name)
+
+		value, params, ok := icsValue(v.Field(i), name)
+		if !ok {
+			continue
+		}
+		if t, zoned := icsZoned(v.Field(i)); zoned {
+			if first, seen := zones[t.Location().String()]; !seen || t.Before(first) {
+				zones[t.Location().String()] = t
+			}
+		}
+		switch name {
+		case "UID":
+			hasUID = true
+		case "DTSTART":
+			hasStart = true
+		case "DTSTAMP":
+			hasStamp = true
+		}
+		lines = append(lines, name+params+":"+value)
+	}
+
+	if !hasUID {
+		return errors.New("ics: event has no uid field")
+	}
+	if !hasStart {
+		return errors.New("ics: event has no dtstart field")
+	}
+
+	writeContentLine(buf, "BEGIN:VEVENT")
+	if !hasStamp {
+		writeContentLine(buf, "DTSTAMP:"+stamp)
+	}
+	for _, line := range lines {
+		writeContentLine(buf, line)
+	}
+	writeContentLine(buf, "END:VEVENT")
+	return nil
+}
+
+// icsValue formats a single property value, reporting false for empty
+// values so optional properties are left out.
+func icsValue(field reflect.Value, name string) (value string, params string, ok bool) {
+	switch v := field.Interface().(type) {
+	case DateTime:
+		if v.IsZero() {
+			return "", "", false
+		}
+		value, params = icsDateTime(v.Time())
+		return value, params, true
+	case NullDateTime:
+		if !v.Valid || v.DateTime.IsZero() {
+			return "", "", false
+		}
+		value, params = icsDateTime(v.DateTime.Time())
+		return value, params, true
+	case Duration:
+		return icsDuration(v.duration), "", true
+	case fmt.Stringer:
+		s := v.String()
+		return icsText(s, name), "", s != ""
+	case string:
+		return icsText(v, name), "", v != ""
+	default:
+		s := fmt.Sprint(v)
+		return icsText(s, name), "", s != ""
+	}
+}
+
+// icsText escapes free-form text properties. Structured properties such as
+// RRULE use `;` and `,` as part of their syntax and are left untouched.
+func icsText(s string, name string) string {
+	switch name {
+	case "RRULE", "EXRULE", "RDATE", "EXDATE", "URL", "GEO":
+		return s
+	}
+	return textEscaper.Replace(s)
+}
+
+func icsDateTime(t time.Time) (value string, params string) {
+	if icsNamedZone(t.Location()) {
+		return t.Format(icsLocalLayout), ";TZID=" + t.Location().String()
+	}
+	return t.UTC().Format(icsUTCLayout), ""
+}
+
+// icsNamedZone reports whether times in loc are written with a TZID.
+func icsNamedZone(loc *time.Location) bool {
+	name := loc.String()
+	if name == "" || name == "UTC" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// icsZoned is the time of a DateTime or NullDateTime field written with a
+// TZID.
+func icsZoned(field reflect.Value) (time.Time, bool) {
+	var t time.Time
+	switch v := field.Interface().(type) {
+	case DateTime:
+		t = v.Time()
+	case NullDateTime:
+		if !v.Valid {
+			return time.Time{}, false
+		}
+		t = v.DateTime.Time()
+	default:
+		return time.Time{}, false
+	}
+	return t, !t.IsZero() && icsNamedZone(t.Location())
+}
+
+// writeICSTimezone writes the VTIMEZONE for at's zone: a STANDARD or
+// DAYLIGHT observance per offset change in at's year, repeated yearly on
+// the same weekday of the month, or a single STANDARD for a zone without
+// changes that year.
+func writeICSTimezone(buf *bytes.Buffer, at time.Time) {
+	loc := at.Location()
+	writeContentLine(buf, "BEGIN:VTIMEZONE")
+	writeContentLine(buf, "TZID:"+loc.String())
+
+	transitions := zoneTransitions(loc, at.Year())
+	if len(transitions) == 0 {
+		start := time.Date(at.Year(), time.January, 1, 0, 0, 0, 0, loc)
+		name, offset := start.Zone()
+		writeICSObservance(buf, "STANDARD", "19700101T000000", offset, offset, name, "")
+	}
+	for _, change := range transitions {
+		before := change.Add(-time.Second)
+		_, from := before.Zone()
+		name, to := change.Zone()
+		kind := "STANDARD"
+		if change.IsDST() {
+			kind = "DAYLIGHT"
+		}
+		// DTSTART is the wall time the change happens at, read on the clock
+		// before it.
+		local := change.In(time.FixedZone("", from))
+		rule := fmt.Sprintf("FREQ=YEARLY;BYMONTH=%d;BYDAY=%s", local.Month(), icsMonthWeekday(local))
+		writeICSObservance(buf, kind, local.Format(icsLocalLayout), from, to, name, rule)
+	}
+	writeContentLine(buf, "END:VTIMEZONE")
+}
+
+func writeICSObservance(buf *bytes.Buffer, kind string, start string, from int, to int, name string, rule string) {
+	writeContentLine(buf, "BEGIN:"+kind)
+	writeContentLine(buf, "DTSTART:"+start)
+	writeContentLine(buf, "TZOFFSETFROM:"+icsOffset(from))
+	writeContentLine(buf, "TZOFFSETTO:"+icsOffset(to))
+	if name != "" {
+		writeContentLine(buf, "TZNAME:"+textEscaper.Replace(name))
+	}
+	if rule != "" {
+		writeContentLine(buf, "RRULE:"+rule)
+	}
+	writeContentLine(buf, "END:"+kind)
+}
+
+// zoneTransitions returns the instants in year at which loc's offset
+// changes.
+func zoneTransitions(loc *time.Location, year int) []time.Time {
+	var changes []time.Time
+	end := time.Date(year+1, time.January, 1, 0, 0, 0, 0, loc)
+	for day := time.Date(year, time.January, 1, 0, 0, 0, 0, loc); day.Before(end); day = day.Add(24 * time.Hour) {
+		_, offset := day.Zone()
+		if _, next := day.Add(24 * time.Hour).Zone(); next == offset {
+			continue
+		}
+		// Narrow the change down to the second.
+		lo, hi := day.Unix(), day.Unix()+24*60*60
+		for hi-lo > 1 {
+			mid := (lo + hi) / 2
+			if _, m := time.Unix(mid, 0).In(loc).Zone(); m == offset {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		changes = append(changes, time.Unix(hi, 0).In(loc))
+	}
+	return changes
+}
+
+// icsMonthWeekday is t's weekday as an RRULE BYDAY, counted from the end
+// of the month in its last week: 2SU for the second Sunday, -1SU for the
+// last.
+func icsMonthWeekday(t time.Time) string {
+	day := [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}[t.Weekday()]
+	if t.AddDate(0, 0, 7).Month() != t.Month() {
+		return "-1" + day
+	}
+	return fmt.Sprintf("%d%s", (t.Day()-1)/7+1, day)
+}
+
+// icsOffset formats a UTC offset in seconds as RFC 5545's +hhmm.
+func icsOffset(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign, seconds = '-', -seconds
+	}
+	if seconds%60 != 0 {
+		return fmt.Sprintf("%c%02d%02d%02d", sign, seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, seconds/3600, seconds/60%60)
+}
+
+func icsDuration(d time.Duration) string {
+	var sb strings.Builder
+	if d < 0 {
+		sb.WriteByte('-')
+		d = -d
+	}
+	sb.WriteByte('P')
+
+	days := d / (24 * time.Hour)
+	d -= days * 24 * time.Hour
+	if days > 0 {
+		fmt.Fprintf(&sb, "%dD", days)
+	}
+	if d > 0 || days == 0 {
+		sb.WriteByte('T')
+		hours := d / time.Hour
+		d -= hours * time.Hour
+		minutes := d / time.Minute
+		d -= minutes * time.Minute
+		seconds := d / time.Second
+		if hours > 0 {
+			fmt.Fprintf(&sb, "%dH", hours)
+		}
+		if minutes > 0 {
+			fmt.Fprintf(&sb, "%dM", minutes)
+		}
+		if seconds > 0 || (hours == 0 && minutes == 0) {
+			fmt.Fprintf(&sb, "%dS", seconds)
+		}
+	}
+	return sb.String()
+}
+
+// writeContentLine folds content lines longer than 75 octets as required by
+// RFC 5545 and RFC 6350, without splitting a UTF-8 sequence, and terminates
+// them with CRLF.
+func writeContentLine(buf *bytes.Buffer, line string) {
+	limit := contentLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		buf.WriteString(line[:cut])
+		buf.WriteString("\r\n ")
+		line = line[cut:]
+		limit = contentLineLimit - 1
+	}
+	buf.WriteString(line)
+	buf.WriteString("\r\n")
+}
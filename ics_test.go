@@ -0,0 +1,140 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type icsMeeting struct {
+	ID      string   `ics:"uid"`
+	Title   string   `ics:"summary"`
+	StartAt DateTime `ics:"dtstart"`
+	Length  Duration `ics:"duration"`
+}
+
+func icsLines(t *testing.T, events ...interface{}) []string {
+	t.Helper()
+	ics, err := MarshalICS(events...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSuffix(string(ics), "\r\n"), "\r\n")
+}
+
+// icsBlock returns the lines from BEGIN:name to END:name, both included.
+func icsBlock(lines []string, name string) []string {
+	for i, line := range lines {
+		if line != "BEGIN:"+name {
+			continue
+		}
+		for j := i; j < len(lines); j++ {
+			if lines[j] == "END:"+name {
+				return lines[i : j+1]
+			}
+		}
+	}
+	return nil
+}
+
+func TestMarshalICSTimezone(t *testing.T) {
+	newYork := loadLocation(t, "America/New_York")
+	meeting := icsMeeting{
+		ID:      "standup-1@example.com",
+		Title:   "Standup",
+		StartAt: NewDateTime(time.Date(2024, time.July, 1, 9, 0, 0, 0, newYork)),
+	}
+	lines := icsLines(t, meeting)
+
+	want := []string{
+		"BEGIN:VTIMEZONE",
+		"TZID:America/New_York",
+		"BEGIN:DAYLIGHT",
+		"DTSTART:20240310T020000",
+		"TZOFFSETFROM:-0500",
+		"TZOFFSETTO:-0400",
+		"TZNAME:EDT",
+		"RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=2SU",
+		"END:DAYLIGHT",
+		"BEGIN:STANDARD",
+		"DTSTART:20241103T020000",
+		"TZOFFSETFROM:-0400",
+		"TZOFFSETTO:-0500",
+		"TZNAME:EST",
+		"RRULE:FREQ=YEARLY;BYMONTH=11;BYDAY=1SU",
+		"END:STANDARD",
+		"END:VTIMEZONE",
+	}
+	if got := icsBlock(lines, "VTIMEZONE"); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("VTIMEZONE:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	event := strings.Join(icsBlock(lines, "VEVENT"), "\n")
+	if !strings.Contains(event, "\nDTSTART;TZID=America/New_York:20240701T090000\n") {
+		t.Errorf("VEVENT has no local DTSTART with its TZID:\n%s", event)
+	}
+}
+
+func TestMarshalICSTimezoneOncePerZone(t *testing.T) {
+	newYork := loadLocation(t, "America/New_York")
+	jakarta := loadLocation(t, "Asia/Jakarta")
+	lines := icsLines(t,
+		icsMeeting{ID: "a", StartAt: NewDateTime(time.Date(2024, time.July, 1, 9, 0, 0, 0, newYork))},
+		icsMeeting{ID: "b", StartAt: NewDateTime(time.Date(2024, time.July, 2, 9, 0, 0, 0, newYork))},
+		icsMeeting{ID: "c", StartAt: NewDateTime(time.Date(2024, time.July, 3, 9, 0, 0, 0, jakarta))},
+		icsMeeting{ID: "d", StartAt: MustParseDateTime("2024-07-04T09:00:00Z")},
+	)
+
+	var zones []string
+	for _, line := range lines {
+		if strings.HasPrefix(line, "TZID:") {
+			zones = append(zones, line)
+		}
+	}
+	if got := strings.Join(zones, " "); got != "TZID:America/New_York TZID:Asia/Jakarta" {
+		t.Errorf("VTIMEZONEs %s, want one for each of America/New_York and Asia/Jakarta", got)
+	}
+
+	// Jakarta has no DST: a single STANDARD observance.
+	var jakartaZone []string
+	for i, line := range lines {
+		if line == "TZID:Asia/Jakarta" {
+			jakartaZone = icsBlock(lines[i:], "STANDARD")
+		}
+	}
+	want := "BEGIN:STANDARD DTSTART:19700101T000000 TZOFFSETFROM:+0700 TZOFFSETTO:+0700 TZNAME:WIB END:STANDARD"
+	if got := strings.Join(jakartaZone, " "); got != want {
+		t.Errorf("Asia/Jakarta observance %s, want %s", got, want)
+	}
+
+	for _, line := range lines {
+		if strings.HasPrefix(line, "DTSTART:20240704") && line != "DTSTART:20240704T090000Z" {
+			t.Errorf("UTC event written as %s", line)
+		}
+	}
+}
+
+func TestMarshalICSUTCHasNoTimezone(t *testing.T) {
+	lines := icsLines(t, icsMeeting{ID: "a", StartAt: MustParseDateTime("2020-01-01T09:00:00+07:00")})
+	if block := icsBlock(lines, "VTIMEZONE"); block != nil {
+		t.Errorf("VTIMEZONE for an event without a zone:\n%s", strings.Join(block, "\n"))
+	}
+	event := strings.Join(icsBlock(lines, "VEVENT"), "\n")
+	if !strings.Contains(event, "\nDTSTART:20200101T020000Z\n") {
+		t.Errorf("VEVENT does not start at 02:00 UTC:\n%s", event)
+	}
+}
+
+func TestMarshalICSSouthernHemisphere(t *testing.T) {
+	sydney := loadLocation(t, "Australia/Sydney")
+	lines := icsLines(t, icsMeeting{ID: "a", StartAt: NewDateTime(time.Date(2024, time.January, 15, 9, 0, 0, 0, sydney))})
+	zone := strings.Join(icsBlock(lines, "VTIMEZONE"), "\n")
+	for _, want := range []string{
+		"BEGIN:STANDARD\nDTSTART:20240407T030000\nTZOFFSETFROM:+1100\nTZOFFSETTO:+1000\nTZNAME:AEST\nRRULE:FREQ=YEARLY;BYMONTH=4;BYDAY=1SU",
+		"BEGIN:DAYLIGHT\nDTSTART:20241006T020000\nTZOFFSETFROM:+1000\nTZOFFSETTO:+1100\nTZNAME:AEDT\nRRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=1SU",
+	} {
+		if !strings.Contains(zone, want) {
+			t.Errorf("VTIMEZONE has no\n%s\nin\n%s", want, zone)
+		}
+	}
+}
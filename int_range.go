@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+)
+
+var (
+	ErrCodeIntRangeNotString = RegisterErrorCode(ErrorCode{
+		Code: "int_range.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeIntRangeEmpty = RegisterErrorCode(ErrorCode{
+		Code: "int_range.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeIntRangeInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "int_range.invalid_format", Status: http.StatusBadRequest,
+		Message: "format must be MIN-MAX, e.g. 10-20", Params: []string{"value"}, Example: "format must be MIN-MAX, e.g. 10-20",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeIntRangeMinOutOfRange = RegisterErrorCode(ErrorCode{
+		Code: "int_range.min_out_of_range", Status: http.StatusBadRequest,
+		Message: "min is out of range", Params: []string{"value"}, Example: "min is out of range",
+	})
+	ErrCodeIntRangeMaxOutOfRange = RegisterErrorCode(ErrorCode{
+		Code: "int_range.max_out_of_range", Status: http.StatusBadRequest,
+		Message: "max is out of range", Params: []string{"value"}, Example: "max is out of range",
+	})
+	ErrCodeIntRangeReversed = RegisterErrorCode(ErrorCode{
+		Code: "int_range.reversed", Status: http.StatusBadRequest,
+		Message: "min must not be greater than max", Params: []string{"min", "max"}, Example: "min must not be greater than max",
+	})
+	ErrCodeIntRangeNotIntegers = RegisterErrorCode(ErrorCode{
+		Code: "int_range.not_integers", Status: http.StatusBadRequest,
+		Message: "min and max must be integers", Example: "min and max must be integers",
+	})
+	ErrCodeIntRangeMissingBound = RegisterErrorCode(ErrorCode{
+		Code: "int_range.missing_bound", Status: http.StatusBadRequest,
+		Message: "min and max must not be empty", Example: "min and max must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeIntRangeWrongType = RegisterErrorCode(ErrorCode{
+		Code: "int_range.wrong_type", Status: http.StatusBadRequest,
+		Message: "must be a {min, max} object or a \"min-max\" string", Example: "must be a {min, max} object or a \"min-max\" string",
+	})
+	ErrCodeIntRangeOutside = RegisterErrorCode(ErrorCode{
+		Code: "int_range.outside", Status: http.StatusBadRequest,
+		Message: "must be within {min}-{max}", Params: []string{"min", "max", "value"}, Example: "must be within 0-1000000",
+	})
+	ErrCodeIntRangeRejected = RegisterErrorCode(ErrorCode{
+		Code: "int_range.rejected", Status: http.StatusBadRequest,
+		Message: "{reason}", Params: []string{"reason"}, Example: "must be within 0-1000000",
+	})
+)
+
+// IntRangeValidate, when set, runs after parsing; the error message it
+// returns is sent back to the client as a 400. See IntRangeWithin.
+var IntRangeValidate func(r IntRange) error
+
+var intRangePattern = regexp.MustCompile(`^(-?\d+)-(-?\d+)$`)
+
+type intRangeJSON struct {
+	Min *int64 `json:"min"`
+	Max *int64 `json:"max"`
+}
+
+// IntRange is an inclusive range of integers such as a price filter or a
+// window of rows, sent as "10-20" or {"min":10,"max":20}. Min is never
+// greater than Max.
+type IntRange struct {
+	min int64
+	max int64
+}
+
+func NewIntRange(min int64, max int64) (IntRange, error) {
+	if min > max {
+		return IntRange{}, ErrCodeIntRangeReversed.Err(map[string]string{"min": strconv.FormatInt(min, 10), "max": strconv.FormatInt(max, 10)})
+	}
+	return IntRange{min: min, max: max}, nil
+}
+
+func ParseIntRange(s string) (IntRange, error) {
+	m := intRangePattern.FindStringSubmatch(s)
+	if m == nil {
+		return IntRange{}, ErrCodeIntRangeInvalidFormat.Err(map[string]string{"value": s})
+	}
+	min, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return IntRange{}, ErrCodeIntRangeMinOutOfRange.Err(map[string]string{"value": s})
+	}
+	max, err := strconv.ParseInt(m[2], 10, 64)
+	if err != nil {
+		return IntRange{}, ErrCodeIntRangeMaxOutOfRange.Err(map[string]string{"value": s})
+	}
+	return NewIntRange(min, max)
+}
+
+// IntRangeWithin is a ready-made IntRangeValidate hook.
+func IntRangeWithin(min int64, max int64) func(r IntRange) error {
+	return func(r IntRange) error {
+		if r.min < min || r.max > max {
+			return ErrCodeIntRangeOutside.Err(map[string]string{"min": strconv.FormatInt(min, 10), "max": strconv.FormatInt(max, 10), "value": r.String()})
+		}
+		return nil
+	}
+}
+
+func (ir IntRange) Min() int64 {
+	return ir.min
+}
+
+func (ir IntRange) Max() int64 {
+	return ir.max
+}
+
+// Len is the number of integers in the range, counting both ends. The one
+// range too long to count, all of int64, wraps around to 0.
+func (ir IntRange) Len() uint64 {
+	return uint64(ir.max-ir.min) + 1
+}
+
+func (ir IntRange) Contains(n int64) bool {
+	return n >= ir.min && n <= ir.max
+}
+
+// Clamp returns n moved into the range.
+func (ir IntRange) Clamp(n int64) int64 {
+	if n < ir.min {
+		return ir.min
+	}
+	if n > ir.max {
+		return ir.max
+	}
+	return n
+}
+
+func (ir IntRange) String() string {
+	return strconv.FormatInt(ir.min, 10) + "-" + strconv.FormatInt(ir.max, 10)
+}
+
+func (ir IntRange) IsZero() bool {
+	return ir == IntRange{}
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (ir IntRange) MarshalJSON() ([]byte, error) {
+	return json.Marshal(intRangeJSON{Min: &ir.min, Max: &ir.max})
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (ir *IntRange) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+
+	var (
+		parsed IntRange
+		err    error
+	)
+	switch {
+	case len(b) > 0 && b[0] == '"':
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return ErrCodeIntRangeNotString.Err(map[string]string{"value": string(b)})
+		}
+		if s == "" {
+			return ErrCodeIntRangeEmpty.Err(nil)
+		}
+		parsed, err = ParseIntRange(s)
+	case len(b) > 0 && b[0] == '{':
+		var raw intRangeJSON
+		if err := json.Unmarshal(b, &raw); err != nil {
+			return ErrCodeIntRangeNotIntegers.Err(nil)
+		}
+		if raw.Min == nil || raw.Max == nil {
+			return ErrCodeIntRangeMissingBound.Err(nil)
+		}
+		parsed, err = NewIntRange(*raw.Min, *raw.Max)
+	default:
+		return ErrCodeIntRangeWrongType.Err(nil)
+	}
+	if err != nil {
+		return err
+	}
+	if IntRangeValidate != nil {
+		if err := IntRangeValidate(parsed); err != nil {
+			return codedOr(err, ErrCodeIntRangeRejected.Err(map[string]string{"reason": err.Error()}))
+		}
+	}
+
+	*ir = parsed
+
+	return nil
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/netip"
+)
+
+var (
+	ErrCodeIPAddressNotString = RegisterErrorCode(ErrorCode{
+		Code: "ip_address.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeIPAddressEmpty = RegisterErrorCode(ErrorCode{
+		Code: "ip_address.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeIPAddressInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "ip_address.invalid_format", Status: http.StatusBadRequest,
+		Message: "must be a valid IP address", Params: []string{"value"}, Example: "must be a valid IP address",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeIPAddressNotIPv4 = RegisterErrorCode(ErrorCode{
+		Code: "ip_address.not_ipv4", Status: http.StatusBadRequest,
+		Message: "must be a valid IPv4 address", Params: []string{"value"}, Example: "must be a valid IPv4 address",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeIPAddressNotIPv6 = RegisterErrorCode(ErrorCode{
+		Code: "ip_address.not_ipv6", Status: http.StatusBadRequest,
+		Message: "must be a valid IPv6 address", Params: []string{"value"}, Example: "must be a valid IPv6 address",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeCIDRNotString = RegisterErrorCode(ErrorCode{
+		Code: "cidr.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeCIDREmpty = RegisterErrorCode(ErrorCode{
+		Code: "cidr.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeCIDRInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "cidr.invalid_format", Status: http.StatusBadRequest,
+		Message: "must be a valid CIDR block", Params: []string{"value"}, Example: "must be a valid CIDR block",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeCIDRNotIPv4 = RegisterErrorCode(ErrorCode{
+		Code: "cidr.not_ipv4", Status: http.StatusBadRequest,
+		Message: "must be a valid IPv4 CIDR block", Params: []string{"value"}, Example: "must be a valid IPv4 CIDR block",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeCIDRNotIPv6 = RegisterErrorCode(ErrorCode{
+		Code: "cidr.not_ipv6", Status: http.StatusBadRequest,
+		Message: "must be a valid IPv6 CIDR block", Params: []string{"value"}, Example: "must be a valid IPv6 CIDR block",
+		Kind: ErrInvalidFormat,
+	})
+)
+
+type IPAddress struct {
+	addr netip.Addr
+}
+
+type IPv4Address struct {
+	IPAddress
+}
+
+type IPv6Address struct {
+	IPAddress
+}
+
+func parseIPAddress(s string) (netip.Addr, error) {
+	if s == "" {
+		return netip.Addr{}, ErrCodeIPAddressEmpty.Err(nil)
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, ErrCodeIPAddressInvalidFormat.Err(map[string]string{"value": s})
+	}
+	return addr, nil
+}
+
+func parseIPv4Address(s string) (netip.Addr, error) {
+	addr, err := parseIPAddress(s)
+	if err != nil {
+		return addr, err
+	}
+	if !addr.Is4() {
+		return netip.Addr{}, ErrCodeIPAddressNotIPv4.Err(map[string]string{"value": s})
+	}
+	return addr, nil
+}
+
+func parseIPv6Address(s string) (netip.Addr, error) {
+	addr, err := parseIPAddress(s)
+	if err != nil {
+		return addr, err
+	}
+	if !addr.Is6() || addr.Is4In6() {
+		return netip.Addr{}, ErrCodeIPAddressNotIPv6.Err(map[string]string{"value": s})
+	}
+	return addr, nil
+}
+
+func (ip IPAddress) Addr() netip.Addr {
+	return ip.addr
+}
+
+func (ip IPAddress) String() string {
+	if !ip.addr.IsValid() {
+		return ""
+	}
+	return ip.addr.String()
+}
+
+/*
+	This part implements `encoding.TextMarshaler`
+	type TextMarshaler interface {
+		MarshalText() (text []byte, err error)
+	}
+*/
+func (ip IPAddress) MarshalText() ([]byte, error) {
+	return []byte(ip.String()), nil
+}
+
+/*
+	This part implements `encoding.TextUnmarshaler`
+	type TextUnmarshaler interface {
+		UnmarshalText(text []byte) error
+	}
+*/
+func (ip *IPAddress) UnmarshalText(b []byte) error {
+	addr, err := parseIPAddress(string(b))
+	if err != nil {
+		return err
+	}
+	ip.addr = addr
+	return nil
+}
+
+func (ip IPAddress) IsZero() bool {
+	return !ip.addr.IsValid()
+}
+
+func (ip IPAddress) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ip.String())
+}
+
+func (ip *IPAddress) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeIPAddressNotString.Err(map[string]string{"value": string(b)})
+	}
+	addr, err := parseIPAddress(s)
+	if err != nil {
+		return err
+	}
+	ip.addr = addr
+	return nil
+}
+
+func (ip *IPv4Address) UnmarshalText(b []byte) error {
+	addr, err := parseIPv4Address(string(b))
+	if err != nil {
+		return err
+	}
+	ip.addr = addr
+	return nil
+}
+
+func (ip *IPv4Address) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeIPAddressNotString.Err(map[string]string{"value": string(b)})
+	}
+	addr, err := parseIPv4Address(s)
+	if err != nil {
+		return err
+	}
+	ip.addr = addr
+	return nil
+}
+
+func (ip *IPv6Address) UnmarshalText(b []byte) error {
+	addr, err := parseIPv6Address(string(b))
+	if err != nil {
+		return err
+	}
+	ip.addr = addr
+	return nil
+}
+
+func (ip *IPv6Address) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeIPAddressNotString.Err(map[string]string{"value": string(b)})
+	}
+	addr, err := parseIPv6Address(s)
+	if err != nil {
+		return err
+	}
+	ip.addr = addr
+	return nil
+}
+
+type CIDR struct {
+	prefix netip.Prefix
+}
+
+type IPv4CIDR struct {
+	CIDR
+}
+
+type IPv6CIDR struct {
+	CIDR
+}
+
+// parseCIDR masks the host bits so "10.1.2.3/8" is stored as "10.0.0.0/8".
+func parseCIDR(s string) (netip.Prefix, error) {
+	if s == "" {
+		return netip.Prefix{}, ErrCodeCIDREmpty.Err(nil)
+	}
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Prefix{}, ErrCodeCIDRInvalidFormat.Err(map[string]string{"value": s})
+	}
+	return prefix.Masked(), nil
+}
+
+func parseIPv4CIDR(s string) (netip.Prefix, error) {
+	prefix, err := parseCIDR(s)
+	if err != nil {
+		return prefix, err
+	}
+	if !prefix.Addr().Is4() {
+		return netip.Prefix{}, ErrCodeCIDRNotIPv4.Err(map[string]string{"value": s})
+	}
+	return prefix, nil
+}
+
+func parseIPv6CIDR(s string) (netip.Prefix, error) {
+	prefix, err := parseCIDR(s)
+	if err != nil {
+		return prefix, err
+	}
+	if !prefix.Addr().Is6() || prefix.Addr().Is4In6() {
+		return netip.Prefix{}, ErrCodeCIDRNotIPv6.Err(map[string]string{"value": s})
+	}
+	return prefix, nil
+}
+
+func (c CIDR) Prefix() netip.Prefix {
+	return c.prefix
+}
+
+func (c CIDR) Contains(ip IPAddress) bool {
+	return c.prefix.IsValid() && c.prefix.Contains(ip.addr)
+}
+
+func (c CIDR) String() string {
+	if !c.prefix.IsValid() {
+		return ""
+	}
+	return c.prefix.String()
+}
+
+func (c CIDR) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func (c *CIDR) UnmarshalText(b []byte) error {
+	prefix, err := parseCIDR(string(b))
+	if err != nil {
+		return err
+	}
+	c.prefix = prefix
+	return nil
+}
+
+func (c CIDR) IsZero() bool {
+	return !c.prefix.IsValid()
+}
+
+func (c CIDR) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.String())
+}
+
+func (c *CIDR) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeCIDRNotString.Err(map[string]string{"value": string(b)})
+	}
+	prefix, err := parseCIDR(s)
+	if err != nil {
+		return err
+	}
+	c.prefix = prefix
+	return nil
+}
+
+func (c *IPv4CIDR) UnmarshalText(b []byte) error {
+	prefix, err := parseIPv4CIDR(string(b))
+	if err != nil {
+		return err
+	}
+	c.prefix = prefix
+	return nil
+}
+
+func (c *IPv4CIDR) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeCIDRNotString.Err(map[string]string{"value": string(b)})
+	}
+	prefix, err := parseIPv4CIDR(s)
+	if err != nil {
+		return err
+	}
+	c.prefix = prefix
+	return nil
+}
+
+func (c *IPv6CIDR) UnmarshalText(b []byte) error {
+	prefix, err := parseIPv6CIDR(string(b))
+	if err != nil {
+		return err
+	}
+	c.prefix = prefix
+	return nil
+}
+
+func (c *IPv6CIDR) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeCIDRNotString.Err(map[string]string{"value": string(b)})
+	}
+	prefix, err := parseIPv6CIDR(s)
+	if err != nil {
+		return err
+	}
+	c.prefix = prefix
+	return nil
+}
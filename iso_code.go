@@ -0,0 +1,247 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+var (
+	ErrCodeCountryCodeNotString = RegisterErrorCode(ErrorCode{
+		Code: "country_code.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeCountryCodeEmpty = RegisterErrorCode(ErrorCode{
+		Code: "country_code.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeCountryCodeInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "country_code.invalid_format", Status: http.StatusBadRequest,
+		Message: "must be a 2-letter ISO 3166-1 country code", Params: []string{"value"}, Example: "must be a 2-letter ISO 3166-1 country code",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeCountryCodeUnknown = RegisterErrorCode(ErrorCode{
+		Code: "country_code.unknown", Status: http.StatusBadRequest,
+		Message: "unknown country code {value}", Params: []string{"value"}, Example: "unknown country code ZZ",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeCurrencyCodeNotString = RegisterErrorCode(ErrorCode{
+		Code: "currency_code.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeCurrencyCodeEmpty = RegisterErrorCode(ErrorCode{
+		Code: "currency_code.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeCurrencyCodeInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "currency_code.invalid_format", Status: http.StatusBadRequest,
+		Message: "must be a 3-letter ISO 4217 currency code", Params: []string{"value"}, Example: "must be a 3-letter ISO 4217 currency code",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeCurrencyCodeUnknown = RegisterErrorCode(ErrorCode{
+		Code: "currency_code.unknown", Status: http.StatusBadRequest,
+		Message: "unknown currency code {value}", Params: []string{"value"}, Example: "unknown currency code XYZ",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeLanguageTagNotString = RegisterErrorCode(ErrorCode{
+		Code: "language_tag.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeLanguageTagEmpty = RegisterErrorCode(ErrorCode{
+		Code: "language_tag.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeLanguageTagUnknown = RegisterErrorCode(ErrorCode{
+		Code: "language_tag.unknown", Status: http.StatusBadRequest,
+		Message: "unknown language tag {value}", Params: []string{"value"}, Example: "unknown language tag en-",
+		Kind: ErrInvalidFormat,
+	})
+)
+
+// CLDR knows these as regions but ISO 3166-1 only reserves them, so they are
+// not accepted as country codes.
+var reservedCountryCodes = map[string]bool{
+	"AC": true, "CP": true, "DG": true, "EA": true, "EU": true, "EZ": true,
+	"IC": true, "TA": true, "UK": true, "UN": true, "XK": true,
+}
+
+// Currencies whose ISO 4217 minor unit is not 2. Funds and precious metals
+// without a minor unit are listed with -1.
+var currencyExponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0,
+	"KRW": 0, "PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0,
+	"XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+	"XAG": -1, "XAU": -1, "XBA": -1, "XBB": -1, "XBC": -1, "XBD": -1,
+	"XDR": -1, "XPD": -1, "XPT": -1, "XSU": -1, "XTS": -1, "XUA": -1, "XXX": -1,
+}
+
+// CountryCode is an ISO 3166-1 alpha-2 code, upper-cased on input.
+type CountryCode string
+
+func ParseCountryCode(s string) (CountryCode, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if len(s) != 2 || s[0] < 'A' || s[0] > 'Z' || s[1] < 'A' || s[1] > 'Z' {
+		return "", ErrCodeCountryCodeInvalidFormat.Err(map[string]string{"value": s})
+	}
+	region, err := language.ParseRegion(s)
+	if err != nil || !region.IsCountry() || region.String() != s || reservedCountryCodes[s] {
+		return "", ErrCodeCountryCodeUnknown.Err(map[string]string{"value": s})
+	}
+	return CountryCode(s), nil
+}
+
+// Name returns the country name in DisplayLocale, e.g. "Indonesia" for ID.
+func (cc CountryCode) Name() string {
+	region, err := language.ParseRegion(string(cc))
+	if err != nil {
+		return ""
+	}
+	return display.Regions(DisplayLocale).Name(region)
+}
+
+func (cc CountryCode) String() string {
+	return string(cc)
+}
+
+func (cc *CountryCode) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeCountryCodeNotString.Err(map[string]string{"value": string(b)})
+	}
+	if s == "" {
+		return ErrCodeCountryCodeEmpty.Err(nil)
+	}
+	code, err := ParseCountryCode(s)
+	if err != nil {
+		return err
+	}
+
+	*cc = code
+
+	return nil
+}
+
+// CurrencyCode is an ISO 4217 alphabetic code, upper-cased on input.
+type CurrencyCode string
+
+func ParseCurrencyCode(s string) (CurrencyCode, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if len(s) != 3 || strings.Trim(s, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return "", ErrCodeCurrencyCodeInvalidFormat.Err(map[string]string{"value": s})
+	}
+	if _, err := currency.ParseISO(s); err != nil {
+		return "", ErrCodeCurrencyCodeUnknown.Err(map[string]string{"value": s})
+	}
+	return CurrencyCode(s), nil
+}
+
+// Exponent returns the ISO 4217 minor unit: 2 for USD (cents), 0 for JPY,
+// 3 for KWD, and -1 for codes such as XAU that have none.
+func (cc CurrencyCode) Exponent() int {
+	if exp, ok := currencyExponents[string(cc)]; ok {
+		return exp
+	}
+	return 2
+}
+
+func (cc CurrencyCode) String() string {
+	return string(cc)
+}
+
+func (cc *CurrencyCode) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeCurrencyCodeNotString.Err(map[string]string{"value": string(b)})
+	}
+	if s == "" {
+		return ErrCodeCurrencyCodeEmpty.Err(nil)
+	}
+	code, err := ParseCurrencyCode(s)
+	if err != nil {
+		return err
+	}
+
+	*cc = code
+
+	return nil
+}
+
+// LanguageTag is a BCP 47 tag, stored in canonical casing ("en-US",
+// "zh-Hant-TW") whatever the client sent.
+type LanguageTag struct {
+	tag language.Tag
+}
+
+func ParseLanguageTag(s string) (LanguageTag, error) {
+	tag, err := language.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return LanguageTag{}, ErrCodeLanguageTagUnknown.Err(map[string]string{"value": s})
+	}
+	return LanguageTag{tag: tag}, nil
+}
+
+func (lt LanguageTag) Tag() language.Tag {
+	return lt.tag
+}
+
+// Name returns the display name in DisplayLocale, e.g. "American English".
+func (lt LanguageTag) Name() string {
+	return display.Tags(DisplayLocale).Name(lt.tag)
+}
+
+func (lt LanguageTag) String() string {
+	if lt.tag == language.Und {
+		return ""
+	}
+	return lt.tag.String()
+}
+
+func (lt LanguageTag) IsZero() bool {
+	return lt.tag == language.Und
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (lt LanguageTag) MarshalJSON() ([]byte, error) {
+	return json.Marshal(lt.String())
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (lt *LanguageTag) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeLanguageTagNotString.Err(map[string]string{"value": string(b)})
+	}
+	if s == "" {
+		return ErrCodeLanguageTagEmpty.Err(nil)
+	}
+	tag, err := ParseLanguageTag(s)
+	if err != nil {
+		return err
+	}
+
+	*lt = tag
+
+	return nil
+}
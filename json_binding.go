@@ -0,0 +1,170 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+// JSONBinding replaces binding.JSON in ctx.ShouldBindWith(&request,
+// JSONBinding). It applies ctype tags (see Bind) and decodes each top-level
+// field on its own, so a request with several invalid fields is answered
+// with all of them as FieldErrors instead of only the first.
+var JSONBinding binding.BindingBody = jsonBinding{}
+
+type jsonBinding struct{}
+
+func (jsonBinding) Name() string {
+	return "json"
+}
+
+func (b jsonBinding) Bind(req *http.Request, obj interface{}) error {
+	if req == nil || req.Body == nil {
+		return errors.New("invalid request")
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return err
+	}
+	return b.BindBody(body, obj)
+}
+
+func (jsonBinding) BindBody(body []byte, obj interface{}) error {
+	if err := decodeFields(body, obj); err != nil {
+		return err
+	}
+	if binding.Validator == nil {
+		return nil
+	}
+	return binding.Validator.ValidateStruct(obj)
+}
+
+// FieldError is a client error for one field of a request body. Code and
+// Params are set when the error was a CodedError.
+type FieldError struct {
+	Field   string
+	Message string
+	Code    string
+	Params  map[string]string
+	err     error
+}
+
+func newFieldError(field string, err error) FieldError {
+	code, params := errorCodeOf(err)
+	return FieldError{Field: field, Message: err.Error(), Code: code, Params: params, err: err}
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// Unwrap returns the error the field failed with.
+func (e FieldError) Unwrap() error {
+	return e.err
+}
+
+// FieldErrors lists every invalid field of a request, in struct order.
+// ErrorMiddleware answers it with 400 and a "fields" object.
+type FieldErrors []FieldError
+
+func (fe FieldErrors) Error() string {
+	messages := make([]string, len(fe))
+	for i, e := range fe {
+		messages[i] = e.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// decodeFields is json.Unmarshal for a pointer to a struct, except that a
+// field failing to decode is recorded and the next one is tried. Anything
+// else is left to json.Unmarshal.
+func decodeFields(body []byte, obj interface{}) error {
+	v := reflect.ValueOf(obj)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return json.Unmarshal(body, obj)
+	}
+
+	var raws map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raws); err != nil {
+		return err
+	}
+	var fe FieldErrors
+	decodeStruct(v.Elem(), raws, &fe)
+	if len(fe) > 0 {
+		return fe
+	}
+	return validateRequest(obj)
+}
+
+func decodeStruct(v reflect.Value, raws map[string]json.RawMessage, fe *FieldErrors) {
+	for i := 0; i < v.NumField(); i++ {
+		spec := v.Type().Field(i)
+		name, _, _ := strings.Cut(spec.Tag.Get("json"), ",")
+		if name == "-" || !spec.IsExported() && !spec.Anonymous {
+			continue
+		}
+		// Embedded structs are promoted, as encoding/json does.
+		if spec.Anonymous && name == "" && spec.Type.Kind() == reflect.Struct {
+			decodeStruct(v.Field(i), raws, fe)
+			continue
+		}
+		if name == "" {
+			name = spec.Name
+		}
+
+		raw, ok := raws[name]
+		if !ok {
+			// encoding/json falls back to a case-insensitive match.
+			for key, value := range raws {
+				if strings.EqualFold(key, name) {
+					raw, ok = value, true
+					break
+				}
+			}
+		}
+		if !ok {
+			continue
+		}
+		if err := decodeField(v.Field(i), spec, raw); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				if typeErr.Field != "" {
+					name += "." + typeErr.Field
+				}
+				err = wrongTypeError(typeErr)
+			}
+			*fe = append(*fe, newFieldError(name, err))
+		} else if err := validateField(v.Field(i)); err != nil {
+			*fe = append(*fe, newFieldError(name, err))
+		}
+	}
+}
+
+// decodeField turns a BadRequestError panic from an UnmarshalJSON into an
+// error, so the other fields still get decoded.
+func decodeField(field reflect.Value, spec reflect.StructField, raw json.RawMessage) (err error) {
+	defer recoverBadRequest(&err)
+
+	if spec.Tag.Get("ctype") != "" {
+		return applyCtype(field, spec, raw)
+	}
+	return json.Unmarshal(raw, field.Addr().Interface())
+}
+
+// recoverBadRequest, deferred, returns a BadRequestError panic as *err and
+// re-panics anything else.
+func recoverBadRequest(err *error) {
+	if r := recover(); r != nil {
+		bad, ok := r.(BadRequestError)
+		if !ok {
+			panic(r)
+		}
+		recordLegacyPanic(bad.message)
+		*err = bad
+	}
+}
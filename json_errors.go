@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+)
+
+var (
+	ErrCodeWrongType = RegisterErrorCode(ErrorCode{
+		Code: "value.wrong_type", Status: http.StatusBadRequest,
+		Message: "must be {type}", Params: []string{"type", "value"}, Example: "must be an integer",
+		Kind: ErrWrongType,
+	})
+	// ErrCodeStringNotString is a non-string sent for a plain string field.
+	ErrCodeStringNotString = RegisterErrorCode(ErrorCode{
+		Code: "string.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeInvalidJSON = RegisterErrorCode(ErrorCode{
+		Code: "request.invalid_json", Status: http.StatusBadRequest,
+		Message: "request body must be valid JSON", Example: "request body must be valid JSON",
+	})
+)
+
+// TranslateJSONError turns the errors encoding/json reports for plain Go
+// fields into the package's client errors: a type mismatch becomes
+// FieldErrors naming the field, like the custom types produce, and a
+// syntax error a coded 400. Other errors are returned unchanged.
+func TranslateJSONError(err error) error {
+	var (
+		typeErr   *json.UnmarshalTypeError
+		syntaxErr *json.SyntaxError
+	)
+	switch {
+	case errors.As(err, &typeErr):
+		field := typeErr.Field
+		if field == "" {
+			field = "body"
+		}
+		return FieldErrors{newFieldError(field, wrongTypeError(typeErr))}
+	case errors.As(err, &syntaxErr):
+		return ErrCodeInvalidJSON.Err(nil)
+	}
+	return err
+}
+
+func wrongTypeError(err *json.UnmarshalTypeError) CodedError {
+	if err.Type.Kind() == reflect.String {
+		return ErrCodeStringNotString.Err(map[string]string{"value": err.Value})
+	}
+	return ErrCodeWrongType.Err(map[string]string{"type": jsonTypeName(err.Type), "value": err.Value})
+}
+
+// jsonTypeName describes t the way a client sees it.
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Map, reflect.Struct:
+		return "an object"
+	case reflect.String:
+		return "a string"
+	}
+	return "a valid value"
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+var (
+	ErrCodeJSONRawTooLarge = RegisterErrorCode(ErrorCode{
+		Code: "json_raw.too_large", Status: http.StatusBadRequest,
+		Message: "must not be larger than {max} bytes", Params: []string{"max"}, Example: "must not be larger than 65536 bytes",
+	})
+	ErrCodeJSONRawInvalid = RegisterErrorCode(ErrorCode{
+		Code: "json_raw.invalid", Status: http.StatusBadRequest,
+		Message: "must be valid JSON", Example: "must be valid JSON",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeJSONRawTooDeep = RegisterErrorCode(ErrorCode{
+		Code: "json_raw.too_deep", Status: http.StatusBadRequest,
+		Message: "must not be nested more than {max} levels deep", Params: []string{"max"}, Example: "must not be nested more than 32 levels deep",
+	})
+)
+
+// JSONRawMaxSize caps a JSONRaw payload in bytes and JSONRawMaxDepth caps how
+// deeply objects and arrays may nest. Zero or negative disables the check.
+var (
+	JSONRawMaxSize  = 64 << 10
+	JSONRawMaxDepth = 32
+)
+
+// JSONRaw passes a JSON value through untouched, like json.RawMessage, for
+// fields such as webhook payloads or user-defined metadata. Unlike
+// json.RawMessage it enforces JSONRawMaxSize and JSONRawMaxDepth, and it can
+// be stored in a JSON/JSONB column.
+type JSONRaw []byte
+
+func ParseJSONRaw(b []byte) (JSONRaw, error) {
+	if JSONRawMaxSize > 0 && len(b) > JSONRawMaxSize {
+		return nil, ErrCodeJSONRawTooLarge.Err(map[string]string{"max": strconv.Itoa(JSONRawMaxSize)})
+	}
+	if !json.Valid(b) {
+		return nil, ErrCodeJSONRawInvalid.Err(nil)
+	}
+	if JSONRawMaxDepth > 0 && jsonDepth(b) > JSONRawMaxDepth {
+		return nil, ErrCodeJSONRawTooDeep.Err(map[string]string{"max": strconv.Itoa(JSONRawMaxDepth)})
+	}
+	return append(JSONRaw{}, b...), nil
+}
+
+// jsonDepth returns the deepest nesting of objects and arrays in b, which
+// must be valid JSON.
+func jsonDepth(b []byte) int {
+	depth, deepest := 0, 0
+	inString, escaped := false, false
+	for _, c := range b {
+		switch {
+		case escaped:
+			escaped = false
+		case inString:
+			if c == '\\' {
+				escaped = true
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			depth++
+			if depth > deepest {
+				deepest = depth
+			}
+		case c == '}' || c == ']':
+			depth--
+		}
+	}
+	return deepest
+}
+
+// Decode unmarshals the payload into v.
+func (jr JSONRaw) Decode(v interface{}) error {
+	return json.Unmarshal(jr, v)
+}
+
+func (jr JSONRaw) String() string {
+	return string(jr)
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (jr JSONRaw) MarshalJSON() ([]byte, error) {
+	if jr == nil {
+		return []byte("null"), nil
+	}
+	return jr, nil
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+
+	A JSON null is kept as the payload "null" rather than a nil JSONRaw, so
+	an explicit null can be told apart from an absent field.
+*/
+func (jr *JSONRaw) UnmarshalJSON(b []byte) error {
+	parsed, err := ParseJSONRaw(b)
+	if err != nil {
+		return err
+	}
+
+	*jr = parsed
+
+	return nil
+}
+
+/*
+	This part implements `sql.Scanner`
+	type Scanner interface {
+		Scan(src any) error
+	}
+
+	Stored payloads are only checked for syntax, not against the limits,
+	so rows written before a limit was lowered can still be read.
+*/
+func (jr *JSONRaw) Scan(src interface{}) error {
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		*jr = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into JSONRaw", src)
+	}
+	if !json.Valid(b) {
+		return errors.New("cannot scan invalid JSON into JSONRaw")
+	}
+	// The driver may reuse its buffer after Scan returns.
+	*jr = append(JSONRaw{}, b...)
+	return nil
+}
+
+/*
+	This part implements `driver.Valuer`
+	type Valuer interface {
+		Value() (Value, error)
+	}
+
+	The payload is sent as a string: some drivers send []byte as bytea,
+	which a json or jsonb column does not accept.
+*/
+func (jr JSONRaw) Value() (driver.Value, error) {
+	if jr == nil {
+		return nil, nil
+	}
+	return string(jr), nil
+}
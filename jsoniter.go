@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+)
+
+// Bind and JSONBinding decode with encoding/json whichever engine gin was
+// built with. ctx.ShouldBindJSON and direct calls use the engine itself:
+//
+//   - bytedance/sonic (gin -tags=sonic) calls UnmarshalJSON like
+//     encoding/json: an error comes back as it is and a BadRequestError
+//     panic goes through to ErrorMiddleware. Nothing needs registering.
+//   - json-iterator (gin -tags=jsoniter) lets a panic through too, but
+//     flattens a returned error into a string with the field path and byte
+//     offset, so ErrorMiddleware no longer finds its BadRequestError or
+//     error code and answers 500. RegisterJSONIter fixes that.
+//
+// typetest.Codec.Engines checks a type against an engine from a test.
+
+// RegisterJSONIter makes json-iterator return the error of the first of the
+// package's types that failed, unchanged, as encoding/json does. It
+// registers a global extension, so call it once at startup, before
+// json-iterator caches a decoder for a type.
+func RegisterJSONIter() {
+	jsoniter.RegisterExtension(&jsoniterExtension{})
+}
+
+var packagePath = reflect.TypeOf(DateTime{}).PkgPath()
+
+type jsoniterExtension struct {
+	jsoniter.DummyExtension
+}
+
+// jsoniterError is the iterator's Attachment while a top-level value is
+// decoded, and keeps the first error a type returned.
+type jsoniterError struct {
+	err error
+}
+
+func (*jsoniterExtension) CreateDecoder(typ reflect2.Type) jsoniter.ValDecoder {
+	t := typ.Type1()
+	if t.PkgPath() != packagePath || !reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return nil
+	}
+	return jsoniterUnmarshaler{typ: typ}
+}
+
+func (*jsoniterExtension) DecorateDecoder(typ reflect2.Type, decoder jsoniter.ValDecoder) jsoniter.ValDecoder {
+	return jsoniterErrorKeeper{decoder: decoder}
+}
+
+// jsoniterUnmarshaler is json-iterator's own Unmarshaler decoder, except
+// that it records the error as it is.
+type jsoniterUnmarshaler struct {
+	typ reflect2.Type
+}
+
+func (d jsoniterUnmarshaler) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	raw := iter.SkipAndReturnBytes()
+	if iter.Error != nil && iter.Error != io.EOF {
+		return
+	}
+	err := d.typ.PackEFace(ptr).(json.Unmarshaler).UnmarshalJSON(raw)
+	if err == nil {
+		return
+	}
+	if state, ok := iter.Attachment.(*jsoniterError); ok && state.err == nil {
+		state.err = err
+	}
+	iter.ReportError("UnmarshalJSON", err.Error())
+}
+
+// jsoniterErrorKeeper puts the recorded error back in place of the
+// flattened one once the outermost value is decoded.
+type jsoniterErrorKeeper struct {
+	decoder jsoniter.ValDecoder
+}
+
+func (k jsoniterErrorKeeper) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	if iter.Attachment != nil {
+		k.decoder.Decode(ptr, iter)
+		return
+	}
+	state := &jsoniterError{}
+	iter.Attachment = state
+	defer func() { iter.Attachment = nil }()
+
+	k.decoder.Decode(ptr, iter)
+	if state.err != nil && iter.Error != nil && iter.Error != io.EOF {
+		iter.Error = state.err
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+
+	"myapp/typetest"
+)
+
+var registerJSONIterOnce sync.Once
+
+// jsonIterEngine is json-iterator as gin -tags=jsoniter uses it, with
+// RegisterJSONIter applied.
+func jsonIterEngine() typetest.Engine {
+	registerJSONIterOnce.Do(RegisterJSONIter)
+	return typetest.Engine{
+		Name:      "jsoniter",
+		Marshal:   jsoniter.ConfigCompatibleWithStandardLibrary.Marshal,
+		Unmarshal: jsoniter.ConfigCompatibleWithStandardLibrary.Unmarshal,
+	}
+}
+
+func withJSONIter[T any](c typetest.Codec[T]) typetest.Codec[T] {
+	c.Engines = append(append([]typetest.Engine(nil), c.Engines...), jsonIterEngine())
+	return c
+}
+
+// TestJSONIterEngine decodes every example of the core types with
+// encoding/json and json-iterator, alone and as a struct field, and
+// requires the same value or the same error and message from both.
+func TestJSONIterEngine(t *testing.T) {
+	t.Run("DateTime", func(t *testing.T) { typetest.Conformance(t, withJSONIter(dateTimeCodec)) })
+	t.Run("NullDateTime", func(t *testing.T) { typetest.Conformance(t, withJSONIter(nullDateTimeCodec)) })
+	t.Run("Date", func(t *testing.T) { typetest.Conformance(t, withJSONIter(dateCodec)) })
+	t.Run("ArrayString", func(t *testing.T) { typetest.Conformance(t, withJSONIter(arrayStringCodec)) })
+	t.Run("Duration", func(t *testing.T) { typetest.Conformance(t, withJSONIter(durationCodec)) })
+	t.Run("Period", func(t *testing.T) { typetest.Conformance(t, withJSONIter(periodCodec)) })
+	t.Run("StringInt64", func(t *testing.T) { typetest.Conformance(t, withJSONIter(stringInt64Codec)) })
+	t.Run("NullString", func(t *testing.T) { typetest.Conformance(t, withJSONIter(nullStringCodec)) })
+	t.Run("NullInt64", func(t *testing.T) { typetest.Conformance(t, withJSONIter(nullInt64Codec)) })
+	t.Run("FlexibleBool", func(t *testing.T) { typetest.Conformance(t, withJSONIter(flexibleBoolCodec)) })
+	t.Run("ByteSize", func(t *testing.T) { typetest.Conformance(t, withJSONIter(byteSizeCodec)) })
+	t.Run("Percentage", func(t *testing.T) { typetest.Conformance(t, withJSONIter(percentageCodec)) })
+	t.Run("IPAddress", func(t *testing.T) { typetest.Conformance(t, withJSONIter(ipAddressCodec)) })
+	t.Run("Timezone", func(t *testing.T) { typetest.Conformance(t, withJSONIter(timezoneCodec)) })
+	t.Run("Semver", func(t *testing.T) { typetest.Conformance(t, withJSONIter(semverCodec)) })
+	t.Run("OrderStatus", func(t *testing.T) { typetest.Conformance(t, withJSONIter(orderStatusCodec)) })
+	t.Run("Priority", func(t *testing.T) { typetest.Conformance(t, withJSONIter(priorityCodec)) })
+	t.Run("ObfuscatedID", func(t *testing.T) { typetest.Conformance(t, withJSONIter(obfuscatedIDCodec)) })
+	t.Run("TrimmedString", func(t *testing.T) { typetest.Conformance(t, withJSONIter(trimmedStringCodec)) })
+	t.Run("GeoPoint", func(t *testing.T) { typetest.Conformance(t, withJSONIter(geoPointCodec)) })
+}
+
+// TestJSONIterErrorCode checks what RegisterJSONIter is for: the error a
+// type returns from inside a request struct keeps its code, so
+// ErrorMiddleware answers 400 rather than 500.
+func TestJSONIterErrorCode(t *testing.T) {
+	engine := jsonIterEngine()
+	for _, tc := range []struct {
+		body string
+		code string
+	}{
+		{`{"cron":"0 9 * * MON","from":"2020-01-01"}`, "datetime.invalid_format"},
+		{`{"cron":"0 9 * * MON","from":true}`, "datetime.not_string"},
+		{`{"cron":"0 9 * * MON","from":""}`, "datetime.empty"},
+	} {
+		var std, iter RequestContentSchedule
+		stdErr := json.Unmarshal([]byte(tc.body), &std)
+		iterErr := engine.Unmarshal([]byte(tc.body), &iter)
+		if code, _ := errorCodeOf(stdErr); code != tc.code {
+			t.Errorf("encoding/json %s: code %q (%v), want %q", tc.body, code, stdErr, tc.code)
+		}
+		if code, _ := errorCodeOf(iterErr); code != tc.code {
+			t.Errorf("jsoniter %s: code %q (%v), want %q", tc.body, code, iterErr, tc.code)
+		}
+		if stdErr == nil || iterErr == nil || stdErr.Error() != iterErr.Error() {
+			t.Errorf("%s: jsoniter error %v, encoding/json %v", tc.body, iterErr, stdErr)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"log"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// LegacyPanicSite is a call site that still reports a client error by
+// panicking with BadRequestError instead of returning it.
+type LegacyPanicSite struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Count    int64  `json:"count"`
+}
+
+// LegacyPanicHook is called every time a BadRequestError panic is
+// recovered, by the recovery middleware turning it into a 400 or by the
+// binding and decoding helpers turning it into an error. The default logs
+// each call site the first time it panics; replace it to feed a metrics
+// counter instead. Returning the error (see BadRequestError.Error) does not
+// trigger it.
+var LegacyPanicHook = func(site LegacyPanicSite, message string) {
+	if site.Count == 1 {
+		log.Printf("deprecated: BadRequestError panic at %s (%s:%d): %s", site.Function, site.File, site.Line, message)
+	}
+}
+
+var (
+	legacyPanicMu    sync.Mutex
+	legacyPanicSites = map[uintptr]*LegacyPanicSite{}
+)
+
+// LegacyPanicUsage returns every call site seen so far, most used first, to
+// drive the migration away from panics.
+func LegacyPanicUsage() []LegacyPanicSite {
+	legacyPanicMu.Lock()
+	defer legacyPanicMu.Unlock()
+
+	sites := make([]LegacyPanicSite, 0, len(legacyPanicSites))
+	for _, site := range legacyPanicSites {
+		sites = append(sites, *site)
+	}
+	sort.Slice(sites, func(i, j int) bool {
+		if sites[i].Count != sites[j].Count {
+			return sites[i].Count > sites[j].Count
+		}
+		return sites[i].Function < sites[j].Function
+	})
+	return sites
+}
+
+// recordLegacyPanic must be called from the deferred function that
+// recovered the panic: the panicking frames are still on the stack there,
+// directly below runtime.gopanic.
+func recordLegacyPanic(message string) {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+
+	var caller runtime.Frame
+	for frame, more := frames.Next(); more; frame, more = frames.Next() {
+		if frame.Function != "runtime.gopanic" {
+			continue
+		}
+		caller, _ = frames.Next()
+		break
+	}
+	if caller.PC == 0 {
+		return
+	}
+
+	legacyPanicMu.Lock()
+	site, ok := legacyPanicSites[caller.PC]
+	if !ok {
+		file := caller.File
+		if i := strings.LastIndex(file, "/"); i >= 0 {
+			file = file[i+1:]
+		}
+		site = &LegacyPanicSite{Function: caller.Function, File: file, Line: caller.Line}
+		legacyPanicSites[caller.PC] = site
+	}
+	site.Count++
+	snapshot := *site
+	legacyPanicMu.Unlock()
+
+	if LegacyPanicHook != nil {
+		LegacyPanicHook(snapshot, message)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"log"
+	"strings"
+	"testing"
+)
+
+type legacyPanicker struct{}
+
+func (*legacyPanicker) UnmarshalJSON([]byte) error {
+	panic(NewBadRequestError(nil, "legacy panic for the test"))
+}
+
+// legacyPanicker's call site; the package is main in the binary and myapp
+// in its test.
+const legacyPanickerFunction = ".(*legacyPanicker).UnmarshalJSON"
+
+func legacyPanicCount() int64 {
+	for _, site := range LegacyPanicUsage() {
+		if strings.HasSuffix(site.Function, legacyPanickerFunction) {
+			return site.Count
+		}
+	}
+	return 0
+}
+
+func TestRecoverBadRequestRecordsLegacyPanic(t *testing.T) {
+	var logged bytes.Buffer
+	defer log.SetOutput(log.Writer())
+	log.SetOutput(&logged)
+
+	before := legacyPanicCount()
+	for i := 0; i < 3; i++ {
+		var v legacyPanicker
+		if err := unmarshalBinaryJSON(&v, []byte(`"x"`)); err == nil || err.Error() != "legacy panic for the test" {
+			t.Fatalf("error %v, want the panic's message", err)
+		}
+	}
+	if got := legacyPanicCount() - before; got != 3 {
+		t.Errorf("recorded %d panics, want 3", got)
+	}
+
+	// The default hook logs a call site once, not on every request.
+	if n := strings.Count(logged.String(), legacyPanickerFunction+" (legacy_panic_test.go:"); before == 0 && n != 1 {
+		t.Errorf("logged the call site %d times, want once:\n%s", n, logged.String())
+	}
+}
+
+func TestDecodeFieldRecordsLegacyPanic(t *testing.T) {
+	var hooked []string
+	defer func(hook func(LegacyPanicSite, string)) { LegacyPanicHook = hook }(LegacyPanicHook)
+	LegacyPanicHook = func(site LegacyPanicSite, message string) {
+		hooked = append(hooked, site.Function+": "+message)
+	}
+
+	var request struct {
+		Value legacyPanicker `json:"value"`
+	}
+	if err := JSONBinding.BindBody([]byte(`{"value":"x"}`), &request); err == nil {
+		t.Fatal("BindBody accepted a value whose UnmarshalJSON panics")
+	}
+	if want := legacyPanickerFunction + ": legacy panic for the test"; len(hooked) != 1 || !strings.HasSuffix(hooked[0], want) {
+		t.Errorf("hook calls %q, want one ending in %q", hooked, want)
+	}
+}
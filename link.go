@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Link is a navigable action attached to a response resource.
+type Link struct {
+	Href   string `json:"href"`
+	Rel    string `json:"rel"`
+	Method string `json:"method"`
+}
+
+type Links []Link
+
+// Get returns the first link with the given rel.
+func (ls Links) Get(rel string) (Link, bool) {
+	for _, l := range ls {
+		if l.Rel == rel {
+			return l, true
+		}
+	}
+	return Link{}, false
+}
+
+// LinkBuilder generates hrefs from the routes registered on a gin engine,
+// so a renamed or removed route fails loudly instead of producing a dead
+// link. Routes are looked up when a link is built, so the builder can be
+// created before the routes are added.
+type LinkBuilder struct {
+	engine *gin.Engine
+}
+
+func NewLinkBuilder(engine *gin.Engine) *LinkBuilder {
+	return &LinkBuilder{engine: engine}
+}
+
+// Link fills the route's ":name" and "*name" segments from params, given as
+// name/value pairs, escaping each value:
+//
+//	lb.Link("self", http.MethodGet, "/orders/:id", "id", "42")
+//
+// It panics when the route is not registered or a parameter is missing or
+// unknown; those are programming errors, not client errors.
+func (lb *LinkBuilder) Link(rel string, method string, route string, params ...string) Link {
+	if !lb.hasRoute(method, route) {
+		panic(fmt.Errorf("link %q: no route %s %s", rel, method, route))
+	}
+	if len(params)%2 != 0 {
+		panic(fmt.Errorf("link %q: params must be name/value pairs", rel))
+	}
+	values := make(map[string]string, len(params)/2)
+	for i := 0; i < len(params); i += 2 {
+		values[params[i]] = params[i+1]
+	}
+
+	segments := strings.Split(route, "/")
+	for i, segment := range segments {
+		if segment == "" || (segment[0] != ':' && segment[0] != '*') {
+			continue
+		}
+		name := segment[1:]
+		value, ok := values[name]
+		if !ok {
+			panic(fmt.Errorf("link %q: missing param %s for %s", rel, name, route))
+		}
+		delete(values, name)
+		if segment[0] == '*' {
+			// Catch-all values keep their slashes.
+			parts := strings.Split(strings.TrimPrefix(value, "/"), "/")
+			for j := range parts {
+				parts[j] = url.PathEscape(parts[j])
+			}
+			segments[i] = strings.Join(parts, "/")
+		} else {
+			segments[i] = url.PathEscape(value)
+		}
+	}
+	for name := range values {
+		panic(fmt.Errorf("link %q: unknown param %s for %s", rel, name, route))
+	}
+
+	return Link{Href: strings.Join(segments, "/"), Rel: rel, Method: method}
+}
+
+func (lb *LinkBuilder) hasRoute(method string, route string) bool {
+	for _, r := range lb.engine.Routes() {
+		if r.Method == method && r.Path == route {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PaginationLinks renders an RFC 8288 Link header value for page-number
+// pagination. URLs are the current request's path and query with only the
+// page parameter replaced, so filters and per_page carry over. "last" is
+// omitted while the total is unknown.
+func PaginationLinks(ctx *gin.Context, p Pagination) string {
+	var links []string
+	link := func(rel string, page int) {
+		links = append(links, formatLink(ctx, rel, "page", strconv.Itoa(page)))
+	}
+
+	link("first", 1)
+	if p.HasPrev() {
+		link("prev", p.Page()-1)
+	}
+	if p.HasNext() {
+		link("next", p.Page()+1)
+	}
+	if p.Total() >= 0 {
+		last := p.TotalPages()
+		if last < 1 {
+			last = 1
+		}
+		link("last", last)
+	}
+	return strings.Join(links, ", ")
+}
+
+// CursorLinks renders a Link header value for cursor pagination. Cursors
+// only go forwards and backwards, so there is no "last".
+func CursorLinks(ctx *gin.Context, next Cursor, prev Cursor) string {
+	links := []string{formatLink(ctx, "first", "cursor", "")}
+	if !prev.IsZero() {
+		links = append(links, formatLink(ctx, "prev", "cursor", prev.String()))
+	}
+	if !next.IsZero() {
+		links = append(links, formatLink(ctx, "next", "cursor", next.String()))
+	}
+	return strings.Join(links, ", ")
+}
+
+func formatLink(ctx *gin.Context, rel string, param string, value string) string {
+	u := *ctx.Request.URL
+	query := u.Query()
+	if value == "" {
+		query.Del(param)
+	} else {
+		query.Set(param, value)
+	}
+	u.RawQuery = query.Encode()
+	return "<" + u.RequestURI() + `>; rel="` + rel + `"`
+}
@@ -2,18 +2,57 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"encoding/gob"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/caarlos0/env/v6"
+	"github.com/fxamacker/cbor/v2"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/jackc/pgx/v5/pgtype"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.mongodb.org/mongo-driver/bson"
+	"golang.org/x/text/language"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"gopkg.in/yaml.v3"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 func main() {
+	exportErrorCatalog := flag.Bool("error-catalog", false, "print the error code catalog as JSON and exit")
+	configPath := flag.String("config", "", "YAML or JSON file with type defaults, see config.example.yaml")
+	flag.Parse()
+	if *configPath != "" {
+		if err := LoadConfig(*configPath); err != nil {
+			panic(err)
+		}
+	}
+	if *exportErrorCatalog {
+		if err := WriteErrorCatalog(os.Stdout); err != nil {
+			panic(err)
+		}
+		return
+	}
+
 	var response *httptest.ResponseRecorder
 
 	// DateTime
@@ -25,212 +64,3279 @@ func main() {
 	response = makeTestRequest(http.MethodPost, "/date-time", map[string]interface{}{
 		"time_at": "",
 	})
-	fmt.Printf("%+v\n", response.Body.String()) // [400] {"error":"must not be empty"}
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"datetime.empty","error":"must not be empty"}
 	response = makeTestRequest(http.MethodPost, "/date-time", map[string]interface{}{
 		"time_at": true,
 	})
-	fmt.Printf("%+v\n", response.Body.String()) // [400] {"error":"not a valid string"}
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"datetime.not_string","error":"not a valid string"}
 	response = makeTestRequest(http.MethodPost, "/date-time", map[string]interface{}{
 		"time_at": "wrong-format",
 	})
-	fmt.Printf("%+v\n", response.Body.String()) // [400] {"error":"format must be YYYY-MM-DDTHH:mm:ssZ"}
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"datetime.invalid_format","error":"format must be YYYY-MM-DDTHH:mm:ssZ"}
 
 	// ArrayString
 	response = makeTestRequest(http.MethodPost, "/array-string", map[string]interface{}{
 		"list": "1,2,3,4",
 	})
-	fmt.Printf("%+v\n", response.Body.String()) // [200] {"time_at":"2020-01-01T02:02:05+07:00"}
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"list":"1,2,3,4"}
 
 	response = makeTestRequest(http.MethodPost, "/array-string", map[string]interface{}{
 		"list": true,
 	})
-	fmt.Printf("%+v\n", response.Body.String()) // [400] {"error":"must not be empty"}
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"array.not_string","error":"must be a valid string"}
 	response = makeTestRequest(http.MethodPost, "/array-string", map[string]interface{}{
 		"list": "",
 	})
-	fmt.Printf("%+v\n", response.Body.String()) // [400] {"error":"must be a valid string"}
-}
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"array.empty","error":"must not be empty"}
 
-var (
-	router     *gin.Engine
-	routerOnce sync.Once
-)
+	// RetryPolicy
+	response = makeTestRequest(http.MethodPost, "/retry-policy", map[string]interface{}{
+		"retry": map[string]interface{}{
+			"max_attempts": 5,
+			"backoff":      "exponential",
+			"base":         "200ms",
+			"max":          "30s",
+		},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"retry":{"max_attempts":5,"backoff":"exponential","base":"200ms","max":"30s"}}
 
-type BadRequestError string
+	response = makeTestRequest(http.MethodPost, "/retry-policy", map[string]interface{}{
+		"retry": map[string]interface{}{
+			"max_attempts": 5,
+			"backoff":      "exponential",
+			"base":         "1m",
+			"max":          "30s",
+		},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"retry_policy.max_below_base","error":"max must not be less than base"}
 
-type DateTime struct {
-	time time.Time
-}
+	// IPAddress & CIDR
+	response = makeTestRequest(http.MethodPost, "/ip-address", map[string]interface{}{
+		"source": "10.1.2.3",
+		"allow":  "10.1.2.3/8",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"source":"10.1.2.3","allow":"10.0.0.0/8"}
 
-// RFC3339     = "2006-01-02T15:04:05Z07:00"
-func (dt DateTime) format() string {
-	return time.RFC3339
-}
+	response = makeTestRequest(http.MethodPost, "/ip-address", map[string]interface{}{
+		"source": "::1",
+		"allow":  "10.0.0.0/8",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"ip_address.not_ipv4","error":"must be a valid IPv4 address"}
 
-/*
-	This receiver function overwrite `fmt.Stringer` which use to print the output
-	type Stringer interface {
-		String() string
-	}
-*/
-func (dt DateTime) String() string {
-	return dt.time.Format(dt.format())
-}
+	// BreakerConfig
+	response = makeTestRequest(http.MethodPost, "/breaker-config", map[string]interface{}{
+		"breaker": map[string]interface{}{
+			"window": "30s",
+		},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"breaker":{"error_rate_percent":50,"window":"30s","min_requests":20}}
 
-/*
-	This part implements `json.Marshaler`
-	type Marshaler interface {
-		MarshalJSON() ([]byte, error)
-	}
-*/
-func (dt DateTime) MarshalJSON() ([]byte, error) {
-	return json.Marshal(dt.String())
-}
+	response = makeTestRequest(http.MethodPost, "/breaker-config", map[string]interface{}{
+		"breaker": map[string]interface{}{
+			"error_rate_percent": 150,
+		},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"breaker_config.error_rate_percent","error":"error_rate_percent must be greater than 0 and at most 100"}
 
-/*
-	This part implements `json.Unmarshaler`
-	type Unmarshaler interface {
-		UnmarshalJSON([]byte) error
-	}
-*/
-func (dt *DateTime) UnmarshalJSON(b []byte) error {
-	var s string
-	err := json.Unmarshal(b, &s)
-	if err != nil {
-		panic(BadRequestError("not a valid string"))
-	}
-	if s == "" {
-		panic(BadRequestError("must not be empty"))
-	}
-	t, err := time.Parse(dt.format(), s)
-	if err != nil {
-		panic(BadRequestError("format must be YYYY-MM-DDTHH:mm:ssZ"))
-	}
+	// Duration
+	response = makeTestRequest(http.MethodPost, "/duration", map[string]interface{}{
+		"timeout": "1h30m",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"timeout":"1h30m"}
 
-	dt.time = t
+	response = makeTestRequest(http.MethodPost, "/duration", map[string]interface{}{
+		"timeout": 90,
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"timeout":"1m30s"}
 
-	return nil
-}
+	response = makeTestRequest(http.MethodPost, "/duration", map[string]interface{}{
+		"timeout": "an hour",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"duration.invalid_format","error":"format must be a duration like 1h30m or 90s"}
 
-type ArrayString []string
+	// HealthCheck
+	response = makeTestRequest(http.MethodPost, "/health-check", map[string]interface{}{
+		"check": map[string]interface{}{
+			"url":               "https://example.com/healthz",
+			"interval":          "30s",
+			"timeout":           "5s",
+			"expected_statuses": []int{200, 204},
+		},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"check":{"url":"https://example.com/healthz","method":"GET","interval":"30s","timeout":"5s","expected_statuses":[200,204]}}
 
-func (dt ArrayString) separator() string {
-	return ","
-}
+	response = makeTestRequest(http.MethodPost, "/health-check", map[string]interface{}{
+		"check": map[string]interface{}{
+			"url":      "https://example.com/healthz",
+			"interval": "5s",
+			"timeout":  "10s",
+		},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"health_check.timeout_too_long","error":"timeout must be less than interval"}
 
-func (dt ArrayString) parse(s string) []string {
-	return strings.Split(s, dt.separator())
-}
+	// Period
+	response = makeTestRequest(http.MethodPost, "/period", map[string]interface{}{
+		"every": "P1DT2H30M",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"every":"P1DT2H30M"}
 
-func (dt ArrayString) String() string {
-	return strings.Join(dt, dt.separator())
-}
+	response = makeTestRequest(http.MethodPost, "/period", map[string]interface{}{
+		"every": "1h30m",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"period.invalid_format","error":"format must be an ISO-8601 duration like P1DT2H30M"}
 
-func (dt ArrayString) List() []string {
-	return dt
-}
+	// Channel
+	response = makeTestRequest(http.MethodPost, "/channel", map[string]interface{}{
+		"notify": []map[string]interface{}{
+			{"type": "email", "address": "ops@example.com"},
+			{"type": "slack", "webhook_url": "https://hooks.slack.com/services/T0/B0/x", "channel": "#alerts"},
+		},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"notify":[{"address":"ops@example.com","type":"email"},{"channel":"#alerts","type":"slack","webhook_url":"https://hooks.slack.com/services/T0/B0/x"}]}
 
-/*
-	This part implements `json.Marshaler`
-	type Marshaler interface {
-		MarshalJSON() ([]byte, error)
-	}
-*/
-func (dt ArrayString) MarshalJSON() ([]byte, error) {
-	return json.Marshal(dt.String())
-}
+	response = makeTestRequest(http.MethodPost, "/channel", map[string]interface{}{
+		"notify": []map[string]interface{}{
+			{"type": "sms", "phone": "0812345"},
+		},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"channel.phone","error":"phone must be an E.164 phone number like +6281234567890"}
 
-/*
-	This part implements `json.Unmarshaler`
-	type Unmarshaler interface {
-		UnmarshalJSON([]byte) error
-	}
-*/
-func (dt *ArrayString) UnmarshalJSON(b []byte) error {
-	var s string
-	if err := json.Unmarshal(b, &s); err != nil {
-		panic(BadRequestError("must be a valid string"))
-	}
-	if s == "" {
-		panic(BadRequestError("must not be empty"))
-	}
+	// NullDateTime
+	response = makeTestRequest(http.MethodPost, "/null-date-time", map[string]interface{}{
+		"time_at":     "2020-01-01T02:02:05+07:00",
+		"archived_at": nil,
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"time_at":"2020-01-01T02:02:05+07:00","archived_at":null}
 
-	*dt = dt.parse(s)
-	return nil
-}
+	// ICS
+	response = makeTestRequest(http.MethodPost, "/calendar-event", map[string]interface{}{
+		"uid":      "standup-1@example.com",
+		"title":    "Standup, daily",
+		"start_at": "2020-01-01T09:00:00+07:00",
+		"length":   "15m",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] BEGIN:VCALENDAR ... DTSTART:20200101T020000Z ... SUMMARY:Standup\, daily ... DURATION:PT15M ... END:VCALENDAR
 
-type RequestContentDateTime struct {
-	TimeAt DateTime `json:"time_at"`
-}
+	// NullString, NullInt64, NullBool, NullFloat64
+	response = makeTestRequest(http.MethodPost, "/null-scalar", map[string]interface{}{
+		"nickname": "dy",
+		"age":      nil,
+		"verified": true,
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"nickname":"dy","age":null,"verified":true,"score":null}
 
-type RequestContentArrayString struct {
-	List ArrayString `json:"list"`
-}
+	response = makeTestRequest(http.MethodPost, "/null-scalar", map[string]interface{}{
+		"age": "twenty",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"null_int64.invalid","error":"must be a valid integer or null"}
 
-func getRouter() *gin.Engine {
-	routerOnce.Do(func() {
-		router = gin.New()
+	// vCard
+	response = makeTestRequest(http.MethodPost, "/contact", map[string]interface{}{
+		"name":  "David Yappeter",
+		"parts": []string{"Yappeter", "David", "", "", ""},
+		"email": []string{"david@example.com"},
+		"phone": "+6281234567890",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] BEGIN:VCARD ... FN:David Yappeter ... N:Yappeter;David;;; ... END:VCARD
 
-		// panic handler
-		router.Use(func(ctx *gin.Context) {
-			defer func() {
-				if r := recover(); r != nil {
-					switch v := r.(type) {
-					case BadRequestError:
-						ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-							"error": v,
-						})
-						return
-					case error:
-						fmt.Println("log error: ", v)
-					default:
-						ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-							"error": "internal server error",
-						})
-					}
-				}
-			}()
+	// Enum
+	response = makeTestRequest(http.MethodPost, "/enum", map[string]interface{}{
+		"status": "ACTIVE",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"status":"active"}
 
-			ctx.Next()
-		})
+	response = makeTestRequest(http.MethodPost, "/enum", map[string]interface{}{
+		"status": "archived",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"enum.invalid_value","error":"must be one of pending, active, closed"}
 
-		// simple routing
-		router.POST("/date-time", func(ctx *gin.Context) {
-			var request RequestContentDateTime
-			err := ctx.ShouldBind(&request)
-			if err != nil {
-				panic(err)
-			}
+	// ShortCode
+	response = makeTestRequest(http.MethodPost, "/short-code", map[string]interface{}{
+		"code": "abcd-1234-a",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"code":"ABCD1234A","qr_payload":"https://example.com/redeem?code=ABCD1234A"}
 
-			ctx.JSON(http.StatusOK, request)
-		})
+	response = makeTestRequest(http.MethodPost, "/short-code", map[string]interface{}{
+		"code": "ABCD1234B",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"short_code.check","error":"has an invalid check character"}
 
-		router.POST("/array-string", func(ctx *gin.Context) {
-			var request RequestContentArrayString
-			err := ctx.ShouldBind(&request)
-			if err != nil {
-				panic(err)
-			}
+	// ObfuscatedID
+	response = makeTestRequest(http.MethodPost, "/obfuscated-id", map[string]interface{}{
+		"id": "BEAsrn5Q",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"id":"BEAsrn5Q"}
 
-			ctx.JSON(http.StatusOK, request)
-		})
+	response = makeTestRequest(http.MethodPost, "/obfuscated-id", map[string]interface{}{
+		"id": "42",
 	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"obfuscated_id.invalid","error":"must be a valid ID"}
 
-	return router
-}
+	// Priority (generated by cmd/enumgen)
+	response = makeTestRequest(http.MethodPost, "/priority", map[string]interface{}{
+		"priority": "high",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"priority":"high"}
 
-func makeTestRequest(method string, url string, body map[string]interface{}) *httptest.ResponseRecorder {
-	jsoned, err := json.Marshal(body)
-	if err != nil {
-		panic(err)
-	}
+	response = makeTestRequest(http.MethodPost, "/priority", map[string]interface{}{
+		"priority": "asap",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"priority.invalid_value","error":"must be one of low, normal, high, urgent"}
 
-	request, err := http.NewRequest(method, url, bytes.NewBuffer(jsoned))
-	if err != nil {
+	// FlexibleBool
+	response = makeTestRequest(http.MethodPost, "/flexible-bool", map[string]interface{}{
+		"active":     "yes",
+		"newsletter": 0,
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"active":true,"newsletter":false}
+
+	response = makeTestRequest(http.MethodPost, "/flexible-bool", map[string]interface{}{
+		"active": "maybe",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"flexible_bool.invalid_value","error":"must be one of true, false, 1, 0, yes, no, on, off, y, n, t, f"}
+
+	// CompositeKey
+	response = makeTestRequest(http.MethodGet, "/records/acme:invoice:INV-42", nil)
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"id":"INV-42","key":"acme:invoice:INV-42","resource":"invoice","tenant":"acme"}
+
+	response = makeTestRequest(http.MethodGet, "/records/acme:INV-42", nil)
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"composite_key.invalid_format","error":"key format must be tenant:resource:id"}
+
+	// StringInt64
+	response = makeTestRequest(http.MethodPost, "/string-int64", map[string]interface{}{
+		"id":        "9007199254740993",
+		"parent_id": 42,
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"id":"9007199254740993","parent_id":"42"}
+
+	response = makeTestRequest(http.MethodPost, "/string-int64", map[string]interface{}{
+		"id": 1.5,
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"string_int64.fraction","error":"must be a whole number"}
+
+	response = makeTestRequest(http.MethodPost, "/string-int64", map[string]interface{}{
+		"id": "99999999999999999999",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"string_int64.out_of_range","error":"must be between -9223372036854775808 and 9223372036854775807"}
+
+	// ResourceName
+	response = makeTestRequest(http.MethodPost, "/resource-name", map[string]interface{}{
+		"name": "projects/acme/locations/asia-southeast2/jobs/nightly-export",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"job":"nightly-export","name":"projects/acme/locations/asia-southeast2/jobs/nightly-export","project":"acme"}
+
+	response = makeTestRequest(http.MethodPost, "/resource-name", map[string]interface{}{
+		"name": "projects/acme/jobs/nightly-export",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"resource_name.no_match","error":"must match one of projects/{project}/locations/{location}/jobs/{job}"}
+
+	// Password
+	response = makeTestRequest(http.MethodPost, "/password", map[string]interface{}{
+		"email":    "david@example.com",
+		"password": "correct horse 1",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"email":"david@example.com","password":null}
+
+	response = makeTestRequest(http.MethodPost, "/password", map[string]interface{}{
+		"email":    "david@example.com",
+		"password": "short",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"password.too_short","error":"must be at least 8 characters"}
+
+	// FieldMapping
+	response = makeTestRequest(http.MethodPost, "/field-mapping", map[string]interface{}{
+		"mapping": []map[string]interface{}{
+			{"source": "First Name", "target": "given_name", "transform": []string{"trim"}},
+			{"source": "Age", "target": "age", "transform": []string{"to_number"}},
+		},
+		"sample": map[string]interface{}{
+			"First Name": "  David ",
+			"Age":        "27",
+		},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"mapped":{"age":27,"given_name":"David"}}
+
+	response = makeTestRequest(http.MethodPost, "/field-mapping", map[string]interface{}{
+		"mapping": []map[string]interface{}{
+			{"source": "Age", "target": "age"},
+		},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"field_mapping.target_kind","error":"mapping 0: produces string but target field \"age\" is number"}
+
+	// MaskedString
+	response = makeTestRequest(http.MethodPost, "/masked-string", map[string]interface{}{
+		"national_id": "3171234567890001",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"national_id":"************0001"}
+
+	// CellRange
+	response = makeTestRequest(http.MethodPost, "/cell-range", map[string]interface{}{
+		"range": "'Q1 Report'!b2:d10",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"cells":27,"range":"'Q1 Report'!B2:D10","sheet":"Q1 Report"}
+
+	response = makeTestRequest(http.MethodPost, "/cell-range", map[string]interface{}{
+		"range": "C10:A1",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"cell_range.reversed","error":"range end must not be above or left of its start"}
+
+	// CreditCardNumber
+	response = makeTestRequest(http.MethodPost, "/credit-card", map[string]interface{}{
+		"card_number": "4111 1111 1111 1111",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"brand":"visa","card_number":"****-****-****-1111"}
+
+	response = makeTestRequest(http.MethodPost, "/credit-card", map[string]interface{}{
+		"card_number": "4111-1111-1111-1112",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"credit_card.checksum","error":"must be a valid card number"}
+
+	// BitString
+	response = makeTestRequest(http.MethodPost, "/bit-string", map[string]interface{}{
+		"capabilities": "10110010",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"capabilities":"0xb2","supports_wifi":true}
+
+	response = makeTestRequest(http.MethodPost, "/bit-string", map[string]interface{}{
+		"capabilities": "10112",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"bit_string.invalid_format","error":"must only contain 0 and 1, or be 0x-prefixed hex"}
+
+	// CountryCode, CurrencyCode, LanguageTag
+	response = makeTestRequest(http.MethodPost, "/iso-code", map[string]interface{}{
+		"country":  "id",
+		"currency": "idr",
+		"language": "en-us",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"country":"ID","country_name":"Indonesia","currency":"IDR","currency_exponent":2,"language":"en-US","language_name":"American English"}
+
+	response = makeTestRequest(http.MethodPost, "/iso-code", map[string]interface{}{
+		"country":  "UK",
+		"currency": "GBP",
+		"language": "en-GB",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"country_code.unknown","error":"unknown country code UK"}
+
+	// Date (ordinal and week forms are opt-in)
+	DateParseOptions.AllowOrdinal = true
+	DateParseOptions.AllowWeek = true
+	response = makeTestRequest(http.MethodPost, "/date", map[string]interface{}{
+		"ship_on": "2024-045",
+		"deliver": "2024-W05-3",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"ship_on":"2024-02-14","deliver":"2024-01-31"}
+
+	response = makeTestRequest(http.MethodPost, "/date", map[string]interface{}{
+		"ship_on": "2023-W53-1",
+		"deliver": "2024-01-31",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"date.week","error":"week must be between 01 and 52"}
+
+	// Timezone
+	response = makeTestRequest(http.MethodPost, "/timezone", map[string]interface{}{
+		"time_at":  "2020-01-01T02:02:05Z",
+		"timezone": "Asia/Jakarta",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"time_at":"2020-01-01T09:02:05+07:00","timezone":"Asia/Jakarta"}
+
+	response = makeTestRequest(http.MethodPost, "/timezone", map[string]interface{}{
+		"time_at":  "2020-01-01T02:02:05Z",
+		"timezone": "Asia/Bandung",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"timezone.unknown","error":"unknown time zone Asia/Bandung, must be an IANA name like Asia/Jakarta"}
+
+	// GeoPoint
+	response = makeTestRequest(http.MethodPost, "/geo-point", map[string]interface{}{
+		"from": map[string]interface{}{"lat": -6.2, "lng": 106.8},
+		"to":   "-6.9175,107.6191",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"distance_km":121,"from":{"lat":-6.2,"lng":106.8},"to":{"lat":-6.9175,"lng":107.6191}}
+
+	response = makeTestRequest(http.MethodPost, "/geo-point", map[string]interface{}{
+		"from": "-96.2,106.8",
+		"to":   "-6.9175,107.6191",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"geo_point.lat_range","error":"lat must be between -90 and 90"}
+
+	// DST-safe scheduling: 09:00 in New York stays 09:00 across the 2024-03-10 change
+	response = makeTestRequest(http.MethodPost, "/next-run", map[string]interface{}{
+		"time_at":  "2024-03-09T09:00:00-05:00",
+		"timezone": "America/New_York",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"next_run":"2024-03-10T09:00:00-04:00"}
+
+	response = makeTestRequest(http.MethodPost, "/next-run", map[string]interface{}{
+		"time_at":  "2024-03-09T02:30:00-05:00",
+		"timezone": "America/New_York",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"next_run":"2024-03-10T03:30:00-04:00"}
+
+	// Semver
+	response = makeTestRequest(http.MethodPost, "/semver", map[string]interface{}{
+		"client_version": "v2.1.0-rc.1+build.5",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"client_version":"2.1.0-rc.1+build.5","pre_release":"rc.1","supported":true}
+
+	response = makeTestRequest(http.MethodPost, "/semver", map[string]interface{}{
+		"client_version": "2.1",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"semver.invalid_format","error":"must be a semantic version like 1.2.3"}
+
+	// Elapsed
+	response = makeTestRequest(http.MethodPost, "/elapsed", map[string]interface{}{
+		"list": "a,b,c",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"count":3,"took_ms":0.004} (took_ms varies per run)
+
+	// HexColor
+	response = makeTestRequest(http.MethodPost, "/theme", map[string]interface{}{
+		"primary":    "#1E90FF",
+		"accent":     "#F0A",
+		"background": "#00000080",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"accent":"#ff00aa","background":"#00000080","background_alpha":128,"primary":"#1e90ff"}
+
+	response = makeTestRequest(http.MethodPost, "/theme", map[string]interface{}{
+		"primary":    "#1E90FG",
+		"accent":     "#F0A",
+		"background": "#000",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"hex_color.not_hex","error":"must only contain hex digits"}
+
+	// Response meta
+	response = makeTestRequest(http.MethodGet, "/orders?page=2&per_page=2", nil)
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"data":["order-3","order-4"],"meta":{"request_id":"9f2c4e1a7b3d5c60","took_ms":0.011,"pagination":{"page":2,"per_page":2,"total":5,"total_pages":3},"deprecation":["page-number pagination is deprecated, use cursor"]}}
+
+	response = makeTestRequest(http.MethodGet, "/orders?per_page=500", nil)
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"pagination.per_page","error":"per_page must be between 1 and 100"}
+
+	// Base64Bytes
+	response = makeTestRequest(http.MethodPost, "/attachment", map[string]interface{}{
+		"file_name": "hello.txt",
+		"content":   "aGVsbG8_Pz8",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"content":"aGVsbG8/Pz8=","file_name":"hello.txt","size":8}
+
+	response = makeTestRequest(http.MethodPost, "/attachment", map[string]interface{}{
+		"file_name": "hello.txt",
+		"content":   "not base64!",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"base64.invalid_format","error":"must be valid base64"}
+
+	// Link header
+	response = makeTestRequest(http.MethodGet, "/orders?page=2&per_page=2", nil)
+	fmt.Printf("%+v\n", response.Header().Get("Link")) // </orders?page=1&per_page=2>; rel="first", </orders?page=1&per_page=2>; rel="prev", </orders?page=3&per_page=2>; rel="next", </orders?page=3&per_page=2>; rel="last"
+
+	response = makeTestRequest(http.MethodGet, "/order-feed?limit=2&cursor="+NewCursor(orderFeedPosition{After: 2}).String(), nil)
+	fmt.Printf("%+v\n", response.Header().Get("Link")) // </order-feed?limit=2>; rel="first", </order-feed?cursor=eyJhZnRlciI6MH0&limit=2>; rel="prev", </order-feed?cursor=eyJhZnRlciI6NH0&limit=2>; rel="next"
+
+	response = makeTestRequest(http.MethodGet, "/order-feed?cursor=bm9wZQ", nil)
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"cursor.invalid","error":"cursor must be a cursor returned by a previous response"}
+
+	// ByteSize
+	ByteSizeValidate = ByteSizeBetween(1<<10, 2<<30)
+	response = makeTestRequest(http.MethodPost, "/upload-limits", map[string]interface{}{
+		"max_file_size":    "1.5GiB",
+		"max_request_size": "10mb",
+		"chunk_size":       65536,
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"chunk_size":"64KiB","max_file_size":"1.5GiB","max_file_size_bytes":1610612736,"max_request_size":"10MB"}
+
+	response = makeTestRequest(http.MethodPost, "/upload-limits", map[string]interface{}{
+		"max_file_size":    "5TB",
+		"max_request_size": "10MB",
+		"chunk_size":       "64KiB",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"byte_size.out_of_range","error":"must be between 1KiB and 2GiB"}
+
+	// Links
+	response = makeTestRequest(http.MethodGet, "/orders/order%203", nil)
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"id":"order 3","links":[{"href":"/orders/order%203","rel":"self","method":"GET"},{"href":"/orders/order%203/cancel","rel":"cancel","method":"POST"},{"href":"/orders","rel":"collection","method":"GET"}]}
+
+	// Percentage
+	response = makeTestRequest(http.MethodPost, "/discount", map[string]interface{}{
+		"subtotal_cents": 1999,
+		"discount":       "12.5%",
+		"tax_rate":       11,
+		"interchange":    "175bps",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"discount":12.5,"discount_cents":250,"interchange":175,"interchange_percent":"1.75%","tax_cents":192,"tax_rate":11}
+
+	response = makeTestRequest(http.MethodPost, "/discount", map[string]interface{}{
+		"subtotal_cents": 1999,
+		"discount":       "120%",
+		"tax_rate":       11,
+		"interchange":    175,
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"percentage.out_of_range","error":"must be between 0% and 100%"}
+
+	// Error catalog (also: go run . -error-catalog > errors.json)
+	response = makeTestRequest(http.MethodGet, "/_errors", nil)
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"errors":[{"code":"array.empty","status":400,"message":"must not be empty","example":"must not be empty"},...]}
+
+	// CronExpression
+	response = makeTestRequest(http.MethodPost, "/schedule", map[string]interface{}{
+		"cron": "*/30 9-10 * * MON-FRI",
+		"from": "2024-03-08T10:15:00+07:00",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"cron":"*/30 9-10 * * MON-FRI","next_runs":["2024-03-08T10:30:00+07:00","2024-03-11T09:00:00+07:00","2024-03-11T09:30:00+07:00"]}
+
+	response = makeTestRequest(http.MethodPost, "/schedule", map[string]interface{}{
+		"cron": "0 24 * * *",
+		"from": "2024-03-08T10:15:00+07:00",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"cron.out_of_range","error":"hour field \"24\": value 24 is out of range 0-23"}
+
+	// Legacy panic telemetry: BadRequestError panics are counted per call
+	// site, returned errors (like /order-feed?limit=0) are not. Every type
+	// now returns its error, so no site is left.
+	response = makeTestRequest(http.MethodGet, "/order-feed?limit=0", nil)
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"error":"limit must be a positive integer"}
+
+	response = makeTestRequest(http.MethodGet, "/_legacy-panics", nil)
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"sites":[]}
+
+	// RegexPattern
+	response = makeTestRequest(http.MethodPost, "/regex-pattern", map[string]interface{}{
+		"pattern": `^INV-\d{4}$`,
+		"samples": "INV-0042,INV-42,inv-0042",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"matches":["INV-0042"],"pattern":"^INV-\\d{4}$"}
+
+	response = makeTestRequest(http.MethodPost, "/regex-pattern", map[string]interface{}{
+		"pattern": `(INV-\d{4}`,
+		"samples": "INV-0042",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"regex_pattern.invalid","error":"error parsing regexp: missing closing ): `(INV-\\d{4}`"}
+
+	// TrimmedString / NormalizedString
+	response = makeTestRequest(http.MethodPost, "/normalized-string", map[string]interface{}{
+		"code": "  SKU-001\n",
+		"name": " Jose\u0301 \t  Di\u0301az ", // decomposed accents
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"code":"SKU-001","matches_existing":true,"name":"José Díaz"}
+
+	// Catalog (hot reload)
+	response = makeTestRequest(http.MethodPost, "/checkout-currency", map[string]interface{}{
+		"currency": "EUR",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"enum.invalid_value","error":"must be one of IDR, USD"}
+
+	// e.g. go supportedCurrencies.Watch(ctx, FileCatalogProvider[[]string]{Path: "currencies.yaml"}, time.Minute, nil)
+	if err := supportedCurrencies.Reload(CatalogProviderFunc[[]string](func() ([]string, error) {
+		return []string{"IDR", "USD", "EUR"}, nil
+	})); err != nil {
 		panic(err)
 	}
-	request.Header.Add("Content-Type", "application/json")
+	response = makeTestRequest(http.MethodPost, "/checkout-currency", map[string]interface{}{
+		"currency": "EUR",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"currency":"EUR"}
+
+	// NonEmptyString / BoundedString
+	response = makeTestRequest(http.MethodPost, "/sign-up", map[string]interface{}{
+		"username":     "dévì",
+		"display_name": "Devi",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"display_name":"Devi","username":"dévì"}
+
+	response = makeTestRequest(http.MethodPost, "/sign-up", map[string]interface{}{
+		"username":     "dv",
+		"display_name": "Devi",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"bounded_string.length","error":"must be between 3 and 20 characters"}
+
+	response = makeTestRequest(http.MethodPost, "/sign-up", map[string]interface{}{
+		"username":     "devi",
+		"display_name": "   ",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"non_empty_string.empty","error":"must not be empty"}
+
+	// Degradation policy
+	response = makeTestRequest(http.MethodPost, "/delivery", map[string]interface{}{
+		"delivery_date": "2024-08-17",
+		"city":          "Jakarta",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"error":"2024-08-17 is a public holiday"}
+
+	deliveryServicesDown = true
+	response = makeTestRequest(http.MethodPost, "/delivery", map[string]interface{}{
+		"delivery_date": "2024-08-17",
+		"city":          "Jakarta",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"error":"2024-08-17 is a public holiday"} (stale holidays)
+
+	response = makeTestRequest(http.MethodPost, "/delivery", map[string]interface{}{
+		"delivery_date": "2024-08-19",
+		"city":          "Jakarta",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [503] {"error":"courier coverage is temporarily unavailable, try again later"}
+	deliveryServicesDown = false
+
+	// Slug
+	response = makeTestRequest(http.MethodPost, "/articles", map[string]interface{}{
+		"title": "  Crème Brûlée: Straße & Smørrebrød (2024 Edition)!",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"slug":"creme-brulee-strasse-smorrebrod-2024-edition","title":"  Crème Brûlée: Straße \u0026 Smørrebrød (2024 Edition)!"}
+
+	response = makeTestRequest(http.MethodPost, "/articles", map[string]interface{}{
+		"title": "Custom",
+		"slug":  "Custom--Slug",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"slug.invalid_format","error":"must only contain lowercase letters, digits and single dashes between them"}
+
+	// JSONRaw
+	response = makeTestRequest(http.MethodPost, "/webhooks", map[string]interface{}{
+		"event":   "order.paid",
+		"payload": map[string]interface{}{"order_id": 42, "items": []interface{}{map[string]interface{}{"sku": "A-1", "qty": 2}}},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"event":"order.paid","payload":{"items":[{"qty":2,"sku":"A-1"}],"order_id":42}}
+
+	response = makeTestRequest(http.MethodPost, "/webhooks", map[string]interface{}{
+		"event":   "order.paid",
+		"payload": json.RawMessage(strings.Repeat("[", 40) + strings.Repeat("]", 40)),
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"json_raw.too_deep","error":"must not be nested more than 32 levels deep"}
+
+	// DelimitedMap
+	response = makeTestRequest(http.MethodPost, "/labels", map[string]interface{}{
+		"labels": map[string]interface{}{"team": "payments", "env": "prod"},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"keys":["env","team"],"labels":{"env":"prod","team":"payments"}}
+
+	response = makeTestRequest(http.MethodPost, "/labels", map[string]interface{}{
+		"labels": "team:payments, env:prod, tier:1",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"keys":["team","env","tier"],"labels":{"team":"payments","env":"prod","tier":"1"}}
+
+	response = makeTestRequest(http.MethodPost, "/labels", map[string]interface{}{
+		"labels": "team:payments,team:search",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"delimited_map.repeated_key","error":"key \"team\" must not be repeated"}
+
+	response = makeTestRequest(http.MethodGet, "/labels/search?labels=env:prod,team", nil)
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"delimited_map.pair","error":"labels \"team\" must be a key and value separated by \":\""}
+
+	// DateRange and TimeRange
+	DateRangeMaxDays = 30
+	response = makeTestRequest(http.MethodPost, "/bookings", map[string]interface{}{
+		"stay": map[string]interface{}{"from": "2024-03-05", "to": "2024-03-08"},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"days":4,"stay":{"from":"2024-03-05","to":"2024-03-08"}}
+
+	response = makeTestRequest(http.MethodPost, "/bookings", map[string]interface{}{
+		"stay": map[string]interface{}{"from": "2024-03-08", "to": "2024-03-11"},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [409] {"error":"stay overlaps an existing booking 2024-03-10/2024-03-12"}
+
+	response = makeTestRequest(http.MethodPost, "/bookings", map[string]interface{}{
+		"stay": map[string]interface{}{"from": "2024-03-08", "to": "2024-03-05"},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"date_range.reversed","error":"to must not be before from"}
+
+	response = makeTestRequest(http.MethodPost, "/bookings", map[string]interface{}{
+		"stay": map[string]interface{}{"from": "2024-03-01", "to": "2024-04-30"},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"date_range.too_long","error":"must not span more than 30 days"}
+
+	response = makeTestRequest(http.MethodPost, "/room-reservations", map[string]interface{}{
+		"slot": map[string]interface{}{"from": "2024-03-05T11:00:00+07:00", "to": "2024-03-05T12:30:00+07:00"},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"minutes":90,"slot":{"from":"2024-03-05T11:00:00+07:00","to":"2024-03-05T12:30:00+07:00"}}
+
+	response = makeTestRequest(http.MethodPost, "/room-reservations", map[string]interface{}{
+		"slot": map[string]interface{}{"from": "2024-03-05T10:30:00+07:00", "to": "2024-03-05T11:30:00+07:00"},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [409] {"error":"slot overlaps an existing reservation 2024-03-05T10:00:00+07:00/2024-03-05T11:00:00+07:00"}
+
+	response = makeTestRequest(http.MethodPost, "/room-reservations", map[string]interface{}{
+		"slot": map[string]interface{}{"from": "2024-03-05T10:30:00+07:00"},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"range.empty","error":"from and to must not be empty"}
+
+	// IntRange
+	IntRangeValidate = IntRangeWithin(0, 1000000)
+	response = makeTestRequest(http.MethodPost, "/products/search", map[string]interface{}{
+		"price": "100-500",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"price":{"min":100,"max":500},"products":["mug","lamp"]}
+
+	response = makeTestRequest(http.MethodPost, "/products/search", map[string]interface{}{
+		"price": map[string]interface{}{"min": 1000, "max": 5000},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"price":{"min":1000,"max":5000},"products":["chair"]}
+
+	response = makeTestRequest(http.MethodPost, "/products/search", map[string]interface{}{
+		"price": "500-100",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"int_range.reversed","error":"min must not be greater than max"}
+
+	response = makeTestRequest(http.MethodPost, "/products/search", map[string]interface{}{
+		"price": "-10-20",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"int_range.outside","error":"must be within 0-1000000"}
+
+	response = makeTestRequest(http.MethodGet, "/products?rows=2-99", nil)
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"products":["lamp","chair","desk"],"rows":{"min":2,"max":4}}
+
+	// Weekday and Month
+	response = makeTestRequest(http.MethodPost, "/opening-hours", map[string]interface{}{
+		"closed_on":     []interface{}{"Sun", "1", 6},
+		"holiday_month": "AUG",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"closed_on":["sunday","monday","saturday"],"holiday_month":"august","weekend_closed":true}
+
+	response = makeTestRequest(http.MethodPost, "/opening-hours", map[string]interface{}{
+		"closed_on":     []interface{}{"funday"},
+		"holiday_month": "aug",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"weekday.invalid_value","error":"must be one of monday, tuesday, wednesday, thursday, friday, saturday, sunday"}
+
+	response = makeTestRequest(http.MethodPost, "/opening-hours", map[string]interface{}{
+		"closed_on":     []interface{}{"sunday"},
+		"holiday_month": 13,
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"month.invalid_value","error":"must be one of january, february, march, april, may, june, july, august, september, october, november, december"}
+
+	// Recurrence
+	response = makeTestRequest(http.MethodPost, "/events/occurrences", map[string]interface{}{
+		"rrule":   "rrule:freq=weekly;byday=mo,we;interval=1",
+		"start":   "2024-03-04T09:30:00+07:00",
+		"between": map[string]interface{}{"from": "2024-03-06T00:00:00+07:00", "to": "2024-03-14T00:00:00+07:00"},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"occurrences":["2024-03-06T09:30:00+07:00","2024-03-11T09:30:00+07:00","2024-03-13T09:30:00+07:00"],"rrule":"FREQ=WEEKLY;BYDAY=MO,WE"}
+
+	response = makeTestRequest(http.MethodPost, "/events/occurrences", map[string]interface{}{
+		"rrule":   "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+		"start":   "2024-01-01T17:00:00+07:00",
+		"between": map[string]interface{}{"from": "2024-01-01T00:00:00+07:00", "to": "2025-01-01T00:00:00+07:00"},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"occurrences":["2024-01-26T17:00:00+07:00","2024-02-23T17:00:00+07:00","2024-03-29T17:00:00+07:00"],"rrule":"FREQ=MONTHLY;COUNT=3;BYDAY=-1FR"}
+
+	response = makeTestRequest(http.MethodPost, "/events/occurrences", map[string]interface{}{
+		"rrule":   "FREQ=WEEKLY;BYDAY=MO;BYSETPOS=1",
+		"start":   "2024-01-01T17:00:00+07:00",
+		"between": map[string]interface{}{"from": "2024-01-01T00:00:00+07:00", "to": "2025-01-01T00:00:00+07:00"},
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"recurrence.unsupported","error":"BYSETPOS is not supported"}
+
+	// HTTPDate
+	response = makeTestRequest(http.MethodGet, "/reports/monthly", nil)
+	fmt.Printf("%+v\n", response.Header().Get("Last-Modified")) // Tue, 05 Mar 2024 03:00:00 GMT
+
+	response = makeTestRequestWithHeaders(http.MethodGet, "/reports/monthly", nil, map[string]string{
+		"If-Modified-Since": "Tuesday, 05-Mar-24 03:00:00 GMT",
+	})
+	fmt.Printf("%+v\n", response.Code) // 304
+
+	response = makeTestRequestWithHeaders(http.MethodGet, "/reports/monthly", nil, map[string]string{
+		"If-Modified-Since": "Mon Mar  4 03:00:00 2024",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"last_modified":"Tue, 05 Mar 2024 03:00:00 GMT","report":"monthly"}
+
+	response = makeTestRequestWithHeaders(http.MethodGet, "/reports/monthly", nil, map[string]string{
+		"If-Modified-Since": "yesterday",
+	})
+	fmt.Printf("%+v\n", response.Code) // 200
+
+	// DateTime precision
+	response = makeTestRequest(http.MethodPost, "/audit-events", map[string]interface{}{
+		"occurred_at": "2020-01-01T02:02:05.123456+07:00",
+		"received_at": "2020-01-01T02:02:05.123456+07:00",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"occurred_at":"2020-01-01T02:02:05.123+07:00","received_at":"2020-01-01T02:02:05+07:00"}
+
+	response = makeTestRequest(http.MethodPost, "/audit-events", map[string]interface{}{
+		"occurred_at": "2020-01-01T02:02:05+07:00",
+		"received_at": "2020-01-01T02:02:05+07:00",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"occurred_at":"2020-01-01T02:02:05.000+07:00","received_at":"2020-01-01T02:02:05+07:00"}
+
+	// DateTime UTC output
+	DateTimeOutputUTC = true
+	response = makeTestRequest(http.MethodPost, "/date-time", map[string]interface{}{
+		"time_at": "2020-01-01T02:02:05+07:00",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"time_at":"2019-12-31T19:02:05Z"}
+	DateTimeOutputUTC = false
+
+	// DateTime default location
+	response = makeTestRequest(http.MethodPost, "/date-time", map[string]interface{}{
+		"time_at": "2020-01-01 02:02:05",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"datetime.invalid_format","error":"format must be YYYY-MM-DDTHH:mm:ssZ"}
+
+	DateTimeDefaultLocation, _ = time.LoadLocation("Asia/Tokyo")
+	response = makeTestRequest(http.MethodPost, "/date-time", map[string]interface{}{
+		"time_at": "2020-01-01 02:02:05",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"time_at":"2020-01-01T02:02:05+09:00"}
+
+	response = makeTestRequest(http.MethodPost, "/date-time", map[string]interface{}{
+		"time_at": "2020-01-01T02:02:05+07:00",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"time_at":"2020-01-01T02:02:05+07:00"}
+	DateTimeDefaultLocation = nil
+
+	// DateTime comparison and arithmetic
+	response = makeTestRequest(http.MethodPost, "/date-time/day", map[string]interface{}{
+		"time_at": "2020-01-01T02:02:05+07:00",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"end_of_day":"2020-01-01T23:59:59+07:00","in_first_hour":false,"start_of_day":"2020-01-01T00:00:00+07:00","until_end_of_day":"21h57m54.999999999s"}
+
+	// NewDateTime, MustParseDateTime and Time
+	fmt.Printf("%+v\n", MustParseDateTime("2020-01-01T02:02:05+07:00").Time().Unix()) // 1577818925
+	fmt.Printf("%+v\n", NewDateTime(time.Unix(1577818925, 0).UTC()))                  // 2019-12-31T19:02:05Z
+
+	// DateTime constraints
+	DateTimeNow = func() time.Time { return time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC) }
+	response = makeTestRequest(http.MethodPost, "/appointments", map[string]interface{}{
+		"starts_at": "2024-03-15T09:00:00+07:00",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"starts_at":"2024-03-15T09:00:00+07:00"}
+
+	response = makeTestRequest(http.MethodPost, "/appointments", map[string]interface{}{
+		"starts_at": "2024-02-28T09:00:00+07:00",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"datetime.not_future","error":"must be in the future"}
+
+	response = makeTestRequest(http.MethodPost, "/appointments", map[string]interface{}{
+		"starts_at": "2024-07-01T09:00:00+07:00",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"datetime.not_within_next","error":"must be within the next 90 days"}
+	DateTimeNow = time.Now
+
+	// ctype struct tags
+	response = makeTestRequest(http.MethodPost, "/shipments", map[string]interface{}{
+		"day":   "2020-01-01",
+		"items": "apple; banana ;cherry",
+		"note":  "leave at the door",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"day":"2020-01-01","items":["apple","banana","cherry"],"note":"leave at the door","starts":"2020-01-01T00:00:00+07:00"}
+
+	response = makeTestRequest(http.MethodPost, "/shipments", map[string]interface{}{
+		"day":   "2020-01-01T02:02:05+07:00",
+		"items": "apple",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"request.invalid_fields","error":"request has invalid fields","field_codes":{"day":"datetime.invalid_format"},"fields":{"day":"format must be 2006-01-02"}}
+
+	response = makeTestRequest(http.MethodPost, "/shipments", map[string]interface{}{
+		"day":   "tomorrow",
+		"items": "",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"request.invalid_fields","error":"request has invalid fields","field_codes":{"day":"datetime.invalid_format","items":"array.empty"},"fields":{"day":"format must be 2006-01-02","items":"must not be empty"}}
+
+	// JSONBinding
+	response = makeTestRequest(http.MethodPost, "/profiles", map[string]interface{}{
+		"handle":   "my-profile",
+		"birthday": "1990-02-14",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"birthday":"1990-02-14","handle":"my-profile"}
+
+	response = makeTestRequest(http.MethodPost, "/profiles", map[string]interface{}{
+		"handle":   "My Profile!",
+		"birthday": "1990-02-30",
+		"tags":     42,
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"request.invalid_fields","error":"request has invalid fields","field_codes":{"birthday":"date.invalid_format","handle":"slug.invalid_format","tags":"array.not_string"},"fields":{"birthday":"format must be YYYY-MM-DD, YYYY-DDD, YYYY-Www-D","handle":"must only contain lowercase letters, digits and single dashes between them","tags":"must be a valid string"}}
+
+	// BindQuery and BindURI
+	response = makeTestRequest(http.MethodGet, "/orders/search?from=2020-01-01T02:02:05%2B07:00&tags=a,b&status=ACTIVE&page=2", nil)
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"from":"2020-01-01T02:02:05+07:00","page":2,"status":"active","tags":["a","b"]}
+
+	response = makeTestRequest(http.MethodGet, "/orders/search?from=yesterday&status=lost&page=1", nil)
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"request.invalid_fields","error":"request has invalid fields","field_codes":{"from":"datetime.invalid_format","status":"enum.invalid_value"},"fields":{"from":"format must be YYYY-MM-DDTHH:mm:ssZ","status":"must be one of pending, active, closed"}}
+
+	// EncodeQuery
+	query, queryErr := EncodeQuery(RequestContentOrderSearch{
+		From:   NewDateTime(time.Date(2020, time.January, 1, 2, 2, 5, 0, time.FixedZone("", 7*3600))),
+		Tags:   ArrayString{"a", "b"},
+		Status: MustParseEnum[orderStatus]("active"),
+		Page:   2,
+	})
+	fmt.Printf("%+v %+v\n", query.Encode(), queryErr) // from=2020-01-01T02%3A02%3A05%2B07%3A00&page=2&status=active&tags=a%2Cb <nil>
+	response = makeTestRequest(http.MethodGet, "/orders/search?"+query.Encode(), nil)
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"from":"2020-01-01T02:02:05+07:00","page":2,"status":"active","tags":["a","b"]}
+
+	response = makeTestRequest(http.MethodGet, "/profiles/my-profile", nil)
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"handle":"my-profile"}
+
+	response = makeTestRequest(http.MethodGet, "/profiles/My_Profile", nil)
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"request.invalid_fields","error":"request has invalid fields","field_codes":{"handle":"slug.invalid_format"},"fields":{"handle":"must only contain lowercase letters, digits and single dashes between them"}}
+
+	// Header helpers
+	response = makeTestRequestWithHeaders(http.MethodGet, "/request-info", nil, map[string]string{
+		"X-Request-Start": "2020-01-01T02:02:05+07:00",
+		"Accept-Language": "da, en-GB;q=0.8, fr;q=0, en;q=0.9, *;q=0.1",
+		"X-Tags":          "red, green",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"languages":["da","en","en-GB"],"request_start":"2020-01-01T02:02:05+07:00","tags":["red","green"]}
+
+	response = makeTestRequestWithHeaders(http.MethodGet, "/request-info", nil, map[string]string{
+		"Accept-Language": "en;q=high",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"header.quality","error":"Accept-Language header q must be a number between 0 and 1"}
+
+	response = makeTestRequestWithHeaders(http.MethodGet, "/request-info", nil, map[string]string{
+		"X-Request-Start": "1577818925",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"datetime.invalid_format","error":"X-Request-Start header format must be YYYY-MM-DDTHH:mm:ssZ"}
+
+	// Problem Details
+	response = makeTestRequest(http.MethodPost, "/problems/profiles", map[string]interface{}{
+		"handle":   "My Profile!",
+		"birthday": "1990-02-14",
+	})
+	fmt.Printf("%+v\n", response.Header().Get("Content-Type")) // application/problem+json
+	fmt.Printf("%+v\n", response.Body.String())                // [400] {"type":"about:blank","title":"Bad Request","status":400,"detail":"request has invalid fields","instance":"/problems/profiles","code":"request.invalid_fields","errors":[{"field":"handle","message":"must only contain lowercase letters, digits and single dashes between them","code":"slug.invalid_format"}]}
+
+	// Translated messages
+	response = makeTestRequestWithHeaders(http.MethodPost, "/date-time", map[string]interface{}{
+		"time_at": "",
+	}, map[string]string{"Accept-Language": "id-ID, en;q=0.5"})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"datetime.empty","error":"tidak boleh kosong"}
+
+	response = makeTestRequestWithHeaders(http.MethodPost, "/shipments", map[string]interface{}{
+		"day":   "tomorrow",
+		"items": "apple",
+	}, map[string]string{"Accept-Language": "id"})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"request.invalid_fields","error":"permintaan memiliki field yang tidak valid","field_codes":{"day":"datetime.invalid_format"},"fields":{"day":"format harus 2006-01-02"}}
+
+	RegisterTranslations(language.German, map[string]string{
+		"datetime.empty": "darf nicht leer sein",
+	})
+	response = makeTestRequestWithHeaders(http.MethodPost, "/date-time", map[string]interface{}{
+		"time_at": "",
+	}, map[string]string{"Accept-Language": "de-AT"})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"datetime.empty","error":"darf nicht leer sein"}
+
+	// Message templates
+	MessageTemplates.Swap(map[string]string{
+		"datetime.invalid_format": "{value} is not a {layout} time",
+	})
+	response = makeTestRequest(http.MethodPost, "/date-time", map[string]interface{}{
+		"time_at": "01/01/2020 02:02",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"datetime.invalid_format","error":"01/01/2020 02:02 is not a YYYY-MM-DDTHH:mm:ssZ time"}
+	MessageTemplates.Swap(map[string]string{})
+
+	// Sentinel errors
+	_, parseErr := ParseDateTime("yesterday")
+	fmt.Printf("%+v\n", errors.Is(parseErr, ErrInvalidFormat)) // true
+
+	bindErr := JSONBinding.BindBody([]byte(`{"handle": "", "birthday": "1990-02-14", "tags": 42}`), &RequestContentProfile{})
+	fmt.Printf("%+v %+v %+v\n", errors.Is(bindErr, ErrEmptyValue), errors.Is(bindErr, ErrNotAString), errors.Is(bindErr, ErrInvalidFormat)) // true true false
+
+	// Translated encoding/json errors
+	response = makeTestRequest(http.MethodPost, "/discount", map[string]interface{}{
+		"subtotal_cents": "10000",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"request.invalid_fields","error":"request has invalid fields","field_codes":{"subtotal_cents":"value.wrong_type"},"fields":{"subtotal_cents":"must be an integer"}}
+
+	response = makeTestRequest(http.MethodPost, "/shipments", map[string]interface{}{
+		"day":  "2020-01-01",
+		"note": false,
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"request.invalid_fields","error":"request has invalid fields","field_codes":{"note":"string.not_string"},"fields":{"note":"must be a valid string"}}
+
+	// Validatable
+	response = makeTestRequest(http.MethodPost, "/meetings", map[string]interface{}{
+		"starts_at": "2020-01-01T10:00:00+07:00",
+		"tags":      "planning,q1",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"starts_at":"2020-01-01T10:00:00+07:00","tags":"planning,q1"}
+
+	response = makeTestRequest(http.MethodPost, "/meetings", map[string]interface{}{
+		"starts_at": "2020-01-01T20:00:00+07:00",
+		"tags":      "a,b,c,d,e,f",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"request.invalid_fields","error":"request has invalid fields","fields":{"starts_at":"must be within business hours","tags":"must not have more than 5 items"}}
+
+	// Validator tags on custom types
+	response = makeTestRequest(http.MethodPost, "/reservations", map[string]interface{}{
+		"starts_at": "2020-01-01T10:00:00+07:00",
+		"length":    "30m",
+		"guests":    "ana,budi",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"guests":"ana,budi","length":"30m","starts_at":"2020-01-01T10:00:00+07:00"}
+
+	response = makeTestRequest(http.MethodPost, "/reservations", map[string]interface{}{
+		"length": "3h",
+		"guests": "a,b,c,d,e",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"request.invalid_fields","error":"request has invalid fields","field_codes":{"guests":"value.rule_failed","length":"value.rule_failed","starts_at":"value.empty"},"fields":{"guests":"must satisfy max=4","length":"must satisfy lte=2h","starts_at":"must not be empty"}}
+
+	// IsZero and OmitZero
+	event := ResponseContentEvent{Name: "standup", StartsAt: MustParseDateTime("2020-01-01T09:00:00+07:00")}
+	jsoned, _ := json.Marshal(event)
+	fmt.Printf("%+v\n", string(jsoned)) // {"name":"standup","starts_at":"2020-01-01T09:00:00+07:00","ends_at":"0001-01-01T00:00:00Z","length":"0s","location":null,"cancelled_at":null}
+	jsoned, _ = json.Marshal(OmitZero(event))
+	fmt.Printf("%+v\n", string(jsoned)) // {"name":"standup","starts_at":"2020-01-01T09:00:00+07:00"}
+
+	// XML
+	response = makeTestRequestWithBody(http.MethodPost, "/partner/orders", "application/xml",
+		`<order id="42"><placed_at>2020-01-01T02:02:05+07:00</placed_at><tags>gift,express</tags><size>1.5MiB</size></order>`)
+	fmt.Printf("%+v\n", response.Body.String()) // [200] <order id="42"><placed_at>2020-01-01T02:02:05+07:00</placed_at><tags>gift,express</tags><size>1.5MiB</size></order>
+
+	response = makeTestRequestWithBody(http.MethodPost, "/partner/orders", "application/xml",
+		`<order id="42"><placed_at>yesterday</placed_at></order>`)
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"datetime.invalid_format","error":"format must be YYYY-MM-DDTHH:mm:ssZ"}
+
+	// YAML
+	var serviceConfig ServiceConfig
+	yamlErr := yaml.Unmarshal([]byte("timeout: 1h30m\nmax_upload: 10MB\nsince: 2020-01-01T02:02:05+07:00\nregions: [id, sg]\n"), &serviceConfig)
+	fmt.Printf("%+v %+v %+v %+v %+v\n", serviceConfig.Timeout, serviceConfig.MaxUpload.Bytes(), serviceConfig.Since, serviceConfig.Regions.List(), yamlErr) // 1h30m 10000000 2020-01-01T02:02:05+07:00 [id sg] <nil>
+	yamled, _ := yaml.Marshal(serviceConfig)
+	fmt.Printf("%q\n", string(yamled)) // "timeout: 1h30m\nmax_upload: 10MB\nsince: \"2020-01-01T02:02:05+07:00\"\nregions: id,sg\n"
+	yamlErr = yaml.Unmarshal([]byte("timeout: soon\n"), &serviceConfig)
+	fmt.Printf("%+v\n", yamlErr) // format must be a duration like 1h30m or 90s
+
+	// TOML
+	var tomlConfig ServiceConfig
+	tomlErr := toml.Unmarshal([]byte("timeout = \"90s\"\nmax_upload = 1048576\nsince = 2020-01-01T02:02:05+07:00\nregions = \"id,sg\"\n"), &tomlConfig)
+	fmt.Printf("%+v %+v %+v %+v %+v\n", tomlConfig.Timeout, tomlConfig.MaxUpload, tomlConfig.Since, tomlConfig.Regions.List(), tomlErr) // 1m30s 1MiB 2020-01-01T02:02:05+07:00 [id sg] <nil>
+	tomled, _ := toml.Marshal(tomlConfig)
+	fmt.Printf("%q\n", string(tomled)) // "timeout = '1m30s'\nmax_upload = '1MiB'\nsince = '2020-01-01T02:02:05+07:00'\nregions = 'id,sg'\n"
+	tomlErr = toml.Unmarshal([]byte("since = 2020-01-01\n"), &tomlConfig)
+	fmt.Printf("%+v\n", tomlErr) // toml: format must be YYYY-MM-DDTHH:mm:ssZ
+
+	// MessagePack
+	packed, _ := msgpack.Marshal(InternalEvent{
+		At:       MustParseDateTime("2020-01-01T02:02:05Z"),
+		Tags:     ArrayString{"gift", "express"},
+		Size:     1536,
+		Discount: 12*Percent + 50*BasisPoint,
+	})
+	fmt.Printf("%+v\n", len(packed)) // 51
+	var unpacked InternalEvent
+	packErr := msgpack.Unmarshal(packed, &unpacked)
+	fmt.Printf("%+v %+v %+v %+v %+v\n", unpacked.At, unpacked.Tags.List(), unpacked.Size, unpacked.Discount, packErr) // 2020-01-01T02:02:05Z [gift express] 1.5KiB 12.5% <nil>
+
+	// CBOR
+	reading := SensorReading{At: MustParseDateTime("2020-01-01T02:02:05+07:00"), Tags: ArrayString{"indoor"}}
+	_ = reading.Interval.UnmarshalText([]byte("30s"))
+	cbored, _ := cbor.Marshal(reading)
+	fmt.Printf("%x\n", cbored) // a3626174c07819323032302d30312d30315430323a30323a30352b30373a303064746167738166696e646f6f7268696e74657276616c63333073
+	DateTimeCBORTag = CBORTimeEpoch
+	cbored, _ = cbor.Marshal(reading)
+	DateTimeCBORTag = CBORTimeString
+	fmt.Printf("%x\n", cbored) // a3626174c11a5e0b9b2d64746167738166696e646f6f7268696e74657276616c63333073
+	var decoded SensorReading
+	cborErr := cbor.Unmarshal(cbored, &decoded)
+	fmt.Printf("%+v %+v %+v %+v\n", decoded.At, decoded.Tags.List(), decoded.Interval, cborErr) // 2019-12-31T19:02:05Z [indoor] 30s <nil>
+
+	// BSON
+	stored, _ := bson.Marshal(StoredShipment{ShippedAt: MustParseDateTime("2020-01-01T02:02:05+07:00"), Tags: ArrayString{"gift", "express"}})
+	fmt.Printf("%+v\n", bson.Raw(stored)) // {"shipped_at": {"$date":{"$numberLong":"1577818925000"}},"tags": ["gift","express"]}
+	var loaded StoredShipment
+	bsonErr := bson.Unmarshal(stored, &loaded)
+	fmt.Printf("%+v %+v %+v\n", loaded.ShippedAt, loaded.Tags.List(), bsonErr) // 2019-12-31T19:02:05Z [gift express] <nil>
+
+	// gob
+	var cache bytes.Buffer
+	gobErr := gob.NewEncoder(&cache).Encode(CachedBooking{
+		At:   MustParseDateTime("2020-01-01T02:02:05+07:00").WithPrecision(DateTimeMillis),
+		Day:  NewDate(2020, time.January, 1),
+		Tags: ArrayString{"gift"},
+	})
+	var cached CachedBooking
+	if gobErr == nil {
+		gobErr = gob.NewDecoder(&cache).Decode(&cached)
+	}
+	fmt.Printf("%+v %+v %+v %+v %+v\n", cached.At, cached.Day, cached.Tags.List(), cached.Zone.IsZero(), gobErr) // 2020-01-01T02:02:05.000+07:00 2020-01-01 [gift] true <nil>
+
+	// flag.Value
+	reportFlags := flag.NewFlagSet("report", flag.ContinueOnError)
+	reportFlags.SetOutput(io.Discard)
+	var since DateTime
+	var window Duration
+	var reportTags ArrayString
+	var maxSize ByteSize
+	reportFlags.Var(&since, "since", "report start")
+	reportFlags.Var(&window, "window", "report window")
+	reportFlags.Var(&reportTags, "tags", "comma separated tags")
+	reportFlags.Var(&maxSize, "max-size", "largest export")
+	flagErr := reportFlags.Parse([]string{"--since=2020-01-01T00:00:00Z", "--window=24h", "--tags=a,b,c", "--max-size=10MiB"})
+	fmt.Printf("%+v %+v %+v %+v %+v\n", since, window, reportTags.List(), maxSize.Bytes(), flagErr) // 2020-01-01T00:00:00Z 24h [a b c] 10485760 <nil>
+	flagErr = reportFlags.Parse([]string{"--since=yesterday"})
+	fmt.Printf("%+v\n", flagErr) // invalid value "yesterday" for flag -since: format must be YYYY-MM-DDTHH:mm:ssZ
+
+	// Environment variables
+	var envConfig EnvConfig
+	envErr := env.Parse(&envConfig, env.Options{Environment: map[string]string{
+		"CACHE_TTL":       "1h30m",
+		"ALLOWED_ORIGINS": "a.com,b.com",
+		"TZ":              "Asia/Jakarta",
+		"MAX_BODY":        "8MiB",
+	}})
+	fmt.Printf("%+v %+v %+v %+v %+v\n", envConfig.CacheTTL, envConfig.AllowedOrigins.List(), envConfig.Zone, envConfig.MaxBody.Bytes(), envErr) // 1h30m [a.com b.com] Asia/Jakarta 8388608 <nil>
+	envErr = env.Parse(&envConfig, env.Options{Environment: map[string]string{"CACHE_TTL": "soon"}})
+	fmt.Printf("%+v\n", envErr) // env: parse error on field "CacheTTL" of type "main.Duration": format must be a duration like 1h30m or 90s
+
+	// GORM
+	var gormLog sqlLog
+	gormDB, gormErr := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               &gormLog,
+	})
+	if gormErr == nil {
+		gormErr = gormDB.Migrator().CreateTable(&ShipmentRecord{})
+	}
+	fmt.Printf("%+v %+v\n", strings.Join(gormLog, ";"), gormErr) // CREATE TABLE "shipment_records" ("id" bigserial,"shipped_at" timestamptz,"delivered_at" timestamptz,"tags" text[],"transit" bigint,"discount" decimal(19,4),"origin" inet,"zone" text,"spot" jsonb,PRIMARY KEY ("id")) <nil>
+	spot, _ := NewGeoPoint(-6.2, 106.8)
+	insert := gormDB.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return tx.Create(&ShipmentRecord{
+			ShippedAt: MustParseDateTime("2020-01-01T02:02:05+07:00"),
+			Tags:      ArrayString{"gift", "two words"},
+			Discount:  12*Percent + 50*BasisPoint,
+			Spot:      spot,
+		})
+	})
+	fmt.Printf("%+v\n", insert) // INSERT INTO "shipment_records" ("shipped_at","delivered_at","tags","transit","discount","origin","zone","spot") VALUES ('2020-01-01 02:02:05',NULL,'{"gift","two words"}',0,'12.5',NULL,NULL,'{"lat":-6.2,"lng":106.8}') RETURNING "id"
+	var record ShipmentRecord
+	scanErr := record.Tags.Scan(`{gift,"two words"}`)
+	if scanErr == nil {
+		scanErr = record.Discount.Scan("12.5000")
+	}
+	if scanErr == nil {
+		scanErr = record.Origin.Scan([]byte("10.0.0.1"))
+	}
+	fmt.Printf("%q %+v %+v %+v\n", record.Tags.List(), record.Discount, record.Origin, scanErr) // ["gift" "two words"] 12.5% 10.0.0.1 <nil>
+	scanErr = record.Origin.Scan("localhost")
+	fmt.Printf("%+v\n", scanErr) // cannot scan "localhost" into IPAddress: must be a valid IP address
+
+	// pgx
+	pgTypes := pgtype.NewMap()
+	RegisterPgxTypes(pgTypes)
+	pgArray, pgErr := pgTypes.Encode(pgtype.TextArrayOID, pgtype.TextFormatCode, ArrayString{"gift", "a,b"}, nil)
+	fmt.Printf("%s %+v\n", pgArray, pgErr) // {gift,"a,b"} <nil>
+	pgArray, _ = pgTypes.Encode(pgtype.TextArrayOID, pgtype.BinaryFormatCode, ArrayString{"gift", "a,b"}, nil)
+	var pgTags ArrayString
+	pgErr = pgTypes.Scan(pgtype.TextArrayOID, pgtype.BinaryFormatCode, pgArray, &pgTags)
+	fmt.Printf("%q %+v\n", pgTags.List(), pgErr) // ["gift" "a,b"] <nil>
+	stay, _ := NewDateRange(NewDate(2024, time.March, 1), NewDate(2024, time.March, 3))
+	pgRange, _ := pgTypes.Encode(pgtype.DaterangeOID, pgtype.TextFormatCode, stay, nil)
+	fmt.Printf("%s\n", pgRange) // [2024-03-01,2024-03-04)
+	pgRange, _ = pgTypes.Encode(pgtype.DaterangeOID, pgtype.BinaryFormatCode, stay, nil)
+	var pgStay DateRange
+	pgErr = pgTypes.Scan(pgtype.DaterangeOID, pgtype.BinaryFormatCode, pgRange, &pgStay)
+	fmt.Printf("%+v %+v %+v\n", pgStay.From(), pgStay.To(), pgErr) // 2024-03-01 2024-03-03 <nil>
+	pgErr = pgTypes.Scan(pgtype.DaterangeOID, pgtype.TextFormatCode, []byte("[2024-03-01,)"), &pgStay)
+	fmt.Printf("%+v\n", pgErr) // cannot scan an empty or unbounded range into DateRange
+
+	// GraphQL scalars
+	var gqlOut bytes.Buffer
+	MustParseDateTime("2020-01-01T02:02:05+07:00").MarshalGQL(&gqlOut)
+	gqlOut.WriteByte(' ')
+	ArrayString{"gift", "express"}.MarshalGQL(&gqlOut)
+	gqlOut.WriteByte(' ')
+	(12*Percent + 50*BasisPoint).MarshalGQL(&gqlOut)
+	fmt.Printf("%+v\n", gqlOut.String()) // "2020-01-01T02:02:05+07:00" "gift,express" 12.5
+	var gqlTags ArrayString
+	var gqlSize ByteSize
+	gqlErr := gqlTags.UnmarshalGQL([]interface{}{"gift", "express"})
+	if gqlErr == nil {
+		gqlErr = gqlSize.UnmarshalGQL(int64(1536))
+	}
+	fmt.Printf("%q %+v %+v\n", gqlTags.List(), gqlSize, gqlErr) // ["gift" "express"] 1.5KiB <nil>
+	var gqlAt DateTime
+	gqlErr = gqlAt.UnmarshalGQL("yesterday")
+	fmt.Printf("%+v\n", gqlErr) // format must be YYYY-MM-DDTHH:mm:ssZ
+
+	// Protobuf
+	placedAt := NewDateTime(time.Date(2020, time.January, 1, 2, 2, 5, 5e8, time.UTC)).WithPrecision(DateTimeMillis)
+	pbTime := placedAt.ToProto()
+	fmt.Printf("%+v %+v\n", pbTime.GetSeconds(), pbTime.GetNanos()) // 1577844125 500000000
+	fromProto := DateTime{}.WithPrecision(DateTimeMillis)
+	protoErr := fromProto.FromProto(pbTime)
+	fmt.Printf("%+v %+v\n", fromProto, protoErr) // 2020-01-01T02:02:05.500Z <nil>
+	var protoWindow Duration
+	protoErr = protoWindow.FromProto(durationpb.New(90 * time.Minute))
+	fmt.Printf("%+v %+v %+v\n", protoWindow, protoWindow.ToProto().AsDuration(), protoErr) // 1h30m 1h30m0s <nil>
+	protoErr = protoWindow.FromProto(&durationpb.Duration{Seconds: 300 * 365 * 24 * 3600})
+	fmt.Printf("%+v\n", protoErr) // cannot convert Duration: 9460800000s is out of range
+
+	// CSV
+	var report bytes.Buffer
+	csvErr := WriteCSV(&report, []ReportShipment{
+		{ID: 9007199254740993, ShipAt: NewDateTime(time.Date(2020, time.January, 1, 2, 2, 5, 0, time.FixedZone("", 7*3600))), Tags: ArrayString{"gift", "express"}, Weight: 1536, Customer: "Budi"},
+	})
+	fmt.Printf("%q %+v\n", report.String(), csvErr) // "id,ship_at,tags,weight,customer\n9007199254740993,2020-01-01T02:02:05+07:00,\"gift,express\",1.5KiB,Budi\n" <nil>
+	shipments, csvErr := ReadCSV[ReportShipment](strings.NewReader(report.String() + "1,yesterday,,2KB,Ani\n"))
+	fmt.Printf("%+v %+v\n", shipments[0].Tags, csvErr) // gift,express 3.ship_at: format must be YYYY-MM-DDTHH:mm:ssZ
+
+	// Redis
+	var schedule RequestContentSchedule
+	_ = json.Unmarshal([]byte(`{"cron":"0 9 * * MON","from":"2020-01-01T02:02:05+07:00"}`), &schedule)
+	stored, redisErr := RedisValue{V: schedule}.MarshalBinary()
+	fmt.Printf("%s %+v\n", stored, redisErr) // {"cron":"0 9 * * MON","from":"2020-01-01T02:02:05+07:00"} <nil>
+	var restored RequestContentSchedule
+	redisErr = RedisValue{V: &restored}.UnmarshalBinary(stored)
+	fmt.Printf("%+v %+v %+v\n", restored.Cron, restored.From, redisErr) // 0 9 * * MON 2020-01-01T02:02:05+07:00 <nil>
+	stored, _ = RedisValue{V: schedule, Codec: RedisMsgpack}.MarshalBinary()
+	redisErr = RedisValue{V: &restored, Codec: RedisMsgpack}.UnmarshalBinary(stored)
+	fmt.Printf("%d %+v %+v\n", len(stored), restored.From, redisErr) // 32 2019-12-31T19:02:05Z <nil>
+	redisErr = RedisValue{V: &restored}.UnmarshalBinary([]byte(`{"cron":"61 * * * *"}`))
+	fmt.Printf("%+v\n", redisErr) // minute field "61": value 61 is out of range 0-59
+
+	// json-iterator
+	RegisterJSONIter()
+	enginePayload := []byte(`{"cron":"0 9 * * MON","from":"2020-01-01"}`)
+	var stdSchedule, iterSchedule RequestContentSchedule
+	stdErr := json.Unmarshal(enginePayload, &stdSchedule)
+	fmt.Printf("%T %+v\n", stdErr, stdErr) // main.CodedError format must be YYYY-MM-DDTHH:mm:ssZ
+	iterErr := jsoniter.ConfigCompatibleWithStandardLibrary.Unmarshal(enginePayload, &iterSchedule)
+	fmt.Printf("%T %+v\n", iterErr, iterErr) // main.CodedError format must be YYYY-MM-DDTHH:mm:ssZ
+
+	// AppendJSON
+	encoded := []byte(`{"placed_at":`)
+	encoded = placedAt.AppendJSON(encoded)
+	encoded = append(encoded, `,"delivered_at":`...)
+	encoded = NullDateTime{}.AppendJSON(encoded)
+	encoded = append(encoded, '}')
+	fmt.Printf("%s\n", encoded) // {"placed_at":"2020-01-01T02:02:05.500Z","delivered_at":null}
+
+	// DateTimeLazyParsing
+	DateTimeLazyParsing = true
+	var passedAt DateTime
+	lazyErr := json.Unmarshal([]byte(`"2020-01-01T02:02:05+07:00"`), &passedAt)
+	passedOut, _ := json.Marshal(passedAt)
+	fmt.Printf("%s %+v\n", passedOut, lazyErr) // "2020-01-01T02:02:05+07:00" <nil>
+	fmt.Printf("%+v\n", passedAt.Time().UTC()) // 2019-12-31 19:02:05 +0000 UTC
+	lazyErr = json.Unmarshal([]byte(`"2020-02-30T02:02:05+07:00"`), &passedAt)
+	fmt.Printf("%+v\n", lazyErr) // format must be YYYY-MM-DDTHH:mm:ssZ
+	DateTimeLazyParsing = false
+
+	// DecodeStream
+	response = makeTestRequestWithBody(http.MethodPost, "/shipments/import", "application/json", `[
+		{"id":"1","ship_at":"2020-01-01T02:02:05+07:00","tags":"gift,express","weight":"1.5KiB","customer":"Budi"},
+		{"id":"2","ship_at":"yesterday","weight":"2KB"},
+		{"id":"3","ship_at":"2020-01-02T09:00:00+07:00","customer":"Ani"},
+		{"id":4,"customer":"Sari"}
+	]`)
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"request.invalid_fields","error":"request has invalid fields","field_codes":{"1.ship_at":"datetime.invalid_format","3.ship_at":"value.empty"},"fields":{"1.ship_at":"format must be YYYY-MM-DDTHH:mm:ssZ","3.ship_at":"must not be empty"}}
+	response = makeTestRequestWithBody(http.MethodPost, "/shipments/import", "application/json", `{"id":"1"}`)
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"request.invalid_fields","error":"request has invalid fields","field_codes":{"body":"value.wrong_type"},"fields":{"body":"must be an array"}}
+
+	// LoadConfig (run before serving, shown last here because it changes the
+	// defaults every demo above relies on)
+	if err := LoadConfig("config.example.yaml"); err != nil {
+		panic(err)
+	}
+	response = makeTestRequest(http.MethodPost, "/date-time", map[string]interface{}{
+		"time_at": "2020-01-01 02:02:05+07:00",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"time_at":"2020-01-01T02:02:05+07:00"}
+
+	response = makeTestRequest(http.MethodPost, "/array-string", map[string]interface{}{
+		"list": "",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [400] {"code":"array.empty","error":"wajib diisi"}
+
+	response = makeTestRequest(http.MethodPost, "/iso-code", map[string]interface{}{
+		"country":  "de",
+		"currency": "eur",
+		"language": "en-gb",
+	})
+	fmt.Printf("%+v\n", response.Body.String()) // [200] {"country":"DE","country_name":"Jerman","currency":"EUR","currency_exponent":2,"language":"en-GB","language_name":"Inggris (Inggris)"}
+}
+
+var (
+	router     *gin.Engine
+	routerOnce sync.Once
+	routeLinks *LinkBuilder
+)
+
+// BadRequestError is a client error answered with 400 and its message.
+// Its kind, the sentinel errors.Is matches it against, is set when it is
+// built, see NewBadRequestError.
+type BadRequestError struct {
+	message string
+	kind    error
+}
+
+// NewBadRequestError builds a BadRequestError that matches kind
+// (ErrEmptyValue, ErrNotAString, ErrInvalidFormat, ...) with errors.Is, or
+// no sentinel when kind is nil.
+func NewBadRequestError(kind error, message string) BadRequestError {
+	return BadRequestError{message: message, kind: kind}
+}
+
+// badRequestFrom passes err on as a BadRequestError, keeping the sentinel
+// it matches.
+func badRequestFrom(err error) BadRequestError {
+	return NewBadRequestError(sentinelOf(err), err.Error())
+}
+
+// Error lets handlers and types return a BadRequestError (or pass it to
+// ctx.Error) instead of panicking with it; the middleware answers 400 either
+// way.
+func (e BadRequestError) Error() string {
+	return e.message
+}
+
+// DateTime is an instant sent as text in DateTimeLayouts, e.g.
+// "2020-01-01T02:02:05+07:00". Build one with NewDateTime or ParseDateTime;
+// Time returns the underlying time.Time.
+type DateTime struct {
+	time      time.Time
+	precision DateTimePrecision
+	// location overrides DateTimeDefaultLocation, see WithDefaultLocation.
+	location *time.Location
+	// constraints are checked on parse, see WithConstraints.
+	constraints *[]DateTimeConstraint
+	// layout replaces DateTimeLayouts for this value, see Bind.
+	layout string
+	// lazy is the text of a value decoded with DateTimeLazyParsing, parsed
+	// into time on first use, see date_time_lazy.go.
+	lazy *lazyDateTime
+}
+
+// RFC3339     = "2006-01-02T15:04:05Z07:00" unless DateTimeLayouts says
+// otherwise, with fractional seconds added for a precision finer than
+// seconds
+func (dt DateTime) format() string {
+	if dt.layout != "" {
+		return dt.precision.layout(dt.layout)
+	}
+	return dt.precision.layout(DateTimeLayouts[0])
+}
+
+/*
+	This receiver function overwrite `fmt.Stringer` which use to print the output
+	type Stringer interface {
+		String() string
+	}
+*/
+func (dt DateTime) String() string {
+	return string(dt.appendFormat(make([]byte, 0, dateTimeBufferSize)))
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (dt DateTime) MarshalJSON() ([]byte, error) {
+	return dt.AppendJSON(make([]byte, 0, dateTimeBufferSize)), nil
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (dt *DateTime) UnmarshalJSON(b []byte) error {
+	if DateTimeLazyParsing && dt.unmarshalLazy(b) {
+		return nil
+	}
+	var s string
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return ErrCodeDateTimeNotString.Err(map[string]string{"value": string(b)})
+	}
+	if s == "" {
+		return ErrCodeDateTimeEmpty.Err(nil)
+	}
+	// Keeps a precision, location or constraints set on the field before
+	// binding.
+	parsed, err := parseDateTime(s, *dt)
+	if err != nil {
+		if code, _ := errorCodeOf(err); code != "" {
+			return err
+		}
+		return badRequestFrom(err)
+	}
+
+	*dt = parsed
+
+	return nil
+}
+
+/*
+	This part implements `encoding.TextMarshaler`
+	type TextMarshaler interface {
+		MarshalText() (text []byte, err error)
+	}
+*/
+func (dt DateTime) MarshalText() ([]byte, error) {
+	return dt.AppendText(make([]byte, 0, dateTimeBufferSize))
+}
+
+/*
+	This part implements `encoding.TextUnmarshaler`
+	type TextUnmarshaler interface {
+		UnmarshalText(text []byte) error
+	}
+*/
+func (dt *DateTime) UnmarshalText(b []byte) error {
+	return unmarshalTextAsJSON(dt, b)
+}
+
+/*
+	This part implements `yaml.Marshaler`
+	type Marshaler interface {
+		MarshalYAML() (interface{}, error)
+	}
+*/
+func (dt DateTime) MarshalYAML() (interface{}, error) {
+	return dt.String(), nil
+}
+
+/*
+	This part implements `yaml.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalYAML(value *yaml.Node) error
+	}
+*/
+func (dt *DateTime) UnmarshalYAML(value *yaml.Node) error {
+	return unmarshalYAMLAsJSON(dt, value)
+}
+
+/*
+	This part implements BurntSushi/toml's `toml.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalTOML(interface{}) error
+	}
+*/
+func (dt *DateTime) UnmarshalTOML(value interface{}) error {
+	t, ok := value.(time.Time)
+	if !ok {
+		return unmarshalValueAsJSON(dt, value)
+	}
+	// A local date or time has no offset, so it is checked as the text it
+	// was written as, the way go-toml hands it over.
+	if layout, local := tomlLocalLayouts[t.Location().String()]; local {
+		return unmarshalValueAsJSON(dt, t.Format(layout))
+	}
+	return dt.setTime(t)
+}
+
+// setTime is parseDateTime for formats that carry an instant instead of
+// text: t is truncated to dt's precision and checked against its
+// constraints.
+func (dt *DateTime) setTime(t time.Time) error {
+	parsed := dt.withTime(t.Truncate(dt.precision.unit()))
+	if err := parsed.checkConstraints(); err != nil {
+		return err
+	}
+	*dt = parsed
+	return nil
+}
+
+// instantLocation is where setTime's callers put an instant that came
+// without an offset: the field's default location, DateTimeDefaultLocation
+// or UTC.
+func (dt DateTime) instantLocation() *time.Location {
+	if dt.location != nil {
+		return dt.location
+	}
+	if DateTimeDefaultLocation != nil {
+		return DateTimeDefaultLocation
+	}
+	return time.UTC
+}
+
+func NewDateTime(t time.Time) DateTime {
+	return DateTime{time: t}
+}
+
+// Time returns the instant; for a value decoded with DateTimeLazyParsing
+// that is when its text is parsed, once for all copies.
+func (dt DateTime) Time() time.Time {
+	if dt.lazy != nil {
+		return dt.lazy.instant()
+	}
+	return dt.time
+}
+
+// ParseDateTime accepts any of DateTimeLayouts, or only the first one when
+// StrictParsing is set. When DateTimeDefaultLocation is set it also accepts
+// DateTimeLocalLayouts, read as wall-clock time in that location.
+// Fractional seconds beyond DefaultDateTimePrecision are truncated, or
+// rejected when StrictParsing is set.
+func ParseDateTime(s string) (DateTime, error) {
+	return parseDateTime(s, DateTime{})
+}
+
+func MustParseDateTime(s string) DateTime {
+	dt, err := ParseDateTime(s)
+	if err != nil {
+		panic(err)
+	}
+	return dt
+}
+
+// parseDateTime parses with the precision, location, constraints and
+// layout of preset.
+func parseDateTime(s string, preset DateTime) (DateTime, error) {
+	layouts := DateTimeLayouts
+	if StrictParsing {
+		layouts = layouts[:1]
+	}
+	loc := preset.location
+	if loc == nil {
+		loc = DateTimeDefaultLocation
+	}
+	if preset.layout != "" {
+		layouts = []string{preset.layout}
+	} else if loc != nil {
+		layouts = append(layouts[:len(layouts):len(layouts)], DateTimeLocalLayouts...)
+	}
+	if loc == nil {
+		// Same as time.Parse.
+		loc = time.UTC
+	}
+
+	precision := preset.precision
+	for _, layout := range layouts {
+		t, err := time.ParseInLocation(layout, s, loc)
+		if err != nil {
+			continue
+		}
+		truncated := t.Truncate(precision.unit())
+		if StrictParsing && !truncated.Equal(t) {
+			return DateTime{}, ErrCodeDateTimeTooPrecise.Err(map[string]string{"precision": precision.String(), "value": s})
+		}
+		parsed := preset.withTime(truncated)
+		if err := parsed.checkConstraints(); err != nil {
+			return DateTime{}, err
+		}
+		return parsed, nil
+	}
+	if preset.layout != "" {
+		return DateTime{}, ErrCodeDateTimeInvalidFormat.Err(map[string]string{"layout": preset.layout, "value": s})
+	}
+	return DateTime{}, ErrCodeDateTimeInvalidFormat.Err(map[string]string{"layout": "YYYY-MM-DDTHH:mm:ssZ", "value": s})
+}
+
+// WithDefaultLocation returns dt set to read offset-less input in loc
+// instead of DateTimeDefaultLocation. Like WithPrecision, set it on a
+// request field before binding.
+func (dt DateTime) WithDefaultLocation(loc *time.Location) DateTime {
+	dt.location = loc
+	return dt
+}
+
+type ArrayString []string
+
+func (dt ArrayString) separator() string {
+	return ArrayStringSeparator
+}
+
+func (dt ArrayString) parse(s string) []string {
+	return strings.Split(s, dt.separator())
+}
+
+func (dt ArrayString) String() string {
+	return strings.Join(dt, dt.separator())
+}
+
+func (dt ArrayString) List() []string {
+	return dt
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (dt ArrayString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dt.String())
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (dt *ArrayString) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeArrayNotString.Err(map[string]string{"value": string(b)})
+	}
+	if s == "" {
+		return ErrCodeArrayEmpty.Err(nil)
+	}
+
+	*dt = dt.parse(s)
+	return nil
+}
+
+/*
+	This part implements `encoding.TextMarshaler`
+	type TextMarshaler interface {
+		MarshalText() (text []byte, err error)
+	}
+*/
+func (dt ArrayString) MarshalText() ([]byte, error) {
+	return []byte(dt.String()), nil
+}
+
+/*
+	This part implements `encoding.TextUnmarshaler`
+	type TextUnmarshaler interface {
+		UnmarshalText(text []byte) error
+	}
+*/
+func (dt *ArrayString) UnmarshalText(b []byte) error {
+	return unmarshalTextAsJSON(dt, b)
+}
+
+/*
+	This part implements `yaml.Marshaler`
+	type Marshaler interface {
+		MarshalYAML() (interface{}, error)
+	}
+*/
+func (dt ArrayString) MarshalYAML() (interface{}, error) {
+	return dt.String(), nil
+}
+
+/*
+	This part implements `yaml.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalYAML(value *yaml.Node) error
+	}
+*/
+func (dt *ArrayString) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.SequenceNode {
+		var list []string
+		if err := value.Decode(&list); err != nil {
+			return ErrCodeArrayNotString.Err(nil)
+		}
+		if len(list) == 0 {
+			return ErrCodeArrayEmpty.Err(nil)
+		}
+		*dt = list
+		return nil
+	}
+	return unmarshalYAMLAsJSON(dt, value)
+}
+
+/*
+	This part implements BurntSushi/toml's `toml.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalTOML(interface{}) error
+	}
+*/
+func (dt *ArrayString) UnmarshalTOML(value interface{}) error {
+	items, ok := value.([]interface{})
+	if !ok {
+		return unmarshalValueAsJSON(dt, value)
+	}
+	return dt.setItems(items)
+}
+
+// setItems sets dt from a decoded array, which must hold only strings.
+func (dt *ArrayString) setItems(items []interface{}) error {
+	if len(items) == 0 {
+		return ErrCodeArrayEmpty.Err(nil)
+	}
+	list := make(ArrayString, len(items))
+	for i, item := range items {
+		s, ok := item.(string)
+		if !ok {
+			return ErrCodeArrayNotString.Err(nil)
+		}
+		list[i] = s
+	}
+	*dt = list
+	return nil
+}
+
+type RequestContentDateTime struct {
+	TimeAt DateTime `json:"time_at"`
+}
+
+type RequestContentArrayString struct {
+	List ArrayString `json:"list"`
+}
+
+type RequestContentRetryPolicy struct {
+	Retry RetryPolicy `json:"retry"`
+}
+
+type RequestContentIPAddress struct {
+	Source IPv4Address `json:"source"`
+	Allow  CIDR        `json:"allow"`
+}
+
+type RequestContentBreakerConfig struct {
+	Breaker BreakerConfig `json:"breaker"`
+}
+
+type RequestContentDuration struct {
+	Timeout Duration `json:"timeout"`
+}
+
+type RequestContentHealthCheck struct {
+	Check HealthCheck `json:"check"`
+}
+
+type RequestContentPeriod struct {
+	Every Period `json:"every"`
+}
+
+type RequestContentChannel struct {
+	Notify []Channel `json:"notify"`
+}
+
+type RequestContentNullDateTime struct {
+	TimeAt     NullDateTime `json:"time_at"`
+	ArchivedAt NullDateTime `json:"archived_at"`
+}
+
+type RequestContentCalendarEvent struct {
+	UID     string   `json:"uid" ics:"uid"`
+	Title   string   `json:"title" ics:"summary"`
+	StartAt DateTime `json:"start_at" ics:"dtstart"`
+	Length  Duration `json:"length" ics:"duration"`
+}
+
+type RequestContentNullScalar struct {
+	Nickname NullString  `json:"nickname"`
+	Age      NullInt64   `json:"age"`
+	Verified NullBool    `json:"verified"`
+	Score    NullFloat64 `json:"score"`
+}
+
+type RequestContentContact struct {
+	Name    string   `json:"name" vcard:"fn"`
+	Parts   []string `json:"parts" vcard:"n"`
+	Email   []string `json:"email" vcard:"email"`
+	Phone   string   `json:"phone" vcard:"tel"`
+	Address []string `json:"address" vcard:"adr"`
+}
+
+type orderStatus struct{}
+
+func (orderStatus) Values() []string {
+	return []string{"pending", "active", "closed"}
+}
+
+func (orderStatus) CaseInsensitive() bool {
+	return true
+}
+
+type OrderStatus = Enum[orderStatus]
+
+// Data-driven enum: the allowed values live in a catalog that is reloaded
+// at runtime instead of being compiled in.
+var supportedCurrencies = NewCatalog("supported_currencies", []string{"IDR", "USD"})
+
+type supportedCurrency struct{}
+
+func (supportedCurrency) Values() []string {
+	return supportedCurrencies.Get()
+}
+
+type SupportedCurrency = Enum[supportedCurrency]
+
+type usernameBounds struct{}
+
+func (usernameBounds) Bounds() (min int, max int) {
+	return 3, 20
+}
+
+type Username = BoundedString[usernameBounds]
+
+type RequestContentEnum struct {
+	Status OrderStatus `json:"status"`
+}
+
+type RequestContentShortCode struct {
+	Code ShortCode `json:"code"`
+}
+
+type RequestContentObfuscatedID struct {
+	ID ObfuscatedID `json:"id"`
+}
+
+//go:generate go run ./cmd/enumgen -yaml=priority.yaml
+
+type RequestContentPriority struct {
+	Priority Priority `json:"priority"`
+}
+
+type RequestContentFlexibleBool struct {
+	Active     FlexibleBool `json:"active"`
+	Newsletter FlexibleBool `json:"newsletter"`
+}
+
+type RequestContentStringInt64 struct {
+	ID       StringInt64 `json:"id"`
+	ParentID StringInt64 `json:"parent_id"`
+}
+
+var jobResourcePattern = RegisterResourcePattern("projects/{project}/locations/{location}/jobs/{job}")
+
+type RequestContentResourceName struct {
+	Name ResourceName `json:"name"`
+}
+
+type RequestContentPassword struct {
+	Email    string   `json:"email"`
+	Password Password `json:"password"`
+}
+
+var (
+	importSourceSchema = FieldSchema{"First Name": FieldKindString, "Age": FieldKindString, "Subscribed": FieldKindString}
+	importTargetSchema = FieldSchema{"given_name": FieldKindString, "age": FieldKindNumber, "subscribed": FieldKindBool}
+)
+
+type RequestContentFieldMapping struct {
+	Mapping FieldMapping           `json:"mapping"`
+	Sample  map[string]interface{} `json:"sample"`
+}
+
+type RequestContentMaskedString struct {
+	NationalID MaskedString `json:"national_id"`
+}
+
+type RequestContentCellRange struct {
+	Range CellRange `json:"range"`
+}
+
+type RequestContentCreditCard struct {
+	CardNumber CreditCardNumber `json:"card_number"`
+}
+
+type RequestContentBitString struct {
+	Capabilities BitString `json:"capabilities"`
+}
+
+type RequestContentISOCode struct {
+	Country  CountryCode  `json:"country"`
+	Currency CurrencyCode `json:"currency"`
+	Language LanguageTag  `json:"language"`
+}
+
+type RequestContentDate struct {
+	ShipOn  Date `json:"ship_on"`
+	Deliver Date `json:"deliver"`
+}
+
+type RequestContentTimezone struct {
+	TimeAt   DateTime `json:"time_at"`
+	Timezone Timezone `json:"timezone"`
+}
+
+type RequestContentGeoPoint struct {
+	From GeoPoint `json:"from"`
+	To   GeoPoint `json:"to"`
+}
+
+var minimumClientVersion = MustParseSemver("2.0.0")
+
+type RequestContentSemver struct {
+	ClientVersion Semver `json:"client_version"`
+}
+
+type RequestContentTheme struct {
+	Primary    HexColor `json:"primary"`
+	Accent     HexColor `json:"accent"`
+	Background HexColor `json:"background"`
+}
+
+var sampleOrders = []string{"order-1", "order-2", "order-3", "order-4", "order-5"}
+
+type RequestContentAttachment struct {
+	FileName string      `json:"file_name"`
+	Content  Base64Bytes `json:"content"`
+}
+
+type orderFeedPosition struct {
+	After int `json:"after"`
+}
+
+type RequestContentUploadLimits struct {
+	MaxFileSize    ByteSize `json:"max_file_size"`
+	MaxRequestSize ByteSize `json:"max_request_size"`
+	ChunkSize      ByteSize `json:"chunk_size"`
+}
+
+type ResponseOrder struct {
+	ID    string `json:"id"`
+	Links Links  `json:"links"`
+}
+
+type RequestContentDiscount struct {
+	SubtotalCents int64       `json:"subtotal_cents"`
+	Discount      Percentage  `json:"discount"`
+	TaxRate       Percentage  `json:"tax_rate"`
+	Interchange   BasisPoints `json:"interchange"`
+}
+
+type RequestContentSchedule struct {
+	Cron CronExpression `json:"cron"`
+	From DateTime       `json:"from"`
+}
+
+type RequestContentRegexPattern struct {
+	Pattern RegexPattern `json:"pattern"`
+	Samples ArrayString  `json:"samples"`
+}
+
+type RequestContentNormalizedString struct {
+	Code TrimmedString    `json:"code"`
+	Name NormalizedString `json:"name"`
+}
+
+type RequestContentCheckoutCurrency struct {
+	Currency SupportedCurrency `json:"currency"`
+}
+
+type RequestContentSignUp struct {
+	Username    Username       `json:"username"`
+	DisplayName NonEmptyString `json:"display_name"`
+}
+
+// deliveryServicesDown simulates an outage of the services below.
+var deliveryServicesDown = false
+
+var (
+	deliveryHolidays = NewExternalData("holiday calendar", func() (map[string]bool, error) {
+		if deliveryServicesDown {
+			return nil, errors.New("holiday service: connection refused")
+		}
+		return map[string]bool{"2024-08-17": true, "2024-12-25": true}, nil
+	}, ExternalDataOptions{Policy: UseStale, MaxStale: 24 * time.Hour})
+
+	courierCoverage = NewExternalData("courier coverage", func() ([]string, error) {
+		if deliveryServicesDown {
+			return nil, errors.New("courier service: timeout")
+		}
+		return []string{"Jakarta", "Bandung"}, nil
+	}, ExternalDataOptions{Policy: FailClosed})
+)
+
+type RequestContentDelivery struct {
+	DeliveryDate Date   `json:"delivery_date"`
+	City         string `json:"city"`
+}
+
+type RequestContentArticle struct {
+	Title string `json:"title"`
+	Slug  Slug   `json:"slug"`
+}
+
+type RequestContentWebhook struct {
+	Event   string  `json:"event"`
+	Payload JSONRaw `json:"payload"`
+}
+
+type RequestContentLabels struct {
+	Labels DelimitedMap `json:"labels"`
+}
+
+var (
+	existingBookings = []DateRange{
+		{from: NewDate(2024, time.March, 10), to: NewDate(2024, time.March, 12)},
+	}
+	existingReservations = []TimeRange{{
+		from: NewDateTime(time.Date(2024, time.March, 5, 10, 0, 0, 0, time.FixedZone("", 7*60*60))),
+		to:   NewDateTime(time.Date(2024, time.March, 5, 11, 0, 0, 0, time.FixedZone("", 7*60*60))),
+	}}
+)
+
+type RequestContentBooking struct {
+	Stay DateRange `json:"stay"`
+}
+
+type RequestContentRoomReservation struct {
+	Slot TimeRange `json:"slot"`
+}
+
+type RequestContentProductSearch struct {
+	Price IntRange `json:"price"`
+}
+
+type sampleProduct struct {
+	Name  string
+	Price int64
+}
+
+var sampleProducts = []sampleProduct{
+	{"pen", 25}, {"mug", 150}, {"lamp", 450}, {"chair", 3200}, {"desk", 12000},
+}
+
+type RequestContentOpeningHours struct {
+	ClosedOn     []Weekday `json:"closed_on"`
+	HolidayMonth Month     `json:"holiday_month"`
+}
+
+type RequestContentOccurrences struct {
+	RRule   Recurrence `json:"rrule"`
+	Start   DateTime   `json:"start"`
+	Between TimeRange  `json:"between"`
+}
+
+type sampleReport struct {
+	Name         string
+	LastModified HTTPDate
+}
+
+var errReportNotFound = errors.New("report not found")
+
+var sampleReports = map[string]sampleReport{
+	"monthly": {Name: "monthly", LastModified: NewHTTPDate(time.Date(2024, time.March, 5, 10, 0, 0, 0, time.FixedZone("", 7*60*60)))},
+}
+
+type RequestContentAuditEvent struct {
+	OccurredAt DateTime `json:"occurred_at"`
+	ReceivedAt DateTime `json:"received_at"`
+}
+
+type RequestContentAppointment struct {
+	StartsAt DateTime `json:"starts_at"`
+}
+
+type RequestContentShipment struct {
+	Day   DateTime    `json:"day" ctype:"layout=2006-01-02,loc=Asia/Jakarta"`
+	Items ArrayString `json:"items" ctype:"sep=;,trim"`
+	Note  string      `json:"note"`
+}
+
+type RequestContentProfile struct {
+	Handle   Slug        `json:"handle"`
+	Birthday Date        `json:"birthday"`
+	Tags     ArrayString `json:"tags"`
+}
+
+type RequestContentOrderSearch struct {
+	From   DateTime    `form:"from"`
+	Tags   ArrayString `form:"tags"`
+	Status OrderStatus `form:"status"`
+	Page   int         `form:"page"`
+}
+
+type RequestContentProfileURI struct {
+	Handle Slug `uri:"handle"`
+}
+
+// MeetingStart is a DateTime in business hours, 09:00 to 17:00 in the
+// zone it was sent in.
+type MeetingStart struct {
+	DateTime
+}
+
+func (m MeetingStart) Validate() error {
+	if hour := m.Time().Hour(); hour < 9 || hour >= 17 {
+		return errors.New("must be within business hours")
+	}
+	return nil
+}
+
+// MeetingTags is an ArrayString of at most 5 items.
+type MeetingTags struct {
+	ArrayString
+}
+
+func (t MeetingTags) Validate() error {
+	if len(t.ArrayString) > 5 {
+		return errors.New("must not have more than 5 items")
+	}
+	return nil
+}
+
+type RequestContentMeeting struct {
+	StartsAt MeetingStart `json:"starts_at"`
+	Tags     MeetingTags  `json:"tags"`
+}
+
+type RequestContentReservation struct {
+	StartsAt DateTime    `json:"starts_at" binding:"required"`
+	Length   Duration    `json:"length" binding:"gte=15m,lte=2h"`
+	Guests   ArrayString `json:"guests" binding:"max=4"`
+}
+
+type ResponseContentEvent struct {
+	Name        string       `json:"name"`
+	StartsAt    DateTime     `json:"starts_at"`
+	EndsAt      DateTime     `json:"ends_at,omitempty"`
+	Length      Duration     `json:"length,omitempty"`
+	Location    NullString   `json:"location,omitempty"`
+	Tags        ArrayString  `json:"tags,omitempty"`
+	CancelledAt NullDateTime `json:"cancelled_at,omitempty"`
+}
+
+type RequestContentPartnerOrder struct {
+	XMLName  xml.Name    `xml:"order"`
+	ID       StringInt64 `xml:"id,attr"`
+	PlacedAt DateTime    `xml:"placed_at"`
+	Tags     ArrayString `xml:"tags"`
+	Size     ByteSize    `xml:"size"`
+}
+
+// ServiceConfig shows the types in a config file, see yaml.go and toml.go.
+type ServiceConfig struct {
+	Timeout   Duration    `yaml:"timeout" toml:"timeout"`
+	MaxUpload ByteSize    `yaml:"max_upload" toml:"max_upload"`
+	Since     DateTime    `yaml:"since" toml:"since"`
+	Regions   ArrayString `yaml:"regions" toml:"regions"`
+}
+
+// InternalEvent is a message between services, see msgpack.go.
+type InternalEvent struct {
+	At       DateTime    `msgpack:"at"`
+	Tags     ArrayString `msgpack:"tags"`
+	Size     ByteSize    `msgpack:"size"`
+	Discount Percentage  `msgpack:"discount"`
+}
+
+// SensorReading is an IoT payload, see cbor.go.
+type SensorReading struct {
+	At       DateTime    `cbor:"at"`
+	Tags     ArrayString `cbor:"tags"`
+	Interval Duration    `cbor:"interval"`
+}
+
+// StoredShipment is a MongoDB document, see bson.go.
+type StoredShipment struct {
+	ShippedAt DateTime    `bson:"shipped_at"`
+	Tags      ArrayString `bson:"tags"`
+}
+
+// CachedBooking is stored in a gob-based cache, see binary.go.
+type CachedBooking struct {
+	At   DateTime
+	Day  Date
+	Tags ArrayString
+	Zone Timezone
+}
+
+// EnvConfig is read from environment variables, see text.go.
+type EnvConfig struct {
+	CacheTTL       Duration    `env:"CACHE_TTL"`
+	AllowedOrigins ArrayString `env:"ALLOWED_ORIGINS"`
+	Zone           Timezone    `env:"TZ"`
+	MaxBody        ByteSize    `env:"MAX_BODY"`
+}
+
+// ShipmentRecord is a GORM model, see gorm.go and sql.go.
+type ShipmentRecord struct {
+	ID          uint
+	ShippedAt   DateTime
+	DeliveredAt NullDateTime
+	Tags        ArrayString
+	Transit     Duration
+	Discount    Percentage
+	Origin      IPAddress
+	Zone        Timezone
+	Spot        GeoPoint
+}
+
+// sqlLog collects the statements of a dry-run gorm.DB.
+type sqlLog []string
+
+func (l *sqlLog) LogMode(logger.LogLevel) logger.Interface      { return l }
+func (l *sqlLog) Info(context.Context, string, ...interface{})  {}
+func (l *sqlLog) Warn(context.Context, string, ...interface{})  {}
+func (l *sqlLog) Error(context.Context, string, ...interface{}) {}
+
+func (l *sqlLog) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	statement, _ := fc()
+	*l = append(*l, statement)
+}
+
+type ReportShipment struct {
+	ID       StringInt64 `csv:"id" json:"id"`
+	ShipAt   DateTime    `csv:"ship_at" json:"ship_at" binding:"required"`
+	Tags     ArrayString `csv:"tags" json:"tags"`
+	Weight   ByteSize    `csv:"weight" json:"weight"`
+	Customer string      `csv:"customer" json:"customer"`
+}
+
+func getRouter() *gin.Engine {
+	routerOnce.Do(func() {
+		router = gin.New()
+		routeLinks = NewLinkBuilder(router)
+		if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+			RegisterWithValidator(v)
+		}
+
+		router.Use(ErrorMiddleware(ErrorMiddlewareOptions{
+			Status: func(err error) int {
+				if errors.Is(err, errReportNotFound) {
+					return http.StatusNotFound
+				}
+				return 0
+			},
+		}))
+		router.Use(ResponseMetaMiddleware())
+
+		// simple routing
+		router.POST("/date-time", func(ctx *gin.Context) {
+			var request RequestContentDateTime
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, request)
+		})
+
+		router.POST("/array-string", func(ctx *gin.Context) {
+			var request RequestContentArrayString
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, request)
+		})
+
+		router.POST("/retry-policy", func(ctx *gin.Context) {
+			var request RequestContentRetryPolicy
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, request)
+		})
+
+		router.POST("/ip-address", func(ctx *gin.Context) {
+			var request RequestContentIPAddress
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, request)
+		})
+
+		router.POST("/breaker-config", func(ctx *gin.Context) {
+			var request RequestContentBreakerConfig
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, request)
+		})
+
+		router.POST("/duration", func(ctx *gin.Context) {
+			var request RequestContentDuration
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, request)
+		})
+
+		router.POST("/health-check", func(ctx *gin.Context) {
+			var request RequestContentHealthCheck
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, request)
+		})
+
+		router.POST("/period", func(ctx *gin.Context) {
+			var request RequestContentPeriod
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, request)
+		})
+
+		router.POST("/channel", func(ctx *gin.Context) {
+			var request RequestContentChannel
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, request)
+		})
+
+		router.POST("/null-date-time", func(ctx *gin.Context) {
+			var request RequestContentNullDateTime
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, request)
+		})
+
+		router.POST("/calendar-event", func(ctx *gin.Context) {
+			var request RequestContentCalendarEvent
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ics, err := MarshalICS(request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", ics)
+		})
+
+		router.POST("/null-scalar", func(ctx *gin.Context) {
+			var request RequestContentNullScalar
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, request)
+		})
+
+		router.POST("/contact", func(ctx *gin.Context) {
+			var request RequestContentContact
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			vcard, err := MarshalVCard(request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.Data(http.StatusOK, "text/vcard; charset=utf-8", vcard)
+		})
+
+		router.POST("/enum", func(ctx *gin.Context) {
+			var request RequestContentEnum
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, request)
+		})
+
+		router.POST("/short-code", func(ctx *gin.Context) {
+			var request RequestContentShortCode
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			payload, err := request.Code.QRPayload("https://example.com/redeem")
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"code":       request.Code,
+				"qr_payload": payload,
+			})
+		})
+
+		router.POST("/obfuscated-id", func(ctx *gin.Context) {
+			var request RequestContentObfuscatedID
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, request)
+		})
+
+		router.POST("/priority", func(ctx *gin.Context) {
+			var request RequestContentPriority
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, request)
+		})
+
+		router.POST("/flexible-bool", func(ctx *gin.Context) {
+			var request RequestContentFlexibleBool
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, request)
+		})
+
+		router.GET("/records/:key", func(ctx *gin.Context) {
+			key := CompositeKeyParam(ctx, "key")
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"key":      key,
+				"tenant":   key.Tenant(),
+				"resource": key.Resource(),
+				"id":       key.ID(),
+			})
+		})
+
+		router.POST("/string-int64", func(ctx *gin.Context) {
+			var request RequestContentStringInt64
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, request)
+		})
+
+		router.POST("/resource-name", func(ctx *gin.Context) {
+			var request RequestContentResourceName
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"name":    request.Name,
+				"project": request.Name.Segment("project"),
+				"job":     request.Name.Segment("job"),
+			})
+		})
+
+		router.POST("/password", func(ctx *gin.Context) {
+			var request RequestContentPassword
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, request)
+		})
+
+		router.POST("/field-mapping", func(ctx *gin.Context) {
+			var request RequestContentFieldMapping
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			mapper, err := request.Mapping.Compile(importSourceSchema, importTargetSchema)
+			if err != nil {
+				panic(err)
+			}
+			mapped, err := mapper(request.Sample)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"mapped": mapped,
+			})
+		})
+
+		router.POST("/masked-string", func(ctx *gin.Context) {
+			var request RequestContentMaskedString
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, request)
+		})
+
+		router.POST("/cell-range", func(ctx *gin.Context) {
+			var request RequestContentCellRange
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"range": request.Range,
+				"sheet": request.Range.Sheet(),
+				"cells": request.Range.Len(),
+			})
+		})
+
+		router.POST("/credit-card", func(ctx *gin.Context) {
+			var request RequestContentCreditCard
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"card_number": request.CardNumber,
+				"brand":       request.CardNumber.Brand(),
+			})
+		})
+
+		router.POST("/bit-string", func(ctx *gin.Context) {
+			var request RequestContentBitString
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"capabilities":  request.Capabilities,
+				"supports_wifi": request.Capabilities.Bit(2),
+			})
+		})
+
+		router.POST("/iso-code", func(ctx *gin.Context) {
+			var request RequestContentISOCode
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"country":           request.Country,
+				"country_name":      request.Country.Name(),
+				"currency":          request.Currency,
+				"currency_exponent": request.Currency.Exponent(),
+				"language":          request.Language,
+				"language_name":     request.Language.Name(),
+			})
+		})
+
+		router.POST("/date", func(ctx *gin.Context) {
+			var request RequestContentDate
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, request)
+		})
+
+		router.POST("/timezone", func(ctx *gin.Context) {
+			var request RequestContentTimezone
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			request.TimeAt = request.Timezone.Convert(request.TimeAt)
+
+			ctx.JSON(http.StatusOK, request)
+		})
+
+		router.POST("/geo-point", func(ctx *gin.Context) {
+			var request RequestContentGeoPoint
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"from":        request.From,
+				"to":          request.To,
+				"distance_km": math.Round(request.From.DistanceTo(request.To) / 1000),
+			})
+		})
+
+		router.POST("/next-run", func(ctx *gin.Context) {
+			var request RequestContentTimezone
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"next_run": request.TimeAt.SameWallClockNextDay(request.Timezone.Location()),
+			})
+		})
+
+		router.POST("/semver", func(ctx *gin.Context) {
+			var request RequestContentSemver
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"client_version": request.ClientVersion,
+				"pre_release":    request.ClientVersion.PreRelease(),
+				"supported":      !request.ClientVersion.LessThan(minimumClientVersion),
+			})
+		})
+
+		router.POST("/elapsed", func(ctx *gin.Context) {
+			timer := StartTimer()
+
+			var request RequestContentArrayString
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"count":   len(request.List.List()),
+				"took_ms": timer.Elapsed(),
+			})
+		})
+
+		router.POST("/theme", func(ctx *gin.Context) {
+			var request RequestContentTheme
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"primary":          request.Primary,
+				"accent":           request.Accent,
+				"background":       request.Background,
+				"background_alpha": request.Background.Alpha(),
+			})
+		})
+
+		router.GET("/orders", func(ctx *gin.Context) {
+			page := PaginationFromQuery(ctx).SetTotal(int64(len(sampleOrders)))
+
+			items := []string{}
+			for i := page.Offset(); i < len(sampleOrders) && i < page.Offset()+page.Limit(); i++ {
+				items = append(items, sampleOrders[i])
+			}
+
+			ctx.JSON(http.StatusOK, NewMeta(ctx).
+				Pagination(page).
+				Deprecated("page-number pagination is deprecated, use cursor").
+				Envelope(items))
+		})
+
+		router.POST("/attachment", func(ctx *gin.Context) {
+			var request RequestContentAttachment
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"file_name": request.FileName,
+				"content":   request.Content,
+				"size":      len(request.Content),
+			})
+		})
+
+		router.GET("/order-feed", func(ctx *gin.Context) {
+			var position orderFeedPosition
+			if err := CursorFromQuery(ctx).Decode(&position); err != nil {
+				ctx.Error(prefixed("cursor", ErrCodeCursorInvalid.Err(nil)))
+				return
+			}
+			limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "2"))
+			if err != nil || limit < 1 {
+				ctx.Error(NewBadRequestError(nil, "limit must be a positive integer"))
+				return
+			}
+
+			items := []string{}
+			for i := position.After; i >= 0 && i < len(sampleOrders) && i < position.After+limit; i++ {
+				items = append(items, sampleOrders[i])
+			}
+
+			var next, prev Cursor
+			if position.After+limit < len(sampleOrders) {
+				next = NewCursor(orderFeedPosition{After: position.After + limit})
+			}
+			if position.After > 0 {
+				before := position.After - limit
+				if before < 0 {
+					before = 0
+				}
+				prev = NewCursor(orderFeedPosition{After: before})
+			}
+
+			ctx.JSON(http.StatusOK, NewMeta(ctx).Cursors(next, prev).Envelope(items))
+		})
+
+		router.POST("/upload-limits", func(ctx *gin.Context) {
+			var request RequestContentUploadLimits
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"max_file_size":       request.MaxFileSize,
+				"max_file_size_bytes": request.MaxFileSize.Bytes(),
+				"max_request_size":    request.MaxRequestSize,
+				"chunk_size":          request.ChunkSize,
+			})
+		})
+
+		router.GET("/orders/:id", func(ctx *gin.Context) {
+			id := ctx.Param("id")
+
+			ctx.JSON(http.StatusOK, ResponseOrder{
+				ID: id,
+				Links: Links{
+					routeLinks.Link("self", http.MethodGet, "/orders/:id", "id", id),
+					routeLinks.Link("cancel", http.MethodPost, "/orders/:id/cancel", "id", id),
+					routeLinks.Link("collection", http.MethodGet, "/orders"),
+				},
+			})
+		})
+
+		router.POST("/orders/:id/cancel", func(ctx *gin.Context) {
+			ctx.Status(http.StatusNoContent)
+		})
+
+		router.POST("/discount", func(ctx *gin.Context) {
+			var request RequestContentDiscount
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			discounted := request.SubtotalCents - request.Discount.Of(request.SubtotalCents)
+			ctx.JSON(http.StatusOK, gin.H{
+				"discount":            request.Discount,
+				"discount_cents":      request.Discount.Of(request.SubtotalCents),
+				"tax_rate":            request.TaxRate,
+				"tax_cents":           request.TaxRate.Of(discounted),
+				"interchange":         request.Interchange,
+				"interchange_percent": request.Interchange.Percentage().String(),
+			})
+		})
+
+		router.GET("/_errors", func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, errorCatalogDocument())
+		})
+
+		router.POST("/schedule", func(ctx *gin.Context) {
+			var request RequestContentSchedule
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			nextRuns := []DateTime{}
+			for _, t := range request.Cron.NextN(request.From.Time(), 3) {
+				nextRuns = append(nextRuns, NewDateTime(t))
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"cron":      request.Cron,
+				"next_runs": nextRuns,
+			})
+		})
+
+		router.GET("/_legacy-panics", func(ctx *gin.Context) {
+			ctx.JSON(http.StatusOK, gin.H{
+				"sites": LegacyPanicUsage(),
+			})
+		})
+
+		router.POST("/regex-pattern", func(ctx *gin.Context) {
+			var request RequestContentRegexPattern
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			matches := []string{}
+			for _, sample := range request.Samples.List() {
+				if request.Pattern.MatchString(sample) {
+					matches = append(matches, sample)
+				}
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"pattern": request.Pattern,
+				"matches": matches,
+			})
+		})
+
+		router.POST("/normalized-string", func(ctx *gin.Context) {
+			var request RequestContentNormalizedString
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"code":             request.Code,
+				"name":             request.Name,
+				"matches_existing": request.Name == NormalizeString("José Díaz"),
+			})
+		})
+
+		router.POST("/checkout-currency", func(ctx *gin.Context) {
+			var request RequestContentCheckoutCurrency
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"currency": request.Currency,
+			})
+		})
+
+		router.POST("/sign-up", func(ctx *gin.Context) {
+			var request RequestContentSignUp
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"username":     request.Username,
+				"display_name": request.DisplayName,
+			})
+		})
+
+		router.POST("/delivery", func(ctx *gin.Context) {
+			var request RequestContentDelivery
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			err = deliveryHolidays.Check(func(holidays map[string]bool) error {
+				if holidays[request.DeliveryDate.String()] {
+					return NewBadRequestError(nil, request.DeliveryDate.String()+" is a public holiday")
+				}
+				return nil
+			})
+			if err == nil {
+				err = courierCoverage.Check(func(cities []string) error {
+					for _, city := range cities {
+						if city == request.City {
+							return nil
+						}
+					}
+					return NewBadRequestError(nil, "no courier delivers to "+request.City)
+				})
+			}
+			if err != nil {
+				ctx.Error(err)
+				return
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"delivery_date": request.DeliveryDate,
+				"city":          request.City,
+			})
+		})
+
+		router.POST("/articles", func(ctx *gin.Context) {
+			var request RequestContentArticle
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			slug := request.Slug
+			if slug == "" {
+				slug = SlugFrom(request.Title)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"title": request.Title,
+				"slug":  slug,
+			})
+		})
+
+		router.POST("/webhooks", func(ctx *gin.Context) {
+			var request RequestContentWebhook
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"event":   request.Event,
+				"payload": request.Payload,
+			})
+		})
+
+		router.POST("/labels", func(ctx *gin.Context) {
+			var request RequestContentLabels
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"labels": request.Labels,
+				"keys":   request.Labels.Keys(),
+			})
+		})
+
+		router.GET("/labels/search", func(ctx *gin.Context) {
+			labels, err := ParseDelimitedMap(ctx.Query("labels"))
+			if err != nil {
+				ctx.Error(prefixed("labels", err))
+				return
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"labels": labels,
+			})
+		})
+
+		router.POST("/bookings", func(ctx *gin.Context) {
+			var request RequestContentBooking
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			for _, booked := range existingBookings {
+				if request.Stay.Overlaps(booked) {
+					ctx.JSON(http.StatusConflict, gin.H{
+						"error": "stay overlaps an existing booking " + booked.String(),
+					})
+					return
+				}
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"stay": request.Stay,
+				"days": request.Stay.Days(),
+			})
+		})
+
+		router.POST("/room-reservations", func(ctx *gin.Context) {
+			var request RequestContentRoomReservation
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			for _, reserved := range existingReservations {
+				if request.Slot.Overlaps(reserved) {
+					ctx.JSON(http.StatusConflict, gin.H{
+						"error": "slot overlaps an existing reservation " + reserved.String(),
+					})
+					return
+				}
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"slot":    request.Slot,
+				"minutes": request.Slot.Duration().Minutes(),
+			})
+		})
+
+		router.POST("/products/search", func(ctx *gin.Context) {
+			var request RequestContentProductSearch
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			products := []string{}
+			for _, product := range sampleProducts {
+				if request.Price.Contains(product.Price) {
+					products = append(products, product.Name)
+				}
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"price":    request.Price,
+				"products": products,
+			})
+		})
+
+		router.GET("/products", func(ctx *gin.Context) {
+			rows, err := ParseIntRange(ctx.DefaultQuery("rows", "0-9"))
+			if err != nil {
+				ctx.Error(prefixed("rows", err))
+				return
+			}
+			// Clamp the window to the rows that exist.
+			available, _ := NewIntRange(0, int64(len(sampleProducts)-1))
+			products := []string{}
+			if rows.Max() >= available.Min() && rows.Min() <= available.Max() {
+				rows, _ = NewIntRange(available.Clamp(rows.Min()), available.Clamp(rows.Max()))
+				for i := rows.Min(); i <= rows.Max(); i++ {
+					products = append(products, sampleProducts[i].Name)
+				}
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"rows":     rows,
+				"products": products,
+			})
+		})
+
+		router.POST("/opening-hours", func(ctx *gin.Context) {
+			var request RequestContentOpeningHours
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			closedSaturday, closedSunday := false, false
+			for _, day := range request.ClosedOn {
+				closedSaturday = closedSaturday || day.Weekday() == time.Saturday
+				closedSunday = closedSunday || day.Weekday() == time.Sunday
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"closed_on":      request.ClosedOn,
+				"holiday_month":  request.HolidayMonth,
+				"weekend_closed": closedSaturday && closedSunday,
+			})
+		})
+
+		router.POST("/events/occurrences", func(ctx *gin.Context) {
+			var request RequestContentOccurrences
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"rrule":       request.RRule,
+				"occurrences": request.RRule.Occurrences(request.Start, request.Between),
+			})
+		})
+
+		router.GET("/reports/:name", func(ctx *gin.Context) {
+			report, ok := sampleReports[ctx.Param("name")]
+			if !ok {
+				ctx.Error(errReportNotFound)
+				return
+			}
+			if NotModified(ctx, report.LastModified) {
+				return
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"report":        report.Name,
+				"last_modified": report.LastModified,
+			})
+		})
+
+		router.POST("/audit-events", func(ctx *gin.Context) {
+			// Millisecond precision for this field only; ReceivedAt keeps
+			// DefaultDateTimePrecision.
+			request := RequestContentAuditEvent{OccurredAt: DateTime{}.WithPrecision(DateTimeMillis)}
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"occurred_at": request.OccurredAt,
+				"received_at": request.ReceivedAt,
+			})
+		})
+
+		router.POST("/date-time/day", func(ctx *gin.Context) {
+			var request RequestContentDateTime
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			start, end := request.TimeAt.StartOfDay(), request.TimeAt.EndOfDay()
+			ctx.JSON(http.StatusOK, gin.H{
+				"start_of_day":     start,
+				"end_of_day":       end,
+				"until_end_of_day": end.Sub(request.TimeAt).String(),
+				"in_first_hour":    request.TimeAt.Before(start.Add(time.Hour)),
+			})
+		})
+
+		router.POST("/appointments", func(ctx *gin.Context) {
+			request := RequestContentAppointment{
+				StartsAt: DateTime{}.WithConstraints(InFuture(), WithinNext(90*24*time.Hour)),
+			}
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"starts_at": request.StartsAt,
+			})
+		})
+
+		router.POST("/shipments", func(ctx *gin.Context) {
+			var request RequestContentShipment
+			err := Bind(ctx, &request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"day":    request.Day,
+				"starts": NewDateTime(request.Day.Time()),
+				"items":  []string(request.Items),
+				"note":   request.Note,
+			})
+		})
+
+		createProfile := func(ctx *gin.Context) {
+			var request RequestContentProfile
+			err := ctx.ShouldBindWith(&request, JSONBinding)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"handle":   request.Handle,
+				"birthday": request.Birthday,
+			})
+		}
+		router.POST("/profiles", createProfile)
+
+		// The same route answering errors as Problem Details; this
+		// ErrorMiddleware runs inside the router's and catches first.
+		problems := router.Group("/problems", ErrorMiddleware(ErrorMiddlewareOptions{ProblemDetails: true}))
+		problems.POST("/profiles", createProfile)
+
+		router.GET("/orders/search", func(ctx *gin.Context) {
+			var request RequestContentOrderSearch
+			err := BindQuery(ctx, &request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"from":   request.From,
+				"tags":   []string(request.Tags),
+				"status": request.Status,
+				"page":   request.Page,
+			})
+		})
+
+		router.GET("/profiles/:handle", func(ctx *gin.Context) {
+			var request RequestContentProfileURI
+			err := BindURI(ctx, &request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"handle": request.Handle,
+			})
+		})
+
+		router.GET("/request-info", func(ctx *gin.Context) {
+			start, err := DateTimeFromHeader(ctx, "X-Request-Start")
+			if err != nil {
+				ctx.Error(err)
+				return
+			}
+			languages, err := LanguageTagsFromHeader(ctx, "Accept-Language")
+			if err != nil {
+				ctx.Error(err)
+				return
+			}
+			tags, err := ArrayStringFromHeader(ctx, "X-Tags")
+			if err != nil {
+				ctx.Error(err)
+				return
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"request_start": start,
+				"languages":     languages,
+				"tags":          []string(tags),
+			})
+		})
+
+		router.POST("/meetings", func(ctx *gin.Context) {
+			var request RequestContentMeeting
+			err := ctx.ShouldBindWith(&request, JSONBinding)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"starts_at": request.StartsAt,
+				"tags":      request.Tags,
+			})
+		})
+
+		router.POST("/reservations", func(ctx *gin.Context) {
+			var request RequestContentReservation
+			err := ctx.ShouldBind(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"starts_at": request.StartsAt,
+				"length":    request.Length,
+				"guests":    request.Guests,
+			})
+		})
+
+		router.POST("/partner/orders", func(ctx *gin.Context) {
+			var request RequestContentPartnerOrder
+			err := ctx.ShouldBindXML(&request)
+			if err != nil {
+				panic(err)
+			}
+
+			ctx.XML(http.StatusOK, request)
+		})
+
+		router.POST("/shipments/import", func(ctx *gin.Context) {
+			imported := 0
+			err := DecodeStream(ctx.Request.Body, func(shipment ReportShipment) error {
+				imported++
+				return nil
+			})
+			if err != nil {
+				ctx.Error(err)
+				return
+			}
+
+			ctx.JSON(http.StatusOK, gin.H{
+				"imported": imported,
+			})
+		})
+	})
+
+	return router
+}
+
+func makeTestRequest(method string, url string, body map[string]interface{}) *httptest.ResponseRecorder {
+	return makeTestRequestWithHeaders(method, url, body, nil)
+}
+
+func makeTestRequestWithHeaders(method string, url string, body map[string]interface{}, headers map[string]string) *httptest.ResponseRecorder {
+	jsoned, err := json.Marshal(body)
+	if err != nil {
+		panic(err)
+	}
+
+	request, err := http.NewRequest(method, url, bytes.NewBuffer(jsoned))
+	if err != nil {
+		panic(err)
+	}
+	request.Header.Add("Content-Type", "application/json")
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+
+	return serveTestRequest(request)
+}
+
+func makeTestRequestWithBody(method string, url string, contentType string, body string) *httptest.ResponseRecorder {
+	request, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		panic(err)
+	}
+	request.Header.Add("Content-Type", contentType)
+
+	return serveTestRequest(request)
+}
 
+func serveTestRequest(request *http.Request) *httptest.ResponseRecorder {
 	response := httptest.NewRecorder()
 
 	router := getRouter()
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrCodeMaskedStringNotString = RegisterErrorCode(ErrorCode{
+		Code: "masked_string.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeMaskedStringEmpty = RegisterErrorCode(ErrorCode{
+		Code: "masked_string.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+)
+
+// MaskStrategy turns a sensitive value into the representation that is safe
+// to send back to clients or write to logs.
+type MaskStrategy func(value string) string
+
+// MaskKeepLast replaces every rune but the last n with '*'.
+func MaskKeepLast(n int) MaskStrategy {
+	return func(value string) string {
+		runes := []rune(value)
+		for i := 0; i < len(runes)-n; i++ {
+			runes[i] = '*'
+		}
+		return string(runes)
+	}
+}
+
+// MaskGrouped masks all but the last visible characters and then splits the
+// result into groups joined by sep, e.g. MaskGrouped(4, 4, "-") renders a card
+// number as "****-****-****-1234". Existing spaces and dashes are dropped.
+func MaskGrouped(size int, visible int, sep string) MaskStrategy {
+	keepLast := MaskKeepLast(visible)
+	return func(value string) string {
+		value = strings.NewReplacer(" ", "", "-", "").Replace(value)
+		runes := []rune(keepLast(value))
+
+		var groups []string
+		for start := len(runes) % size; start <= len(runes); start += size {
+			if start == 0 {
+				continue
+			}
+			from := start - size
+			if from < 0 {
+				from = 0
+			}
+			groups = append(groups, string(runes[from:start]))
+		}
+		return strings.Join(groups, sep)
+	}
+}
+
+// MaskFull hides the value entirely, keeping only its length.
+func MaskFull(value string) string {
+	return strings.Repeat("*", utf8.RuneCountInString(value))
+}
+
+// DefaultMaskStrategy is used by MaskedString values that were unmarshaled or
+// created without an explicit strategy.
+var DefaultMaskStrategy = MaskKeepLast(4)
+
+// MaskedString holds PII such as national IDs in full but only ever
+// marshals and prints its masked form; Reveal returns the original.
+type MaskedString struct {
+	value string
+	mask  MaskStrategy
+}
+
+func NewMaskedString(value string, mask MaskStrategy) MaskedString {
+	return MaskedString{value: value, mask: mask}
+}
+
+// Reveal returns the unmasked value. Keep its call sites few and obvious.
+func (ms MaskedString) Reveal() string {
+	return ms.value
+}
+
+func (ms MaskedString) Masked() string {
+	if ms.value == "" {
+		return ""
+	}
+	if ms.mask != nil {
+		return ms.mask(ms.value)
+	}
+	return DefaultMaskStrategy(ms.value)
+}
+
+func (ms MaskedString) String() string {
+	return ms.Masked()
+}
+
+func (ms MaskedString) GoString() string {
+	return ms.Masked()
+}
+
+func (ms MaskedString) IsZero() bool {
+	return ms.value == ""
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (ms MaskedString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ms.Masked())
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (ms *MaskedString) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeMaskedStringNotString.Err(nil)
+	}
+	if s == "" {
+		return ErrCodeMaskedStringEmpty.Err(nil)
+	}
+
+	ms.value = s
+
+	return nil
+}
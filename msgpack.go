@@ -0,0 +1,153 @@
+package main
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// The types implement msgpack.CustomEncoder and CustomDecoder of
+// vmihailenco/msgpack in their compact form rather than their JSON text:
+//
+//	DateTime     msgpack timestamp (epoch seconds and nanoseconds)
+//	ArrayString  array of strings
+//	Duration     integer nanoseconds
+//	ByteSize     integer bytes
+//	StringInt64  integer
+//	Percentage   integer millionths, see Percentage
+//	BasisPoints  integer basis points
+//	Password     nil, so the plaintext is never written
+//
+// A timestamp has no offset, so a decoded DateTime is in the field's
+// default location, or DateTimeDefaultLocation, or UTC. Decoding applies
+// the same precision, constraints and bounds as a JSON body. Other types,
+// such as ObfuscatedID, are written as their MarshalText string.
+
+/*
+This part implements `msgpack.CustomEncoder`
+
+	type CustomEncoder interface {
+		EncodeMsgpack(*msgpack.Encoder) error
+	}
+*/
+func (dt DateTime) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return enc.EncodeTime(dt.Time())
+}
+
+/*
+This part implements `msgpack.CustomDecoder`
+
+	type CustomDecoder interface {
+		DecodeMsgpack(*msgpack.Decoder) error
+	}
+*/
+func (dt *DateTime) DecodeMsgpack(dec *msgpack.Decoder) error {
+	t, err := dec.DecodeTime()
+	if err != nil {
+		return err
+	}
+	return dt.setTime(t.In(dt.instantLocation()))
+}
+
+func (dt ArrayString) EncodeMsgpack(enc *msgpack.Encoder) error {
+	if dt == nil {
+		return enc.EncodeNil()
+	}
+	if err := enc.EncodeArrayLen(len(dt)); err != nil {
+		return err
+	}
+	for _, item := range dt {
+		if err := enc.EncodeString(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (dt *ArrayString) DecodeMsgpack(dec *msgpack.Decoder) error {
+	n, err := dec.DecodeArrayLen()
+	if err != nil {
+		return err
+	}
+	if n == -1 {
+		*dt = nil
+		return nil
+	}
+	list := make(ArrayString, n)
+	for i := range list {
+		if list[i], err = dec.DecodeString(); err != nil {
+			return err
+		}
+	}
+	*dt = list
+	return nil
+}
+
+func (d Duration) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return enc.EncodeInt(int64(d.duration))
+}
+
+func (d *Duration) DecodeMsgpack(dec *msgpack.Decoder) error {
+	n, err := dec.DecodeInt64()
+	if err != nil {
+		return err
+	}
+	d.duration = time.Duration(n)
+	return nil
+}
+
+func (bs ByteSize) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return enc.EncodeInt(int64(bs))
+}
+
+func (bs *ByteSize) DecodeMsgpack(dec *msgpack.Decoder) error {
+	n, err := dec.DecodeInt64()
+	if err != nil {
+		return err
+	}
+	// Through UnmarshalText, so ByteSizeValidate applies.
+	return bs.UnmarshalText([]byte(strconv.FormatInt(n, 10)))
+}
+
+func (si StringInt64) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return enc.EncodeInt(int64(si))
+}
+
+func (si *StringInt64) DecodeMsgpack(dec *msgpack.Decoder) error {
+	n, err := dec.DecodeInt64()
+	if err != nil {
+		return err
+	}
+	*si = StringInt64(n)
+	return nil
+}
+
+func (p Percentage) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return enc.EncodeInt(int64(p))
+}
+
+func (p *Percentage) DecodeMsgpack(dec *msgpack.Decoder) error {
+	n, err := dec.DecodeInt64()
+	if err != nil {
+		return err
+	}
+	// Through UnmarshalText, so PercentageMin and PercentageMax apply.
+	return p.UnmarshalText([]byte(Percentage(n).String()))
+}
+
+func (bp BasisPoints) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return enc.EncodeInt(int64(bp))
+}
+
+func (bp *BasisPoints) DecodeMsgpack(dec *msgpack.Decoder) error {
+	n, err := dec.DecodeInt64()
+	if err != nil {
+		return err
+	}
+	return bp.UnmarshalText([]byte(strconv.FormatInt(n, 10)))
+}
+
+func (p Password) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return enc.EncodeNil()
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	ErrCodeTrimmedStringNotString = RegisterErrorCode(ErrorCode{
+		Code: "trimmed_string.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeNormalizedStringNotString = RegisterErrorCode(ErrorCode{
+		Code: "normalized_string.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+)
+
+// TrimmedString drops leading and trailing whitespace (including Unicode
+// spaces such as U+00A0) on unmarshal.
+type TrimmedString string
+
+func (ts TrimmedString) String() string {
+	return string(ts)
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (ts *TrimmedString) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeTrimmedStringNotString.Err(nil)
+	}
+
+	*ts = TrimmedString(strings.TrimSpace(s))
+
+	return nil
+}
+
+// NormalizedString is a TrimmedString that also collapses every internal
+// run of whitespace to one space and applies Unicode NFC, so "José  Díaz"
+// and "José Díaz" are stored, compared and indexed as the same string.
+type NormalizedString string
+
+func NormalizeString(s string) NormalizedString {
+	return NormalizedString(norm.NFC.String(strings.Join(strings.Fields(s), " ")))
+}
+
+func (ns NormalizedString) String() string {
+	return string(ns)
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (ns *NormalizedString) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeNormalizedStringNotString.Err(nil)
+	}
+
+	*ns = NormalizeString(s)
+
+	return nil
+}
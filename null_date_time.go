@@ -0,0 +1,63 @@
+package main
+
+// NullDateTime is a DateTime that may be absent. It marshals to `null` when
+// not valid (or when the wrapped time is zero) and accepts `null` on input.
+type NullDateTime struct {
+	DateTime DateTime
+	Valid    bool
+}
+
+func (ndt NullDateTime) String() string {
+	if !ndt.Valid || ndt.DateTime.IsZero() {
+		return ""
+	}
+	return ndt.DateTime.String()
+}
+
+func (ndt NullDateTime) IsZero() bool {
+	return !ndt.Valid || ndt.DateTime.IsZero()
+}
+
+// Equal reports whether both are null, or both hold the same instant, as
+// DateTime.Equal: a value read back from the database is in the default
+// location rather than the offset it was written with.
+func (ndt NullDateTime) Equal(other NullDateTime) bool {
+	if ndt.IsZero() || other.IsZero() {
+		return ndt.IsZero() == other.IsZero()
+	}
+	return ndt.DateTime.Equal(other.DateTime)
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (ndt NullDateTime) MarshalJSON() ([]byte, error) {
+	if !ndt.Valid || ndt.DateTime.IsZero() {
+		return []byte("null"), nil
+	}
+	return ndt.DateTime.AppendJSON(make([]byte, 0, dateTimeBufferSize)), nil
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (ndt *NullDateTime) UnmarshalJSON(b []byte) error {
+	if isJSONNull(b) {
+		ndt.DateTime = DateTime{}
+		ndt.Valid = false
+		return nil
+	}
+
+	if err := ndt.DateTime.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	ndt.Valid = true
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+)
+
+var (
+	ErrCodeNullStringInvalid = RegisterErrorCode(ErrorCode{
+		Code: "null_string.invalid", Status: http.StatusBadRequest,
+		Message: "must be a valid string or null", Example: "must be a valid string or null",
+		Kind: ErrNotAString,
+	})
+	ErrCodeNullInt64Invalid = RegisterErrorCode(ErrorCode{
+		Code: "null_int64.invalid", Status: http.StatusBadRequest,
+		Message: "must be a valid integer or null", Example: "must be a valid integer or null",
+	})
+	ErrCodeNullBoolInvalid = RegisterErrorCode(ErrorCode{
+		Code: "null_bool.invalid", Status: http.StatusBadRequest,
+		Message: "must be a valid boolean or null", Example: "must be a valid boolean or null",
+	})
+	ErrCodeNullFloat64Invalid = RegisterErrorCode(ErrorCode{
+		Code: "null_float64.invalid", Status: http.StatusBadRequest,
+		Message: "must be a valid number or null", Example: "must be a valid number or null",
+	})
+)
+
+/*
+	The Null* types embed their `database/sql` counterpart, so `Scan` and
+	`Value` come for free, but unlike those types they marshal to `null`
+	instead of `{"String":"","Valid":false}` and accept `null` on input.
+*/
+
+type NullString struct {
+	sql.NullString
+}
+
+type NullInt64 struct {
+	sql.NullInt64
+}
+
+type NullBool struct {
+	sql.NullBool
+}
+
+type NullFloat64 struct {
+	sql.NullFloat64
+}
+
+func NewNullString(s string) NullString {
+	return NullString{sql.NullString{String: s, Valid: true}}
+}
+
+func NewNullInt64(i int64) NullInt64 {
+	return NullInt64{sql.NullInt64{Int64: i, Valid: true}}
+}
+
+func NewNullBool(b bool) NullBool {
+	return NullBool{sql.NullBool{Bool: b, Valid: true}}
+}
+
+func NewNullFloat64(f float64) NullFloat64 {
+	return NullFloat64{sql.NullFloat64{Float64: f, Valid: true}}
+}
+
+func isJSONNull(b []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(b), []byte("null"))
+}
+
+func (ns NullString) IsZero() bool {
+	return !ns.Valid
+}
+
+func (ns NullString) MarshalJSON() ([]byte, error) {
+	if !ns.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(ns.String)
+}
+
+func (ns *NullString) UnmarshalJSON(b []byte) error {
+	if isJSONNull(b) {
+		ns.String, ns.Valid = "", false
+		return nil
+	}
+	if err := json.Unmarshal(b, &ns.String); err != nil {
+		return ErrCodeNullStringInvalid.Err(nil)
+	}
+	ns.Valid = true
+	return nil
+}
+
+func (ni NullInt64) IsZero() bool {
+	return !ni.Valid
+}
+
+func (ni NullInt64) MarshalJSON() ([]byte, error) {
+	if !ni.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(ni.Int64)
+}
+
+func (ni *NullInt64) UnmarshalJSON(b []byte) error {
+	if isJSONNull(b) {
+		ni.Int64, ni.Valid = 0, false
+		return nil
+	}
+	if err := json.Unmarshal(b, &ni.Int64); err != nil {
+		return ErrCodeNullInt64Invalid.Err(nil)
+	}
+	ni.Valid = true
+	return nil
+}
+
+func (nb NullBool) IsZero() bool {
+	return !nb.Valid
+}
+
+func (nb NullBool) MarshalJSON() ([]byte, error) {
+	if !nb.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(nb.Bool)
+}
+
+func (nb *NullBool) UnmarshalJSON(b []byte) error {
+	if isJSONNull(b) {
+		nb.Bool, nb.Valid = false, false
+		return nil
+	}
+	if err := json.Unmarshal(b, &nb.Bool); err != nil {
+		return ErrCodeNullBoolInvalid.Err(nil)
+	}
+	nb.Valid = true
+	return nil
+}
+
+func (nf NullFloat64) IsZero() bool {
+	return !nf.Valid
+}
+
+func (nf NullFloat64) MarshalJSON() ([]byte, error) {
+	if !nf.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(nf.Float64)
+}
+
+func (nf *NullFloat64) UnmarshalJSON(b []byte) error {
+	if isJSONNull(b) {
+		nf.Float64, nf.Valid = 0, false
+		return nil
+	}
+	if err := json.Unmarshal(b, &nf.Float64); err != nil {
+		return ErrCodeNullFloat64Invalid.Err(nil)
+	}
+	nf.Valid = true
+	return nil
+}
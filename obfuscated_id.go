@@ -0,0 +1,245 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrCodeObfuscatedIDNotString = RegisterErrorCode(ErrorCode{
+		Code: "obfuscated_id.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeObfuscatedIDEmpty = RegisterErrorCode(ErrorCode{
+		Code: "obfuscated_id.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeObfuscatedIDInvalid = RegisterErrorCode(ErrorCode{
+		Code: "obfuscated_id.invalid", Status: http.StatusBadRequest,
+		Message: "must be a valid ID", Params: []string{"value"}, Example: "must be a valid ID",
+		Kind: ErrInvalidFormat,
+	})
+)
+
+// IDObfuscator turns integer IDs into short opaque strings in the spirit of
+// hashids: the alphabet is shuffled by a secret salt, so without the salt
+// consecutive IDs do not look consecutive. It hides row counts and ordering,
+// it is not encryption.
+type IDObfuscator struct {
+	alphabet  string
+	salt      string
+	minLength int
+}
+
+// DefaultIDObfuscator is used by ObfuscatedID. Replace it at startup with one
+// built from the deployment's own salt.
+var DefaultIDObfuscator = MustNewIDObfuscator("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789", "change-me", 8)
+
+func NewIDObfuscator(alphabet string, salt string, minLength int) (*IDObfuscator, error) {
+	if len(alphabet) < 16 {
+		return nil, errors.New("alphabet must contain at least 16 characters")
+	}
+	seen := make(map[rune]bool, len(alphabet))
+	for _, r := range alphabet {
+		if r > 127 {
+			return nil, errors.New("alphabet must only contain ASCII characters")
+		}
+		if seen[r] {
+			return nil, errors.New("alphabet must not contain duplicate characters")
+		}
+		seen[r] = true
+	}
+	if salt == "" {
+		return nil, errors.New("salt must not be empty")
+	}
+	if minLength < 2 {
+		minLength = 2
+	}
+	return &IDObfuscator{alphabet: alphabet, salt: salt, minLength: minLength}, nil
+}
+
+func MustNewIDObfuscator(alphabet string, salt string, minLength int) *IDObfuscator {
+	o, err := NewIDObfuscator(alphabet, salt, minLength)
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
+// Encode picks a "lottery" character from a salted hash of the ID, shuffles
+// the alphabet with it and the salt, and writes the ID in that base,
+// left-padded with zero digits up to the minimum length. Each position
+// rotates the digit alphabet so the padding does not show up as a run of
+// one character.
+func (o *IDObfuscator) Encode(id uint64) string {
+	base := uint64(len(o.alphabet))
+	lottery := o.lottery(id)
+	digits := o.shuffle(lottery)
+
+	var values []uint64
+	for n := id; ; n /= base {
+		values = append(values, n%base)
+		if n < base {
+			break
+		}
+	}
+	for len(values)+1 < o.minLength {
+		values = append(values, 0)
+	}
+
+	body := make([]byte, len(values))
+	for i := range body {
+		v := values[len(values)-1-i]
+		body[i] = digits[(v+o.rotation(i, lottery))%base]
+	}
+
+	return string(lottery) + string(body)
+}
+
+func (o *IDObfuscator) Decode(s string) (uint64, error) {
+	invalid := ErrCodeObfuscatedIDInvalid.Err(map[string]string{"value": s})
+	if len(s) < o.minLength || strings.IndexByte(o.alphabet, s[0]) < 0 {
+		return 0, invalid
+	}
+
+	digits := o.shuffle(s[0])
+	base := uint64(len(o.alphabet))
+	var id uint64
+	for i := 1; i < len(s); i++ {
+		idx := strings.IndexByte(digits, s[i])
+		if idx < 0 {
+			return 0, invalid
+		}
+		d := (uint64(idx) + base - o.rotation(i-1, s[0])) % base
+		if id > (math.MaxUint64-uint64(d))/base {
+			return 0, invalid
+		}
+		id = id*base + uint64(d)
+	}
+
+	// Only the canonical spelling is accepted, so one ID has one string.
+	if o.Encode(id) != s {
+		return 0, invalid
+	}
+	return id, nil
+}
+
+// lottery hashes the ID with the salt, so neighbouring IDs start with
+// unrelated characters. Decode reads it back from the string instead.
+func (o *IDObfuscator) lottery(id uint64) byte {
+	h := fnv.New64a()
+	h.Write([]byte(o.salt))
+	// The splitmix64 finalizer: every bit of the ID changes about half of
+	// the bits of z.
+	z := id + h.Sum64()
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	z ^= z >> 31
+	return o.alphabet[z%uint64(len(o.alphabet))]
+}
+
+func (o *IDObfuscator) rotation(position int, lottery byte) uint64 {
+	return uint64(position*17+int(lottery)) % uint64(len(o.alphabet))
+}
+
+// shuffle is the hashids "consistent shuffle" keyed by lottery and salt.
+func (o *IDObfuscator) shuffle(lottery byte) string {
+	alphabet := []byte(o.alphabet)
+	key := string(lottery) + o.salt
+	for i, v, p := len(alphabet)-1, 0, 0; i > 0; i-- {
+		c := int(key[v])
+		p += c
+		j := (c + v + p) % i
+		alphabet[i], alphabet[j] = alphabet[j], alphabet[i]
+		v = (v + 1) % len(key)
+	}
+	return string(alphabet)
+}
+
+// ObfuscatedID is an internal numeric ID that only ever appears in API
+// payloads in its DefaultIDObfuscator encoded form.
+type ObfuscatedID uint64
+
+func (id ObfuscatedID) Uint64() uint64 {
+	return uint64(id)
+}
+
+func (id ObfuscatedID) String() string {
+	return DefaultIDObfuscator.Encode(uint64(id))
+}
+
+// GoString keeps the raw number available to debug logging via `%#v`.
+func (id ObfuscatedID) GoString() string {
+	return "ObfuscatedID(" + strconv.FormatUint(uint64(id), 10) + ")"
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (id ObfuscatedID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(id.String())
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (id *ObfuscatedID) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeObfuscatedIDNotString.Err(nil)
+	}
+	if s == "" {
+		return ErrCodeObfuscatedIDEmpty.Err(nil)
+	}
+	decoded, err := DefaultIDObfuscator.Decode(s)
+	if err != nil {
+		return err
+	}
+
+	*id = ObfuscatedID(decoded)
+
+	return nil
+}
+
+/*
+	This part implements `encoding.TextMarshaler`
+	type TextMarshaler interface {
+		MarshalText() (text []byte, err error)
+	}
+*/
+func (id ObfuscatedID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+/*
+	This part implements `encoding.TextUnmarshaler`
+	type TextUnmarshaler interface {
+		UnmarshalText(text []byte) error
+	}
+*/
+func (id *ObfuscatedID) UnmarshalText(b []byte) error {
+	return unmarshalTextAsJSON(id, b)
+}
+
+/*
+	This part implements BurntSushi/toml's `toml.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalTOML(interface{}) error
+	}
+*/
+func (id *ObfuscatedID) UnmarshalTOML(value interface{}) error {
+	return unmarshalValueAsJSON(id, value)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIDObfuscatorRoundTrip(t *testing.T) {
+	o := MustNewIDObfuscator("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789", "test-salt", 8)
+	for _, id := range []uint64{0, 1, 2, 42, 61, 62, 63, 1 << 32, 1<<64 - 1} {
+		s := o.Encode(id)
+		if len(s) < 8 {
+			t.Errorf("Encode(%d) = %q, shorter than the minimum length", id, s)
+		}
+		got, err := o.Decode(s)
+		if err != nil || got != id {
+			t.Errorf("Decode(Encode(%d) = %q) = %d, %v", id, s, got, err)
+		}
+	}
+}
+
+func TestIDObfuscatorConsecutiveIDs(t *testing.T) {
+	const alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	o := MustNewIDObfuscator(alphabet, "test-salt", 8)
+
+	// Without the salt in the lottery, IDs 1, 2, 3, ... start with b, c,
+	// d, ...: the step between first characters is always one.
+	steps := map[int]int{}
+	sharedPrefix := 0
+	prev := o.Encode(1000)
+	for id := uint64(1001); id <= 2000; id++ {
+		s := o.Encode(id)
+		step := (strings.IndexByte(alphabet, s[0]) - strings.IndexByte(alphabet, prev[0]) + len(alphabet)) % len(alphabet)
+		steps[step]++
+		if s[0] == prev[0] {
+			sharedPrefix++
+		}
+		prev = s
+	}
+	for step, n := range steps {
+		if n > 100 {
+			t.Errorf("%d of 1000 consecutive IDs move the first character by %d", n, step)
+		}
+	}
+	// One in 62 by chance.
+	if sharedPrefix > 40 {
+		t.Errorf("%d of 1000 consecutive IDs share their first character", sharedPrefix)
+	}
+}
+
+func TestIDObfuscatorSalt(t *testing.T) {
+	const alphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	a := MustNewIDObfuscator(alphabet, "salt-a", 8)
+	b := MustNewIDObfuscator(alphabet, "salt-b", 8)
+	same := 0
+	for id := uint64(1); id <= 100; id++ {
+		if a.Encode(id)[0] == b.Encode(id)[0] {
+			same++
+		}
+	}
+	if same > 10 {
+		t.Errorf("%d of 100 IDs start with the same character under different salts", same)
+	}
+	if _, err := b.Decode(a.Encode(42)); err == nil {
+		t.Errorf("an ID encoded with one salt decoded with another")
+	}
+}
+
+func TestIDObfuscatorDecodeInvalid(t *testing.T) {
+	for _, s := range []string{"", "42", "QYkm5c2", "has space", "ÿÿÿÿÿÿÿÿ"} {
+		if _, err := DefaultIDObfuscator.Decode(s); err == nil || err.Error() != "must be a valid ID" {
+			t.Errorf("Decode(%q) error = %v, want must be a valid ID", s, err)
+		}
+	}
+}
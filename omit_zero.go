@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// zeroer is implemented by every struct-based type in the package.
+type zeroer interface {
+	IsZero() bool
+}
+
+// OmitZero marshals v like encoding/json, except that an `omitempty` field
+// whose value reports IsZero is left out. encoding/json never omits a
+// struct, so a DateTime or Duration that was not set is otherwise sent as
+// "0001-01-01T00:00:00Z" or "0s":
+//
+//	type ResponseContentEvent struct {
+//		Name   string   `json:"name"`
+//		EndsAt DateTime `json:"ends_at,omitempty"`
+//	}
+//
+//	ctx.JSON(http.StatusOK, OmitZero(response))
+//
+// Nested structs and embedded structs get the same treatment; values with
+// their own MarshalJSON are marshaled as usual. The `omitzero` option is
+// honoured too; on Go 1.24 and later encoding/json itself omits `omitzero`
+// fields using the same IsZero methods. The ",string" option is not
+// supported.
+func OmitZero(v interface{}) json.Marshaler {
+	return omitZero{v: v}
+}
+
+type omitZero struct {
+	v interface{}
+}
+
+func (o omitZero) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encodeOmitZero(&buf, reflect.ValueOf(o.v)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeOmitZero(buf *bytes.Buffer, v reflect.Value) error {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			buf.WriteString("null")
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		buf.WriteString("null")
+		return nil
+	}
+	if v.Kind() != reflect.Struct || marshalsItself(v.Type()) {
+		if v.CanAddr() {
+			v = v.Addr()
+		}
+		b, err := json.Marshal(v.Interface())
+		if err != nil {
+			return err
+		}
+		buf.Write(b)
+		return nil
+	}
+
+	buf.WriteByte('{')
+	first := true
+	if err := encodeFieldsOmitZero(buf, v, &first); err != nil {
+		return err
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+func encodeFieldsOmitZero(buf *bytes.Buffer, v reflect.Value, first *bool) error {
+	for i := 0; i < v.NumField(); i++ {
+		spec := v.Type().Field(i)
+		name, opts, _ := strings.Cut(spec.Tag.Get("json"), ",")
+		if name == "-" && opts == "" || !spec.IsExported() && !spec.Anonymous {
+			continue
+		}
+		field := v.Field(i)
+		// Embedded structs are promoted, as encoding/json does.
+		if spec.Anonymous && name == "" {
+			embedded := field
+			if embedded.Kind() == reflect.Ptr {
+				if embedded.IsNil() {
+					continue
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct && !marshalsItself(embedded.Type()) {
+				if err := encodeFieldsOmitZero(buf, embedded, first); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !spec.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = spec.Name
+		}
+		if omitField(field, opts) {
+			continue
+		}
+
+		if !*first {
+			buf.WriteByte(',')
+		}
+		*first = false
+		writeJSONString(buf, name)
+		buf.WriteByte(':')
+		if err := encodeOmitZero(buf, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func marshalsItself(t reflect.Type) bool {
+	p := reflect.PtrTo(t)
+	return p.Implements(jsonMarshalerType) || p.Implements(textMarshalerType)
+}
+
+// omitField reports whether a field tagged with opts is left out.
+func omitField(field reflect.Value, opts string) bool {
+	var omitempty, omitzero bool
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		omitempty = omitempty || opt == "omitempty"
+		omitzero = omitzero || opt == "omitzero"
+	}
+	if !omitempty && !omitzero {
+		return false
+	}
+	if field.Kind() == reflect.Ptr && field.IsNil() {
+		return true
+	}
+	if z, ok := field.Interface().(zeroer); ok {
+		return z.IsZero()
+	}
+	if omitzero && field.IsZero() {
+		return true
+	}
+	return omitempty && isEmptyValue(field)
+}
+
+// isEmptyValue is encoding/json's omitempty rule.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
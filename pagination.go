@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	ErrCodePaginationPage = RegisterErrorCode(ErrorCode{
+		Code: "pagination.page", Status: http.StatusBadRequest,
+		Message: "page must be a positive integer", Params: []string{"value"}, Example: "page must be a positive integer",
+	})
+	ErrCodePaginationPerPage = RegisterErrorCode(ErrorCode{
+		Code: "pagination.per_page", Status: http.StatusBadRequest,
+		Message: "per_page must be between 1 and {max}", Params: []string{"max", "value"}, Example: "per_page must be between 1 and 100",
+	})
+)
+
+const (
+	DefaultPerPage = 20
+	MaxPerPage     = 100
+)
+
+// Pagination is page-number pagination for list endpoints. The total is
+// unknown (-1) until SetTotal is called.
+type Pagination struct {
+	page    int
+	perPage int
+	total   int64
+}
+
+func NewPagination(page int, perPage int) Pagination {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = DefaultPerPage
+	}
+	if perPage > MaxPerPage {
+		perPage = MaxPerPage
+	}
+	return Pagination{page: page, perPage: perPage, total: -1}
+}
+
+// PaginationFromQuery reads ?page= and ?per_page=, panicking with a coded
+// error when either is present but not a positive integer.
+func PaginationFromQuery(ctx *gin.Context) Pagination {
+	page, perPage := 1, DefaultPerPage
+	if s, ok := ctx.GetQuery("page"); ok {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			panic(ErrCodePaginationPage.Err(map[string]string{"value": s}))
+		}
+		page = n
+	}
+	if s, ok := ctx.GetQuery("per_page"); ok {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > MaxPerPage {
+			panic(ErrCodePaginationPerPage.Err(map[string]string{"max": strconv.Itoa(MaxPerPage), "value": s}))
+		}
+		perPage = n
+	}
+	return NewPagination(page, perPage)
+}
+
+func (p Pagination) Page() int {
+	return p.page
+}
+
+func (p Pagination) PerPage() int {
+	return p.perPage
+}
+
+func (p Pagination) Offset() int {
+	return (p.page - 1) * p.perPage
+}
+
+func (p Pagination) Limit() int {
+	return p.perPage
+}
+
+func (p Pagination) SetTotal(total int64) Pagination {
+	p.total = total
+	return p
+}
+
+// Total returns the total item count, or -1 when it was never set.
+func (p Pagination) Total() int64 {
+	return p.total
+}
+
+func (p Pagination) TotalPages() int {
+	if p.total <= 0 {
+		return 0
+	}
+	return int((p.total + int64(p.perPage) - 1) / int64(p.perPage))
+}
+
+func (p Pagination) HasPrev() bool {
+	return p.page > 1
+}
+
+// HasNext is optimistic when the total is unknown.
+func (p Pagination) HasNext() bool {
+	return p.total < 0 || p.page < p.TotalPages()
+}
+
+type paginationJSON struct {
+	Page       int    `json:"page"`
+	PerPage    int    `json:"per_page"`
+	Total      *int64 `json:"total,omitempty"`
+	TotalPages *int   `json:"total_pages,omitempty"`
+}
+
+func (p Pagination) IsZero() bool {
+	return p == Pagination{}
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (p Pagination) MarshalJSON() ([]byte, error) {
+	out := paginationJSON{Page: p.page, PerPage: p.perPage}
+	if p.total >= 0 {
+		totalPages := p.TotalPages()
+		out.Total = &p.total
+		out.TotalPages = &totalPages
+	}
+	return json.Marshal(out)
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	ErrCodePasswordNotString = RegisterErrorCode(ErrorCode{
+		Code: "password.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodePasswordEmpty = RegisterErrorCode(ErrorCode{
+		Code: "password.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodePasswordTooShort = RegisterErrorCode(ErrorCode{
+		Code: "password.too_short", Status: http.StatusBadRequest,
+		Message: "must be at least {min} characters", Params: []string{"min"}, Example: "must be at least 8 characters",
+	})
+	ErrCodePasswordNotMixed = RegisterErrorCode(ErrorCode{
+		Code: "password.not_mixed", Status: http.StatusBadRequest,
+		Message: "must contain at least one letter and one digit", Example: "must contain at least one letter and one digit",
+	})
+	ErrCodePasswordRejected = RegisterErrorCode(ErrorCode{
+		Code: "password.rejected", Status: http.StatusBadRequest,
+		Message: "{reason}", Params: []string{"reason"}, Example: "must contain at least one letter and one digit",
+	})
+)
+
+var (
+	// PasswordMinLength is the minimum number of characters (runes) a
+	// Password must have on unmarshal.
+	PasswordMinLength = 8
+
+	// PasswordComplexity, when set, runs after the length check; the error
+	// message it returns is sent back to the client as a 400.
+	PasswordComplexity func(password string) error
+)
+
+const passwordMask = "***"
+
+// Password is write-only: it unmarshals normally but marshals as `null` (or
+// nothing, in text and XML) and prints as "***", so echoing a request or
+// logging it does not leak it.
+type Password string
+
+// Plaintext is the only way to read the secret back, e.g. to hash it.
+func (p Password) Plaintext() string {
+	return string(p)
+}
+
+// Equal compares in constant time.
+func (p Password) Equal(other string) bool {
+	return subtle.ConstantTimeCompare([]byte(p), []byte(other)) == 1
+}
+
+func (p Password) String() string {
+	return passwordMask
+}
+
+func (p Password) GoString() string {
+	return passwordMask
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (p Password) MarshalJSON() ([]byte, error) {
+	return []byte("null"), nil
+}
+
+/*
+	This part implements `encoding.TextMarshaler`
+	type TextMarshaler interface {
+		MarshalText() (text []byte, err error)
+	}
+
+	A Password is a string underneath, so without this text encoders would
+	write the plaintext.
+*/
+func (p Password) MarshalText() ([]byte, error) {
+	return nil, nil
+}
+
+/*
+	This part implements `xml.Marshaler`
+	type Marshaler interface {
+		MarshalXML(e *Encoder, start StartElement) error
+	}
+
+	The element is left out, as MarshalJSON writes null.
+*/
+func (p Password) MarshalXML(*xml.Encoder, xml.StartElement) error {
+	return nil
+}
+
+/*
+	This part implements `xml.MarshalerAttr`
+	type MarshalerAttr interface {
+		MarshalXMLAttr(name Name) (Attr, error)
+	}
+*/
+func (p Password) MarshalXMLAttr(xml.Name) (xml.Attr, error) {
+	return xml.Attr{}, nil
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (p *Password) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodePasswordNotString.Err(nil)
+	}
+	if s == "" {
+		return ErrCodePasswordEmpty.Err(nil)
+	}
+	if utf8.RuneCountInString(s) < PasswordMinLength {
+		return ErrCodePasswordTooShort.Err(map[string]string{"min": strconv.Itoa(PasswordMinLength)})
+	}
+	if PasswordComplexity != nil {
+		if err := PasswordComplexity(s); err != nil {
+			return codedOr(err, ErrCodePasswordRejected.Err(map[string]string{"reason": err.Error()}))
+		}
+	}
+
+	*p = Password(s)
+
+	return nil
+}
+
+// PasswordRequireMixed is a ready-made PasswordComplexity hook asking for at
+// least one letter and one digit.
+func PasswordRequireMixed(password string) error {
+	hasLetter, hasDigit := false, false
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return ErrCodePasswordNotMixed.Err(nil)
+	}
+	return nil
+}
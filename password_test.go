@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/vmihailenco/msgpack/v5"
+	"go.mongodb.org/mongo-driver/bson"
+	"gopkg.in/yaml.v3"
+)
+
+const testPassword = Password("hunter2hunter2")
+
+type passwordHolder struct {
+	XMLName  xml.Name `xml:"account" yaml:"-" msgpack:"-" cbor:"-" bson:"-"`
+	Handle   string   `xml:"handle,attr" yaml:"handle" msgpack:"handle" cbor:"handle" bson:"handle"`
+	Secret   Password `xml:"secret,attr" yaml:"-" msgpack:"-" cbor:"-" bson:"-"`
+	Password Password `xml:"password" yaml:"password" msgpack:"password" cbor:"password" bson:"password"`
+}
+
+func leaksPassword(t *testing.T, format string, encoded []byte, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s: %v", format, err)
+	}
+	if strings.Contains(string(encoded), testPassword.Plaintext()) {
+		t.Errorf("%s wrote the plaintext: %q", format, encoded)
+	}
+}
+
+func TestPasswordXML(t *testing.T) {
+	b, err := xml.Marshal(passwordHolder{Handle: "devi", Secret: testPassword, Password: testPassword})
+	leaksPassword(t, "XML", b, err)
+	if want := `<account handle="devi"></account>`; string(b) != want {
+		t.Errorf("XML %s, want %s", b, want)
+	}
+
+	text, err := testPassword.MarshalText()
+	leaksPassword(t, "text", text, err)
+}
+
+func TestPasswordYAML(t *testing.T) {
+	b, err := yaml.Marshal(passwordHolder{Handle: "devi", Password: testPassword})
+	leaksPassword(t, "YAML", b, err)
+	if want := "handle: devi\npassword: null\n"; string(b) != want {
+		t.Errorf("YAML %q, want %q", b, want)
+	}
+}
+
+func TestPasswordMsgpack(t *testing.T) {
+	b, err := msgpack.Marshal(passwordHolder{Handle: "devi", Password: testPassword})
+	leaksPassword(t, "msgpack", b, err)
+	var back map[string]interface{}
+	if err := msgpack.Unmarshal(b, &back); err != nil || back["password"] != nil {
+		t.Errorf("msgpack decoded %v, %v, want a nil password", back, err)
+	}
+}
+
+func TestPasswordCBOR(t *testing.T) {
+	b, err := cbor.Marshal(passwordHolder{Handle: "devi", Password: testPassword})
+	leaksPassword(t, "CBOR", b, err)
+	var back map[string]interface{}
+	if err := cbor.Unmarshal(b, &back); err != nil || len(back) != 2 || back["password"] != nil {
+		t.Errorf("CBOR decoded %v, %v, want a null password", back, err)
+	}
+}
+
+func TestPasswordBSON(t *testing.T) {
+	b, err := bson.Marshal(passwordHolder{Handle: "devi", Password: testPassword})
+	leaksPassword(t, "BSON", b, err)
+	var back bson.M
+	if err := bson.Unmarshal(b, &back); err != nil || len(back) != 2 || back["password"] != nil {
+		t.Errorf("BSON decoded %v, %v, want a null password", back, err)
+	}
+}
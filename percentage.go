@@ -0,0 +1,359 @@
+package main
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrCodePercentageNotString = RegisterErrorCode(ErrorCode{
+		Code: "percentage.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodePercentageEmpty = RegisterErrorCode(ErrorCode{
+		Code: "percentage.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodePercentageInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "percentage.invalid_format", Status: http.StatusBadRequest,
+		Message: "must be a number or a percentage like 12.5%", Params: []string{"value"}, Example: "must be a number or a percentage like 12.5%",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodePercentagePrecision = RegisterErrorCode(ErrorCode{
+		Code: "percentage.precision", Status: http.StatusBadRequest,
+		Message: "must not have more than 4 decimal places of a percent", Params: []string{"value"}, Example: "must not have more than 4 decimal places of a percent",
+	})
+	ErrCodePercentageTooLarge = RegisterErrorCode(ErrorCode{
+		Code: "percentage.too_large", Status: http.StatusBadRequest,
+		Message: "is too large", Params: []string{"value"}, Example: "is too large",
+	})
+	ErrCodePercentageOutOfRange = RegisterErrorCode(ErrorCode{
+		Code: "percentage.out_of_range", Status: http.StatusBadRequest,
+		Message: "must be between {min} and {max}", Params: []string{"min", "max", "value"}, Example: "must be between 0% and 100%",
+	})
+	ErrCodeBasisPointsInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "basis_points.invalid_format", Status: http.StatusBadRequest,
+		Message: "must be a whole number of basis points", Params: []string{"value"}, Example: "must be a whole number of basis points",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeBasisPointsOutOfRange = RegisterErrorCode(ErrorCode{
+		Code: "basis_points.out_of_range", Status: http.StatusBadRequest,
+		Message: "must be between {min} and {max}", Params: []string{"min", "max", "value"}, Example: "must be between 0bp and 10000bp",
+	})
+)
+
+// Percentage is stored as an integer number of millionths (parts per
+// million), so 12.5% is exactly 125000 and no float rounding creeps into
+// discount or tax calculations. Like time.Duration, use the unit constants:
+//
+//	discount := 12*Percent + 50*BasisPoint // 12.5%
+type Percentage int64
+
+const (
+	BasisPoint Percentage = 100
+	Percent    Percentage = 100 * BasisPoint
+)
+
+// PercentageNumberMode decides how a bare JSON number is read. Strings with
+// a "%" suffix are always percents.
+type PercentageNumberMode int
+
+const (
+	// PercentageNumberAsPercent reads 12.5 as 12.5%.
+	PercentageNumberAsPercent PercentageNumberMode = iota
+	// PercentageNumberAsFraction reads 0.125 as 12.5%.
+	PercentageNumberAsFraction
+)
+
+var (
+	// PercentageNumbers is the mode used for both reading and writing JSON
+	// numbers, so a value always round-trips.
+	PercentageNumbers = PercentageNumberAsPercent
+
+	// PercentageMin and PercentageMax bound Percentage and BasisPoints on
+	// unmarshal. Widen them for values such as markups above 100%.
+	PercentageMin Percentage = 0
+	PercentageMax            = 100 * Percent
+)
+
+var (
+	decimalPattern = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+	percentageUnit = big.NewRat(int64(Percent), 1)
+	fractionUnit   = big.NewRat(int64(100*Percent), 1)
+)
+
+// ParsePercentage reads "12.5%", or a bare number according to
+// PercentageNumbers.
+func ParsePercentage(s string) (Percentage, error) {
+	s = strings.TrimSpace(s)
+	unit := percentageUnit
+	if strings.HasSuffix(s, "%") {
+		s = strings.TrimSpace(strings.TrimSuffix(s, "%"))
+	} else if PercentageNumbers == PercentageNumberAsFraction {
+		unit = fractionUnit
+	}
+
+	if !decimalPattern.MatchString(s) {
+		return 0, ErrCodePercentageInvalidFormat.Err(map[string]string{"value": s})
+	}
+	n, _ := new(big.Rat).SetString(s)
+	n.Mul(n, unit)
+	if !n.IsInt() {
+		return 0, ErrCodePercentagePrecision.Err(map[string]string{"value": s})
+	}
+	if !n.Num().IsInt64() {
+		return 0, ErrCodePercentageTooLarge.Err(map[string]string{"value": s})
+	}
+	p := Percentage(n.Num().Int64())
+	if p < PercentageMin || p > PercentageMax {
+		return 0, ErrCodePercentageOutOfRange.Err(map[string]string{"min": PercentageMin.String(), "max": PercentageMax.String(), "value": s})
+	}
+	return p, nil
+}
+
+// Percent returns the value in percent, e.g. 12.5.
+func (p Percentage) Percent() float64 {
+	return float64(p) / float64(Percent)
+}
+
+// Fraction returns the value as a ratio, e.g. 0.125.
+func (p Percentage) Fraction() float64 {
+	return float64(p) / float64(100*Percent)
+}
+
+// BasisPoints truncates anything finer than a basis point.
+func (p Percentage) BasisPoints() BasisPoints {
+	return BasisPoints(p / BasisPoint)
+}
+
+// Of returns p of amount, rounded half away from zero. amount is meant to
+// be in minor units (cents), e.g. 12.5% of 1999 is 250.
+func (p Percentage) Of(amount int64) int64 {
+	r := new(big.Rat).SetFrac(new(big.Int).Mul(big.NewInt(amount), big.NewInt(int64(p))), big.NewInt(int64(100*Percent)))
+	return roundRat(r)
+}
+
+func roundRat(r *big.Rat) int64 {
+	// floor((2|num| + den) / 2den), then the sign back.
+	num := new(big.Int).Abs(r.Num())
+	num.Mul(num, big.NewInt(2)).Add(num, r.Denom())
+	q := num.Quo(num, new(big.Int).Mul(r.Denom(), big.NewInt(2)))
+	if r.Sign() < 0 {
+		q.Neg(q)
+	}
+	return q.Int64()
+}
+
+func (p Percentage) number() string {
+	unit := int64(Percent)
+	if PercentageNumbers == PercentageNumberAsFraction {
+		unit = int64(100 * Percent)
+	}
+	return formatDecimal(int64(p), unit)
+}
+
+// formatDecimal prints n/unit exactly, where unit is a power of ten.
+func formatDecimal(n int64, unit int64) string {
+	sign := ""
+	if n < 0 {
+		sign, n = "-", -n
+	}
+	s := sign + strconv.FormatInt(n/unit, 10)
+	if frac := n % unit; frac != 0 {
+		digits := len(strconv.FormatInt(unit, 10)) - 1
+		f := strconv.FormatInt(frac, 10)
+		f = strings.Repeat("0", digits-len(f)) + f
+		s += "." + strings.TrimRight(f, "0")
+	}
+	return s
+}
+
+func (p Percentage) String() string {
+	return formatDecimal(int64(p), int64(Percent)) + "%"
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+
+	Written as a JSON number in the PercentageNumbers mode.
+*/
+func (p Percentage) MarshalJSON() ([]byte, error) {
+	return []byte(p.number()), nil
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (p *Percentage) UnmarshalJSON(b []byte) error {
+	s, err := percentageInput(b)
+	if err != nil {
+		return err
+	}
+	parsed, err := ParsePercentage(s)
+	if err != nil {
+		return err
+	}
+
+	*p = parsed
+
+	return nil
+}
+
+/*
+	This part implements `encoding.TextMarshaler`
+	type TextMarshaler interface {
+		MarshalText() (text []byte, err error)
+	}
+*/
+func (p Percentage) MarshalText() ([]byte, error) {
+	return []byte(p.number()), nil
+}
+
+/*
+	This part implements `encoding.TextUnmarshaler`
+	type TextUnmarshaler interface {
+		UnmarshalText(text []byte) error
+	}
+*/
+func (p *Percentage) UnmarshalText(b []byte) error {
+	return unmarshalTextAsJSON(p, b)
+}
+
+/*
+	This part implements BurntSushi/toml's `toml.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalTOML(interface{}) error
+	}
+*/
+func (p *Percentage) UnmarshalTOML(value interface{}) error {
+	return unmarshalValueAsJSON(p, value)
+}
+
+// percentageInput accepts a JSON number or a non-empty JSON string.
+func percentageInput(b []byte) (string, error) {
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return "", ErrCodePercentageNotString.Err(map[string]string{"value": string(b)})
+		}
+		if s == "" {
+			return "", ErrCodePercentageEmpty.Err(nil)
+		}
+		return s, nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return "", ErrCodePercentageInvalidFormat.Err(map[string]string{"value": string(b)})
+	}
+	return n.String(), nil
+}
+
+// BasisPoints is a whole number of hundredths of a percent: 125 is 1.25%.
+type BasisPoints int64
+
+// ParseBasisPoints reads "125", "125bp", "125bps" or "1.25%".
+func ParseBasisPoints(s string) (BasisPoints, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasSuffix(s, "%") {
+		p, err := ParsePercentage(s)
+		if err != nil {
+			return 0, err
+		}
+		if p%BasisPoint != 0 {
+			return 0, ErrCodeBasisPointsInvalidFormat.Err(map[string]string{"value": s})
+		}
+		return p.BasisPoints(), nil
+	}
+
+	s = strings.TrimSpace(strings.TrimSuffix(strings.TrimSuffix(s, "bps"), "bp"))
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, ErrCodeBasisPointsInvalidFormat.Err(map[string]string{"value": s})
+	}
+	bp := BasisPoints(n)
+	if bp < PercentageMin.BasisPoints() || bp > PercentageMax.BasisPoints() {
+		return 0, ErrCodeBasisPointsOutOfRange.Err(map[string]string{"min": PercentageMin.BasisPoints().String(), "max": PercentageMax.BasisPoints().String(), "value": s})
+	}
+	return bp, nil
+}
+
+func (bp BasisPoints) Percentage() Percentage {
+	return Percentage(bp) * BasisPoint
+}
+
+func (bp BasisPoints) String() string {
+	return strconv.FormatInt(int64(bp), 10) + "bp"
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (bp BasisPoints) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(bp), 10)), nil
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (bp *BasisPoints) UnmarshalJSON(b []byte) error {
+	s, err := percentageInput(b)
+	if err != nil {
+		return err
+	}
+	parsed, err := ParseBasisPoints(s)
+	if err != nil {
+		return err
+	}
+
+	*bp = parsed
+
+	return nil
+}
+
+/*
+	This part implements `encoding.TextMarshaler`
+	type TextMarshaler interface {
+		MarshalText() (text []byte, err error)
+	}
+*/
+func (bp BasisPoints) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatInt(int64(bp), 10)), nil
+}
+
+/*
+	This part implements `encoding.TextUnmarshaler`
+	type TextUnmarshaler interface {
+		UnmarshalText(text []byte) error
+	}
+*/
+func (bp *BasisPoints) UnmarshalText(b []byte) error {
+	return unmarshalTextAsJSON(bp, b)
+}
+
+/*
+	This part implements BurntSushi/toml's `toml.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalTOML(interface{}) error
+	}
+*/
+func (bp *BasisPoints) UnmarshalTOML(value interface{}) error {
+	return unmarshalValueAsJSON(bp, value)
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrCodePeriodNotString = RegisterErrorCode(ErrorCode{
+		Code: "period.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodePeriodEmpty = RegisterErrorCode(ErrorCode{
+		Code: "period.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodePeriodInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "period.invalid_format", Status: http.StatusBadRequest,
+		Message: "format must be an ISO-8601 duration like P1DT2H30M", Params: []string{"value"}, Example: "format must be an ISO-8601 duration like P1DT2H30M",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodePeriodOutOfRange = RegisterErrorCode(ErrorCode{
+		Code: "period.out_of_range", Status: http.StatusBadRequest,
+		Message: "value out of range", Params: []string{"value"}, Example: "value out of range",
+	})
+)
+
+var periodPattern = regexp.MustCompile(`^([-+]?)P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+
+// Period is an ISO-8601 duration such as "P1DT2H30M". Unlike `Duration` it
+// keeps calendar components (years, months, days) separately, since their
+// length depends on the date they are applied to.
+type Period struct {
+	negative bool
+	years    int
+	months   int
+	weeks    int
+	days     int
+	hours    int
+	minutes  int
+	seconds  time.Duration
+}
+
+func ParsePeriod(s string) (Period, error) {
+	m := periodPattern.FindStringSubmatch(s)
+	if m == nil || strings.Join(m[2:], "") == "" || strings.HasSuffix(s, "T") {
+		return Period{}, ErrCodePeriodInvalidFormat.Err(map[string]string{"value": s})
+	}
+
+	var components [6]int
+	for i, v := range m[2:8] {
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return Period{}, ErrCodePeriodOutOfRange.Err(map[string]string{"value": s})
+		}
+		components[i] = n
+	}
+
+	p := Period{
+		negative: m[1] == "-",
+		years:    components[0],
+		months:   components[1],
+		weeks:    components[2],
+		days:     components[3],
+		hours:    components[4],
+		minutes:  components[5],
+	}
+	if m[8] != "" {
+		seconds, err := strconv.ParseFloat(strings.Replace(m[8], ",", ".", 1), 64)
+		if err != nil {
+			return Period{}, ErrCodePeriodInvalidFormat.Err(map[string]string{"value": s})
+		}
+		if seconds >= float64(math.MaxInt64)/float64(time.Second) {
+			return Period{}, ErrCodePeriodOutOfRange.Err(map[string]string{"value": s})
+		}
+		p.seconds = time.Duration(seconds * float64(time.Second))
+	}
+
+	return p, nil
+}
+
+func (p Period) Years() int {
+	return p.years
+}
+
+func (p Period) Months() int {
+	return p.months
+}
+
+func (p Period) Days() int {
+	return p.weeks*7 + p.days
+}
+
+func (p Period) IsZero() bool {
+	return p.years == 0 && p.months == 0 && p.weeks == 0 && p.days == 0 &&
+		p.hours == 0 && p.minutes == 0 && p.seconds == 0
+}
+
+// Duration converts the period into an exact `time.Duration`. Days and weeks
+// are counted as 24 hours; years and months have no fixed length, so any
+// period carrying them returns an error instead of a guess. A period longer
+// than time.Duration can hold, about 292 years, is out of range.
+func (p Period) Duration() (time.Duration, error) {
+	if p.years != 0 || p.months != 0 {
+		return 0, errors.New("period with years or months has no fixed duration, use AddTo instead")
+	}
+	days, ok := multiplyInt64(int64(p.weeks), 7)
+	if ok {
+		days, ok = addInt64(days, int64(p.days))
+	}
+	if ok {
+		days, ok = multiplyInt64(days, int64(24*time.Hour))
+	}
+	clock, clockOK := p.clock()
+	d, sumOK := addInt64(days, int64(clock))
+	if !ok || !clockOK || !sumOK {
+		return 0, errors.New("value out of range")
+	}
+	if p.negative {
+		d = -d
+	}
+	return time.Duration(d), nil
+}
+
+// AddTo applies the period to t, resolving years, months and days on the
+// calendar and the time components as elapsed time. It fails if the time
+// components are longer than time.Duration can hold.
+func (p Period) AddTo(t time.Time) (time.Time, error) {
+	clock, ok := p.clock()
+	if !ok {
+		return time.Time{}, errors.New("value out of range")
+	}
+	sign := 1
+	if p.negative {
+		sign = -1
+	}
+	t = t.AddDate(sign*p.years, sign*p.months, sign*p.Days())
+	return t.Add(time.Duration(sign) * clock), nil
+}
+
+// clock sums the hours, minutes and seconds, reporting false if the total
+// overflows a time.Duration.
+func (p Period) clock() (time.Duration, bool) {
+	hours, ok := multiplyInt64(int64(p.hours), int64(time.Hour))
+	if !ok {
+		return 0, false
+	}
+	minutes, ok := multiplyInt64(int64(p.minutes), int64(time.Minute))
+	if !ok {
+		return 0, false
+	}
+	clock, ok := addInt64(hours, minutes)
+	if !ok {
+		return 0, false
+	}
+	clock, ok = addInt64(clock, int64(p.seconds))
+	return time.Duration(clock), ok
+}
+
+// addInt64 returns a + b, reporting false if the sum overflows.
+func addInt64(a int64, b int64) (int64, bool) {
+	sum := a + b
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, false
+	}
+	return sum, true
+}
+
+func (p Period) String() string {
+	if p.IsZero() {
+		return "PT0S"
+	}
+
+	var sb strings.Builder
+	if p.negative {
+		sb.WriteByte('-')
+	}
+	sb.WriteByte('P')
+	writePart := func(n int, unit byte) {
+		if n != 0 {
+			sb.WriteString(strconv.Itoa(n))
+			sb.WriteByte(unit)
+		}
+	}
+	writePart(p.years, 'Y')
+	writePart(p.months, 'M')
+	writePart(p.weeks, 'W')
+	writePart(p.days, 'D')
+	if p.hours != 0 || p.minutes != 0 || p.seconds != 0 {
+		sb.WriteByte('T')
+		writePart(p.hours, 'H')
+		writePart(p.minutes, 'M')
+		if p.seconds != 0 {
+			sb.WriteString(strconv.FormatFloat(p.seconds.Seconds(), 'f', -1, 64))
+			sb.WriteByte('S')
+		}
+	}
+	return sb.String()
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (p Period) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (p *Period) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodePeriodNotString.Err(nil)
+	}
+	if s == "" {
+		return ErrCodePeriodEmpty.Err(nil)
+	}
+	parsed, err := ParsePeriod(s)
+	if err != nil {
+		return err
+	}
+
+	*p = parsed
+
+	return nil
+}
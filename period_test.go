@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePeriodOutOfRange(t *testing.T) {
+	for _, s := range []string{
+		"P99999999999999999999D",
+		"P99999999999999999999Y",
+		"PT99999999999999999999H",
+		"PT9999999999999999999.5S",
+	} {
+		if p, err := ParsePeriod(s); err == nil || err.Error() != "value out of range" {
+			t.Errorf("ParsePeriod(%q) = %s, %v, want a value out of range error", s, p, err)
+		}
+	}
+
+	p, err := ParsePeriod("P9223372036854775807D")
+	if err != nil {
+		t.Fatalf("largest int day count: %v", err)
+	}
+	if got := p.String(); got != "P9223372036854775807D" {
+		t.Errorf("round trip %s, want P9223372036854775807D", got)
+	}
+}
+
+func TestPeriodDurationOutOfRange(t *testing.T) {
+	for _, s := range []string{
+		"P200000D",
+		"PT9223372036854775807H",
+		"P9223372036854775807D",
+		"P1307352WT1H",
+		"PT2562047H47M17S",
+	} {
+		p, err := ParsePeriod(s)
+		if err != nil {
+			t.Fatalf("ParsePeriod(%q): %v", s, err)
+		}
+		if d, err := p.Duration(); err == nil || err.Error() != "value out of range" {
+			t.Errorf("%s.Duration() = %s, %v, want a value out of range error", s, d, err)
+		}
+	}
+
+	p, err := ParsePeriod("-P106751DT23H47M16.854775807S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d, err := p.Duration(); err != nil || d != -time.Duration(1<<63-1) {
+		t.Errorf("longest period: %s, %v", d, err)
+	}
+}
+
+func TestPeriodAddToOutOfRange(t *testing.T) {
+	start := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	p, err := ParsePeriod("PT9223372036854775807H")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := p.AddTo(start); err == nil || err.Error() != "value out of range" {
+		t.Errorf("AddTo = %s, %v, want a value out of range error", got, err)
+	}
+
+	p, err = ParsePeriod("P1M2DT3H")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := p.AddTo(start); err != nil || !got.Equal(time.Date(2020, time.February, 3, 3, 0, 0, 0, time.UTC)) {
+		t.Errorf("AddTo = %s, %v, want 2020-02-03T03:00:00Z", got, err)
+	}
+}
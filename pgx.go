@@ -0,0 +1,206 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// With pgx's own interface (pgx.Conn, pgxpool) the types are encoded and
+// decoded by pgx's codecs in the binary protocol rather than through their
+// text Scan and Value:
+//
+//	DateTime     timestamptz
+//	Date         date
+//	ArrayString  text[], element by element, so commas need no escaping
+//	DateRange    daterange
+//	TimeRange    tstzrange
+//	JSONRaw      jsonb
+//
+// RegisterPgxTypes also makes them the default for their Postgres type,
+// which pgx needs where the server does not say the parameter type, as in
+// the simple protocol and CopyFrom. Register on every connection:
+//
+//	config.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+//		RegisterPgxTypes(conn.TypeMap())
+//		return nil
+//	}
+//
+// database/sql, including pgx's stdlib driver, still goes through Scan and
+// Value, see sql.go.
+func RegisterPgxTypes(m *pgtype.Map) {
+	m.RegisterDefaultPgType(DateTime{}, "timestamptz")
+	m.RegisterDefaultPgType(Date{}, "date")
+	m.RegisterDefaultPgType(ArrayString{}, "_text")
+	m.RegisterDefaultPgType(DateRange{}, "daterange")
+	m.RegisterDefaultPgType(TimeRange{}, "tstzrange")
+	m.RegisterDefaultPgType(JSONRaw{}, "jsonb")
+}
+
+/*
+	This part implements `pgtype.TimestamptzValuer`
+	type TimestamptzValuer interface {
+		TimestamptzValue() (Timestamptz, error)
+	}
+*/
+func (dt DateTime) TimestamptzValue() (pgtype.Timestamptz, error) {
+	return pgtype.Timestamptz{Time: dt.Time(), Valid: true}, nil
+}
+
+/*
+	This part implements `pgtype.TimestamptzScanner`
+	type TimestamptzScanner interface {
+		ScanTimestamptz(v Timestamptz) error
+	}
+
+	NULL is read as the zero time, like Scan; infinity is rejected.
+*/
+func (dt *DateTime) ScanTimestamptz(v pgtype.Timestamptz) error {
+	if !v.Valid {
+		*dt = dt.withTime(time.Time{})
+		return nil
+	}
+	if v.InfinityModifier != pgtype.Finite {
+		return fmt.Errorf("cannot scan %s into DateTime", v.InfinityModifier)
+	}
+	return dt.setTime(v.Time.In(dt.instantLocation()))
+}
+
+func (d Date) DateValue() (pgtype.Date, error) {
+	return pgtype.Date{Time: d.time, Valid: true}, nil
+}
+
+func (d *Date) ScanDate(v pgtype.Date) error {
+	if !v.Valid {
+		*d = Date{}
+		return nil
+	}
+	if v.InfinityModifier != pgtype.Finite {
+		return fmt.Errorf("cannot scan %s into Date", v.InfinityModifier)
+	}
+	*d = NewDate(v.Time.Date())
+	return nil
+}
+
+/*
+	This part implements `pgtype.ArrayGetter` and `pgtype.ArraySetter`
+	type ArrayGetter interface {
+		Dimensions() []ArrayDimension
+		Index(i int) any
+		IndexType() any
+	}
+	type ArraySetter interface {
+		SetDimensions(dimensions []ArrayDimension) error
+		ScanIndex(i int) any
+		ScanIndexType() any
+	}
+
+	A multi-dimensional array is flattened.
+*/
+func (dt ArrayString) Dimensions() []pgtype.ArrayDimension {
+	return pgtype.FlatArray[string](dt).Dimensions()
+}
+
+func (dt ArrayString) Index(i int) interface{} {
+	return dt[i]
+}
+
+func (dt ArrayString) IndexType() interface{} {
+	return ""
+}
+
+func (dt *ArrayString) SetDimensions(dimensions []pgtype.ArrayDimension) error {
+	list := (*pgtype.FlatArray[string])(dt)
+	return list.SetDimensions(dimensions)
+}
+
+func (dt ArrayString) ScanIndex(i int) interface{} {
+	return &dt[i]
+}
+
+func (dt ArrayString) ScanIndexType() interface{} {
+	return new(string)
+}
+
+/*
+	This part implements `pgtype.RangeValuer` and `pgtype.RangeScanner`
+	type RangeValuer interface {
+		IsNull() bool
+		BoundTypes() (lower, upper BoundType)
+		Bounds() (lower, upper any)
+	}
+	type RangeScanner interface {
+		ScanNull() error
+		ScanBounds() (lowerTarget, upperTarget any)
+		SetBoundTypes(lower, upper BoundType) error
+	}
+
+	The range is sent as [from,to+1 day), the canonical form Postgres
+	stores.
+*/
+func (dr DateRange) IsNull() bool {
+	return dr.IsZero()
+}
+
+func (dr DateRange) BoundTypes() (pgtype.BoundType, pgtype.BoundType) {
+	return pgtype.Inclusive, pgtype.Exclusive
+}
+
+func (dr DateRange) Bounds() (interface{}, interface{}) {
+	return dr.from, dr.to.AddDays(1)
+}
+
+func (dr *DateRange) ScanNull() error {
+	*dr = DateRange{}
+	return nil
+}
+
+func (dr *DateRange) ScanBounds() (interface{}, interface{}) {
+	return &dr.from, &dr.to
+}
+
+func (dr *DateRange) SetBoundTypes(lower, upper pgtype.BoundType) error {
+	if lower == pgtype.Unbounded || lower == pgtype.Empty || upper == pgtype.Unbounded {
+		*dr = DateRange{}
+		return errors.New("cannot scan an empty or unbounded range into DateRange")
+	}
+	if lower == pgtype.Exclusive {
+		dr.from = dr.from.AddDays(1)
+	}
+	if upper == pgtype.Exclusive {
+		dr.to = dr.to.AddDays(-1)
+	}
+	return nil
+}
+
+func (tr TimeRange) IsNull() bool {
+	return tr.IsZero()
+}
+
+func (tr TimeRange) BoundTypes() (pgtype.BoundType, pgtype.BoundType) {
+	return pgtype.Inclusive, pgtype.Exclusive
+}
+
+func (tr TimeRange) Bounds() (interface{}, interface{}) {
+	return tr.from, tr.to
+}
+
+func (tr *TimeRange) ScanNull() error {
+	*tr = TimeRange{}
+	return nil
+}
+
+func (tr *TimeRange) ScanBounds() (interface{}, interface{}) {
+	return &tr.from, &tr.to
+}
+
+// SetBoundTypes accepts only [from,to), the one form a TimeRange has.
+func (tr *TimeRange) SetBoundTypes(lower, upper pgtype.BoundType) error {
+	if lower != pgtype.Inclusive || upper != pgtype.Exclusive {
+		*tr = TimeRange{}
+		return errors.New("cannot scan into TimeRange: want a [lower,upper) range")
+	}
+	return nil
+}
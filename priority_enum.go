@@ -0,0 +1,124 @@
+// Code generated by enumgen; DO NOT EDIT.
+
+package main
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+type Priority string
+
+const (
+	PriorityLow    Priority = "low"
+	PriorityNormal Priority = "normal"
+	PriorityHigh   Priority = "high"
+	PriorityUrgent Priority = "urgent"
+)
+
+const _PriorityExpected = "must be one of low, normal, high, urgent"
+
+var (
+	ErrCodePriorityNotString = RegisterErrorCode(ErrorCode{
+		Code: "priority.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Params: []string{"value"}, Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodePriorityEmpty = RegisterErrorCode(ErrorCode{
+		Code: "priority.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodePriorityInvalidValue = RegisterErrorCode(ErrorCode{
+		Code: "priority.invalid_value", Status: http.StatusBadRequest,
+		Message: _PriorityExpected, Params: []string{"value"}, Example: _PriorityExpected,
+	})
+)
+
+var _PriorityValues = []Priority{
+	PriorityLow,
+	PriorityNormal,
+	PriorityHigh,
+	PriorityUrgent,
+}
+
+// PriorityValues returns every declared Priority, in declaration order.
+func PriorityValues() []Priority {
+	return append([]Priority(nil), _PriorityValues...)
+}
+
+func (e Priority) IsValid() bool {
+	switch e {
+	case PriorityLow, PriorityNormal, PriorityHigh, PriorityUrgent:
+		return true
+	}
+	return false
+}
+
+func (e Priority) String() string {
+	return string(e)
+}
+
+func (e Priority) MarshalText() ([]byte, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("invalid Priority %q", string(e))
+	}
+	return []byte(e), nil
+}
+
+func (e *Priority) UnmarshalText(b []byte) error {
+	v := Priority(b)
+	if !v.IsValid() {
+		return errors.New(_PriorityExpected)
+	}
+	*e = v
+	return nil
+}
+
+func (e Priority) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(e))
+}
+
+func (e *Priority) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodePriorityNotString.Err(map[string]string{"value": string(b)})
+	}
+	if s == "" {
+		return ErrCodePriorityEmpty.Err(nil)
+	}
+	v := Priority(s)
+	if !v.IsValid() {
+		return ErrCodePriorityInvalidValue.Err(map[string]string{"value": s})
+	}
+	*e = v
+	return nil
+}
+
+func (e *Priority) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Priority", src)
+	}
+	v := Priority(s)
+	if !v.IsValid() {
+		return fmt.Errorf("cannot scan %q into Priority: %s", s, _PriorityExpected)
+	}
+	*e = v
+	return nil
+}
+
+func (e Priority) Value() (driver.Value, error) {
+	if !e.IsValid() {
+		return nil, fmt.Errorf("invalid Priority %q", string(e))
+	}
+	return string(e), nil
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ProblemContentType is the media type of a Problem response.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 Problem Details document. Type is "about:blank",
+// meaning the status code says it all, unless a more specific URI is set.
+// Code, the catalog code, is an extension member.
+type Problem struct {
+	Type     string         `json:"type"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status"`
+	Detail   string         `json:"detail,omitempty"`
+	Instance string         `json:"instance,omitempty"`
+	Code     string         `json:"code,omitempty"`
+	Errors   []ProblemField `json:"errors,omitempty"`
+}
+
+// ProblemField is one entry of Problem.Errors, an invalid request field.
+type ProblemField struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+}
+
+// NewProblem builds the document for status about the current request.
+// Messages are localized like ErrorMiddleware's.
+func NewProblem(ctx *gin.Context, status int, detail string, fields FieldErrors) Problem {
+	locale := requestLocale(ctx)
+	problem := Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   localize(locale, "", detail, nil),
+		Instance: ctx.Request.URL.Path,
+	}
+	for _, e := range fields {
+		problem.Errors = append(problem.Errors, ProblemField{Field: e.Field, Message: localize(locale, e.Code, e.Message, e.Params), Code: e.Code})
+	}
+	return problem
+}
+
+// WriteProblem aborts the request with problem as the response.
+func WriteProblem(ctx *gin.Context, problem Problem) {
+	ctx.Header("Content-Type", ProblemContentType)
+	ctx.AbortWithStatusJSON(problem.Status, problem)
+}
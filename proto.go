@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ToProto and FromProto convert DateTime, NullDateTime and Duration to and
+// from the well-known google.protobuf.Timestamp and Duration, so a gRPC
+// handler and an HTTP handler can fill the same domain struct:
+//
+//	var order Order
+//	if err := order.PlacedAt.FromProto(req.GetPlacedAt()); err != nil {
+//		return nil, status.Error(codes.InvalidArgument, err.Error())
+//	}
+//	...
+//	return &pb.Order{PlacedAt: order.PlacedAt.ToProto()}, nil
+//
+// A zero value is sent as nil, the unset message, and nil is read as the
+// zero value. A Timestamp has no offset, so FromProto puts the instant in
+// the field's default location, or DateTimeDefaultLocation, or UTC, and
+// applies the field's precision and constraints.
+
+func (dt DateTime) ToProto() *timestamppb.Timestamp {
+	if dt.IsZero() {
+		return nil
+	}
+	return timestamppb.New(dt.Time())
+}
+
+func (dt *DateTime) FromProto(ts *timestamppb.Timestamp) error {
+	if ts == nil {
+		*dt = dt.withTime(time.Time{})
+		return nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return fmt.Errorf("cannot convert Timestamp to DateTime: %w", err)
+	}
+	return dt.setTime(ts.AsTime().In(dt.instantLocation()))
+}
+
+func (ndt NullDateTime) ToProto() *timestamppb.Timestamp {
+	if ndt.IsZero() {
+		return nil
+	}
+	return ndt.DateTime.ToProto()
+}
+
+func (ndt *NullDateTime) FromProto(ts *timestamppb.Timestamp) error {
+	if ts == nil {
+		ndt.DateTime, ndt.Valid = ndt.DateTime.withTime(time.Time{}), false
+		return nil
+	}
+	if err := ndt.DateTime.FromProto(ts); err != nil {
+		return err
+	}
+	ndt.Valid = true
+	return nil
+}
+
+func (d Duration) ToProto() *durationpb.Duration {
+	if d.IsZero() {
+		return nil
+	}
+	return durationpb.New(d.duration)
+}
+
+// FromProto rejects a Duration beyond the ±290 years time.Duration holds.
+func (d *Duration) FromProto(pd *durationpb.Duration) error {
+	if pd == nil {
+		d.duration = 0
+		return nil
+	}
+	if err := pd.CheckValid(); err != nil {
+		return fmt.Errorf("cannot convert Duration: %w", err)
+	}
+	// AsDuration saturates, so an out-of-range value does not convert back.
+	duration := pd.AsDuration()
+	if back := durationpb.New(duration); back.Seconds != pd.Seconds || back.Nanos != pd.Nanos {
+		return fmt.Errorf("cannot convert Duration: %ds is out of range", pd.Seconds)
+	}
+	d.duration = duration
+	return nil
+}
@@ -0,0 +1,578 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrCodeRecurrenceNotString = RegisterErrorCode(ErrorCode{
+		Code: "recurrence.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeRecurrenceEmpty = RegisterErrorCode(ErrorCode{
+		Code: "recurrence.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeRecurrencePart = RegisterErrorCode(ErrorCode{
+		Code: "recurrence.part", Status: http.StatusBadRequest,
+		Message: "{part} must be a NAME=VALUE part", Params: []string{"part"}, Example: "\"FREQ\" must be a NAME=VALUE part",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeRecurrenceRepeated = RegisterErrorCode(ErrorCode{
+		Code: "recurrence.repeated", Status: http.StatusBadRequest,
+		Message: "{name} must not be repeated", Params: []string{"name"}, Example: "FREQ must not be repeated",
+	})
+	ErrCodeRecurrenceFreq = RegisterErrorCode(ErrorCode{
+		Code: "recurrence.freq", Status: http.StatusBadRequest,
+		Message: "FREQ must be one of {values}", Params: []string{"values"}, Example: "FREQ must be one of DAILY, WEEKLY, MONTHLY, YEARLY",
+	})
+	ErrCodeRecurrenceInterval = RegisterErrorCode(ErrorCode{
+		Code: "recurrence.interval", Status: http.StatusBadRequest,
+		Message: "INTERVAL must be at most {max}", Params: []string{"max"}, Example: "INTERVAL must be at most 10000",
+	})
+	ErrCodeRecurrenceUntilFormat = RegisterErrorCode(ErrorCode{
+		Code: "recurrence.until_format", Status: http.StatusBadRequest,
+		Message: "UNTIL must be a date (YYYYMMDD) or a date-time (YYYYMMDDTHHMMSSZ)", Example: "UNTIL must be a date (YYYYMMDD) or a date-time (YYYYMMDDTHHMMSSZ)",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeRecurrenceUntilDate = RegisterErrorCode(ErrorCode{
+		Code: "recurrence.until_date", Status: http.StatusBadRequest,
+		Message: "UNTIL {value} is not a valid date", Params: []string{"value"}, Example: "UNTIL 20240230 is not a valid date",
+	})
+	ErrCodeRecurrenceByDay = RegisterErrorCode(ErrorCode{
+		Code: "recurrence.by_day", Status: http.StatusBadRequest,
+		Message: "BYDAY {item} must be a weekday such as MO or -1FR", Params: []string{"item"}, Example: "BYDAY \"XX\" must be a weekday such as MO or -1FR",
+	})
+	ErrCodeRecurrenceByDayNumber = RegisterErrorCode(ErrorCode{
+		Code: "recurrence.by_day_number", Status: http.StatusBadRequest,
+		Message: "BYDAY {item} must number the weekday from 1 to 53 or -53 to -1", Params: []string{"item"}, Example: "BYDAY \"60MO\" must number the weekday from 1 to 53 or -53 to -1",
+	})
+	ErrCodeRecurrenceWeekStart = RegisterErrorCode(ErrorCode{
+		Code: "recurrence.week_start", Status: http.StatusBadRequest,
+		Message: "WKST must be a weekday such as MO", Example: "WKST must be a weekday such as MO",
+	})
+	ErrCodeRecurrenceUnsupported = RegisterErrorCode(ErrorCode{
+		Code: "recurrence.unsupported", Status: http.StatusBadRequest,
+		Message: "{name} is not supported", Params: []string{"name"}, Example: "BYSETPOS is not supported",
+	})
+	ErrCodeRecurrenceFreqMissing = RegisterErrorCode(ErrorCode{
+		Code: "recurrence.freq_missing", Status: http.StatusBadRequest,
+		Message: "FREQ must be set", Example: "FREQ must be set",
+	})
+	ErrCodeRecurrenceCountAndUntil = RegisterErrorCode(ErrorCode{
+		Code: "recurrence.count_and_until", Status: http.StatusBadRequest,
+		Message: "COUNT and UNTIL must not both be set", Example: "COUNT and UNTIL must not both be set",
+	})
+	ErrCodeRecurrenceWeeklyMonthDay = RegisterErrorCode(ErrorCode{
+		Code: "recurrence.weekly_month_day", Status: http.StatusBadRequest,
+		Message: "BYMONTHDAY must not be used with FREQ=WEEKLY", Example: "BYMONTHDAY must not be used with FREQ=WEEKLY",
+	})
+	ErrCodeRecurrenceByDayNumbered = RegisterErrorCode(ErrorCode{
+		Code: "recurrence.by_day_numbered", Status: http.StatusBadRequest,
+		Message: "BYDAY may only number weekdays with FREQ=MONTHLY or FREQ=YEARLY", Example: "BYDAY may only number weekdays with FREQ=MONTHLY or FREQ=YEARLY",
+	})
+	ErrCodeRecurrenceNotPositive = RegisterErrorCode(ErrorCode{
+		Code: "recurrence.not_positive", Status: http.StatusBadRequest,
+		Message: "{name} must be a positive integer", Params: []string{"name"}, Example: "COUNT must be a positive integer",
+	})
+	ErrCodeRecurrenceListRange = RegisterErrorCode(ErrorCode{
+		Code: "recurrence.list_range", Status: http.StatusBadRequest,
+		Message: "{name} {item} must be between 1 and {max}", Params: []string{"name", "item", "max"}, Example: "BYMONTH \"13\" must be between 1 and 12",
+	})
+	ErrCodeRecurrenceListSignedRange = RegisterErrorCode(ErrorCode{
+		Code: "recurrence.list_signed_range", Status: http.StatusBadRequest,
+		Message: "{name} {item} must be between 1 and {max} or -{max} and -1", Params: []string{"name", "item", "max"}, Example: "BYMONTHDAY \"32\" must be between 1 and 31 or -31 and -1",
+	})
+)
+
+// RecurrenceMaxOccurrences caps how many occurrences one Occurrences call
+// returns, so a daily rule over a wide range cannot exhaust memory.
+var RecurrenceMaxOccurrences = 1000
+
+// RecurrenceMaxInterval caps INTERVAL. Every period past it is beyond year
+// 9999 for any FREQ anyway, and a larger one would overflow the period
+// arithmetic.
+const RecurrenceMaxInterval = 10000
+
+var (
+	recurrenceFrequencies = []string{"DAILY", "WEEKLY", "MONTHLY", "YEARLY"}
+	recurrenceWeekdays    = map[string]time.Weekday{
+		"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+		"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+	}
+	recurrenceDayPattern = regexp.MustCompile(`^([+-]?\d{1,2})?(SU|MO|TU|WE|TH|FR|SA)$`)
+	recurrenceUntilDate  = regexp.MustCompile(`^\d{8}$`)
+	recurrenceUntilTime  = regexp.MustCompile(`^\d{8}T\d{6}Z?$`)
+)
+
+type recurrenceDay struct {
+	// n is the 1st (1), 2nd (2) or last (-1) etc. occurrence of weekday in
+	// the month or year; 0 means every one.
+	n       int
+	weekday time.Weekday
+}
+
+// Recurrence is an RFC 5545 recurrence rule such as
+// "FREQ=WEEKLY;BYDAY=MO,WE" or "FREQ=MONTHLY;BYDAY=-1FR;COUNT=6". It
+// supports FREQ (DAILY, WEEKLY, MONTHLY, YEARLY), INTERVAL, COUNT, UNTIL,
+// BYDAY, BYMONTHDAY, BYMONTH and WKST, and rejects the other parts rather
+// than ignoring them. It marshals as the normalized rule: uppercase, parts
+// in a fixed order, defaults left out.
+type Recurrence struct {
+	freq       string
+	interval   int
+	count      int
+	until      string
+	byDay      []recurrenceDay
+	byMonthDay []int
+	byMonth    []int
+	weekStart  time.Weekday
+}
+
+func ParseRecurrence(s string) (Recurrence, error) {
+	s = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "RRULE:")
+	r := Recurrence{interval: 1, weekStart: time.Monday}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || name == "" || value == "" {
+			return Recurrence{}, ErrCodeRecurrencePart.Err(map[string]string{"part": strconv.Quote(part)})
+		}
+		if seen[name] {
+			return Recurrence{}, ErrCodeRecurrenceRepeated.Err(map[string]string{"name": name})
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			r.freq = value
+			if !containsString(recurrenceFrequencies, value) {
+				err = ErrCodeRecurrenceFreq.Err(map[string]string{"values": strings.Join(recurrenceFrequencies, ", ")})
+			}
+		case "INTERVAL":
+			r.interval, err = parseRecurrenceCount(name, value)
+			if err == nil && r.interval > RecurrenceMaxInterval {
+				err = ErrCodeRecurrenceInterval.Err(map[string]string{"max": strconv.Itoa(RecurrenceMaxInterval)})
+			}
+		case "COUNT":
+			r.count, err = parseRecurrenceCount(name, value)
+		case "UNTIL":
+			r.until = value
+			layout := "20060102"
+			if recurrenceUntilTime.MatchString(value) {
+				layout = "20060102T150405Z"[:len(value)]
+			}
+			if !recurrenceUntilDate.MatchString(value) && !recurrenceUntilTime.MatchString(value) {
+				err = ErrCodeRecurrenceUntilFormat.Err(nil)
+			} else if _, parseErr := time.Parse(layout, value); parseErr != nil {
+				err = ErrCodeRecurrenceUntilDate.Err(map[string]string{"value": value})
+			}
+		case "BYDAY":
+			for _, item := range strings.Split(value, ",") {
+				m := recurrenceDayPattern.FindStringSubmatch(item)
+				if m == nil {
+					return Recurrence{}, ErrCodeRecurrenceByDay.Err(map[string]string{"item": strconv.Quote(item)})
+				}
+				day := recurrenceDay{weekday: recurrenceWeekdays[m[2]]}
+				if m[1] != "" {
+					day.n, _ = strconv.Atoi(m[1])
+					if day.n == 0 || day.n < -53 || day.n > 53 {
+						return Recurrence{}, ErrCodeRecurrenceByDayNumber.Err(map[string]string{"item": strconv.Quote(item)})
+					}
+				}
+				r.byDay = append(r.byDay, day)
+			}
+		case "BYMONTHDAY":
+			r.byMonthDay, err = parseRecurrenceList(name, value, 31, true)
+		case "BYMONTH":
+			r.byMonth, err = parseRecurrenceList(name, value, 12, false)
+		case "WKST":
+			var ok bool
+			if r.weekStart, ok = recurrenceWeekdays[value]; !ok {
+				err = ErrCodeRecurrenceWeekStart.Err(nil)
+			}
+		default:
+			err = ErrCodeRecurrenceUnsupported.Err(map[string]string{"name": name})
+		}
+		if err != nil {
+			return Recurrence{}, err
+		}
+	}
+
+	switch {
+	case r.freq == "":
+		return Recurrence{}, ErrCodeRecurrenceFreqMissing.Err(nil)
+	case r.count > 0 && r.until != "":
+		return Recurrence{}, ErrCodeRecurrenceCountAndUntil.Err(nil)
+	case r.freq == "WEEKLY" && len(r.byMonthDay) > 0:
+		return Recurrence{}, ErrCodeRecurrenceWeeklyMonthDay.Err(nil)
+	}
+	if r.freq == "DAILY" || r.freq == "WEEKLY" {
+		for _, day := range r.byDay {
+			if day.n != 0 {
+				return Recurrence{}, ErrCodeRecurrenceByDayNumbered.Err(nil)
+			}
+		}
+	}
+	return r, nil
+}
+
+// multiplyInt64 returns a*b, and false when it overflows.
+func multiplyInt64(a int64, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	product := a * b
+	if product/b != a || (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) {
+		return 0, false
+	}
+	return product, true
+}
+
+func parseRecurrenceCount(name string, value string) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, ErrCodeRecurrenceNotPositive.Err(map[string]string{"name": name})
+	}
+	return n, nil
+}
+
+// parseRecurrenceList reads values from 1 to max, or also -max to -1 when
+// negative values (counting from the end) are allowed.
+func parseRecurrenceList(name string, value string, max int, negative bool) ([]int, error) {
+	var list []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil || n == 0 || n > max || n < -max || (n < 0 && !negative) {
+			if negative {
+				return nil, ErrCodeRecurrenceListSignedRange.Err(map[string]string{"name": name, "item": strconv.Quote(item), "max": strconv.Itoa(max)})
+			}
+			return nil, ErrCodeRecurrenceListRange.Err(map[string]string{"name": name, "item": strconv.Quote(item), "max": strconv.Itoa(max)})
+		}
+		list = append(list, n)
+	}
+	return list, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(list []int, n int) bool {
+	for _, v := range list {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
+
+// Occurrences returns the occurrences of the rule, starting at start (the
+// DTSTART, counted for COUNT if it matches the rule), that fall within
+// between. Every occurrence has start's wall-clock time of day in start's
+// location. At most RecurrenceMaxOccurrences are returned.
+func (r Recurrence) Occurrences(start DateTime, between TimeRange) []DateTime {
+	startAt := start.Time()
+	loc := startAt.Location()
+	hour, minute, second := startAt.Clock()
+	at := func(epochDay int64) time.Time {
+		d := FromEpochDays(epochDay)
+		return time.Date(d.Year(), d.Month(), d.Day(), hour, minute, second, startAt.Nanosecond(), loc)
+	}
+
+	var until time.Time
+	switch {
+	case recurrenceUntilDate.MatchString(r.until):
+		// A date includes the whole day.
+		d, _ := time.Parse("20060102", r.until)
+		until = time.Date(d.Year(), d.Month(), d.Day()+1, 0, 0, 0, -1, loc)
+	case strings.HasSuffix(r.until, "Z"):
+		until, _ = time.Parse("20060102T150405Z", r.until)
+	case r.until != "":
+		// Without Z the time is floating, i.e. local to start.
+		until, _ = time.ParseInLocation("20060102T150405", r.until, loc)
+	}
+
+	first := NewDate(startAt.Year(), startAt.Month(), startAt.Day())
+	occurrences := []DateTime{}
+	counted := 0
+	for period := 0; ; period++ {
+		periodStart, days := r.period(first, period)
+		// Nothing in this period or any later one can come before there.
+		earliest := at(periodStart.EpochDays())
+		if periodStart.Year() > 9999 || !earliest.Before(between.to.Time()) || (!until.IsZero() && earliest.After(until)) {
+			return occurrences
+		}
+		for _, epochDay := range days {
+			t := at(epochDay)
+			if t.Before(startAt) {
+				continue
+			}
+			if (!until.IsZero() && t.After(until)) || !t.Before(between.to.Time()) {
+				return occurrences
+			}
+			counted++
+			if r.count > 0 && counted > r.count {
+				return occurrences
+			}
+			if !t.Before(between.from.Time()) {
+				occurrences = append(occurrences, DateTime{time: t, precision: start.precision})
+				if len(occurrences) >= RecurrenceMaxOccurrences {
+					return occurrences
+				}
+			}
+		}
+	}
+}
+
+// period returns the first day of the n-th period (day, week, month or
+// year, depending on FREQ and INTERVAL) after the one containing first,
+// and the days in it that match the rule, as sorted epoch days.
+func (r Recurrence) period(first Date, n int) (Date, []int64) {
+	// n counts periods of INTERVAL units; past year 9999 Occurrences stops,
+	// so an offset that overflows is reported as that.
+	units, ok := multiplyInt64(int64(n), int64(r.interval))
+	if r.freq == "WEEKLY" && ok {
+		units, ok = multiplyInt64(units, 7)
+	}
+	if !ok || units > 10000*366 {
+		return NewDate(10000, time.January, 1), nil
+	}
+
+	var days []int64
+	switch r.freq {
+	case "DAILY":
+		day := first.AddDays(units)
+		e := day.EpochDays()
+		if r.matchesMonth(day) && r.matchesMonthDay(day) && r.matchesWeekday(e) {
+			days = append(days, e)
+		}
+		return day, days
+
+	case "WEEKLY":
+		offset := (int(first.Weekday()) - int(r.weekStart) + 7) % 7
+		weekStart := first.AddDays(units - int64(offset))
+		for i := int64(0); i < 7; i++ {
+			day := weekStart.AddDays(i)
+			matches := day.Weekday() == first.Weekday()
+			if len(r.byDay) > 0 {
+				matches = r.matchesWeekday(day.EpochDays())
+			}
+			if matches && r.matchesMonth(day) {
+				days = append(days, day.EpochDays())
+			}
+		}
+		return weekStart, days
+
+	case "MONTHLY":
+		month := NewDate(first.Year(), first.Month()+time.Month(units), 1)
+		if r.matchesMonth(month) {
+			days = r.expand(month, lastOfMonth(month), first.Day())
+		}
+		return month, days
+	}
+
+	// YEARLY
+	year := NewDate(first.Year()+int(units), time.January, 1)
+	switch {
+	case len(r.byMonth) > 0:
+		months := append([]int(nil), r.byMonth...)
+		sort.Ints(months)
+		for _, m := range months {
+			month := NewDate(year.Year(), time.Month(m), 1)
+			days = append(days, r.expand(month, lastOfMonth(month), first.Day())...)
+		}
+	case len(r.byMonthDay) > 0:
+		for m := time.January; m <= time.December; m++ {
+			month := NewDate(year.Year(), m, 1)
+			days = append(days, r.expand(month, lastOfMonth(month), first.Day())...)
+		}
+	case len(r.byDay) > 0:
+		days = r.expand(year, NewDate(year.Year(), time.December, 31), 0)
+	default:
+		month := NewDate(year.Year(), first.Month(), 1)
+		days = r.expand(month, lastOfMonth(month), first.Day())
+	}
+	return year, days
+}
+
+// expand lists the days from lo to hi selected by BYMONTHDAY and BYDAY,
+// where numbered weekdays count within lo..hi. With neither set it selects
+// defaultDay of lo's month, if that month has it.
+func (r Recurrence) expand(lo Date, hi Date, defaultDay int) []int64 {
+	var days []int64
+	switch {
+	case len(r.byMonthDay) > 0:
+		weekdays := r.weekdaysIn(lo, hi)
+		for e := lo.EpochDays(); e <= hi.EpochDays(); e++ {
+			if r.matchesMonthDay(FromEpochDays(e)) && (len(r.byDay) == 0 || weekdays[e]) {
+				days = append(days, e)
+			}
+		}
+	case len(r.byDay) > 0:
+		for e := range r.weekdaysIn(lo, hi) {
+			days = append(days, e)
+		}
+		sort.Slice(days, func(i, j int) bool { return days[i] < days[j] })
+	default:
+		if day := lo.AddDays(int64(defaultDay - 1)); day.Month() == lo.Month() {
+			days = append(days, day.EpochDays())
+		}
+	}
+	return days
+}
+
+// weekdaysIn resolves BYDAY to the set of matching days from lo to hi.
+func (r Recurrence) weekdaysIn(lo Date, hi Date) map[int64]bool {
+	set := map[int64]bool{}
+	low, high := lo.EpochDays(), hi.EpochDays()
+	for _, day := range r.byDay {
+		wd := int64(day.weekday)
+		switch {
+		case day.n == 0:
+			for e := low + (wd-epochWeekday(low)+7)%7; e <= high; e += 7 {
+				set[e] = true
+			}
+		case day.n > 0:
+			if e := low + (wd-epochWeekday(low)+7)%7 + int64(7*(day.n-1)); e <= high {
+				set[e] = true
+			}
+		default:
+			if e := high - (epochWeekday(high)-wd+7)%7 - int64(7*(-day.n-1)); e >= low {
+				set[e] = true
+			}
+		}
+	}
+	return set
+}
+
+func (r Recurrence) matchesMonth(d Date) bool {
+	return len(r.byMonth) == 0 || containsInt(r.byMonth, int(d.Month()))
+}
+
+func (r Recurrence) matchesMonthDay(d Date) bool {
+	if len(r.byMonthDay) == 0 {
+		return true
+	}
+	fromEnd := d.Day() - lastOfMonth(d).Day() - 1
+	return containsInt(r.byMonthDay, d.Day()) || containsInt(r.byMonthDay, fromEnd)
+}
+
+func (r Recurrence) matchesWeekday(epochDay int64) bool {
+	if len(r.byDay) == 0 {
+		return true
+	}
+	for _, day := range r.byDay {
+		if int64(day.weekday) == epochWeekday(epochDay) {
+			return true
+		}
+	}
+	return false
+}
+
+func lastOfMonth(d Date) Date {
+	return NewDate(d.Year(), d.Month()+1, 0)
+}
+
+// epochWeekday is the time.Weekday of an epoch day; 1970-01-01 was a
+// Thursday.
+func epochWeekday(epochDay int64) int64 {
+	return ((epochDay+4)%7 + 7) % 7
+}
+
+func (r Recurrence) String() string {
+	parts := []string{"FREQ=" + r.freq}
+	if r.interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.interval))
+	}
+	if r.count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.count))
+	}
+	if r.until != "" {
+		parts = append(parts, "UNTIL="+r.until)
+	}
+	if len(r.byMonth) > 0 {
+		parts = append(parts, "BYMONTH="+joinInts(r.byMonth))
+	}
+	if len(r.byMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.byMonthDay))
+	}
+	if len(r.byDay) > 0 {
+		days := make([]string, len(r.byDay))
+		for i, day := range r.byDay {
+			days[i] = strings.ToUpper(day.weekday.String()[:2])
+			if day.n != 0 {
+				days[i] = strconv.Itoa(day.n) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.weekStart != time.Monday {
+		parts = append(parts, "WKST="+strings.ToUpper(r.weekStart.String()[:2]))
+	}
+	return strings.Join(parts, ";")
+}
+
+func joinInts(list []int) string {
+	s := make([]string, len(list))
+	for i, n := range list {
+		s[i] = strconv.Itoa(n)
+	}
+	return strings.Join(s, ",")
+}
+
+func (r Recurrence) IsZero() bool {
+	return r.freq == ""
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (r Recurrence) MarshalJSON() ([]byte, error) {
+	if r.freq == "" {
+		return json.Marshal("")
+	}
+	return json.Marshal(r.String())
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (r *Recurrence) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeRecurrenceNotString.Err(nil)
+	}
+	if s == "" {
+		return ErrCodeRecurrenceEmpty.Err(nil)
+	}
+	parsed, err := ParseRecurrence(s)
+	if err != nil {
+		return err
+	}
+
+	*r = parsed
+
+	return nil
+}
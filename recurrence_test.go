@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRecurrenceIntervalCap(t *testing.T) {
+	for _, freq := range recurrenceFrequencies {
+		rule := "FREQ=" + freq + ";INTERVAL=9223372036854775807"
+		if _, err := ParseRecurrence(rule); err == nil || err.Error() != "INTERVAL must be at most 10000" {
+			t.Errorf("ParseRecurrence(%s): error %v, want INTERVAL must be at most 10000", rule, err)
+		}
+	}
+}
+
+// The largest INTERVAL still ends: every later period is past year 9999.
+func TestRecurrenceOccurrencesLargeInterval(t *testing.T) {
+	between, err := NewTimeRange(MustParseDateTime("2024-01-01T00:00:00Z"), MustParseDateTime("2030-01-01T00:00:00Z"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := MustParseDateTime("2024-03-04T09:00:00Z")
+	for _, freq := range recurrenceFrequencies {
+		rule, err := ParseRecurrence("FREQ=" + freq + ";INTERVAL=" + strconv.Itoa(RecurrenceMaxInterval))
+		if err != nil {
+			t.Fatal(err)
+		}
+		done := make(chan []DateTime, 1)
+		go func() { done <- rule.Occurrences(start, between) }()
+		select {
+		case occurrences := <-done:
+			if len(occurrences) != 1 || !occurrences[0].Equal(start) {
+				t.Errorf("%s: occurrences %v, want only the start", freq, occurrences)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: Occurrences did not return", freq)
+		}
+	}
+}
+
+func TestRecurrencePeriodOverflow(t *testing.T) {
+	rule, err := ParseRecurrence("FREQ=WEEKLY;INTERVAL=10000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := NewDate(2024, time.March, 4)
+	for _, n := range []int{1 << 40, 1<<62 + 1} {
+		if day, days := rule.period(first, n); day.Year() <= 9999 || days != nil {
+			t.Errorf("period %d starts %s with %v, want past year 9999", n, day, days)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// go-redis writes an encoding.BinaryMarshaler argument with MarshalBinary
+// and Scans into an encoding.BinaryUnmarshaler, so a single DateTime, Date,
+// Enum, ... can be SET and read back as it is, see binary.go. RedisValue
+// does the same for a whole struct, without a json.Marshal at each call:
+//
+//	err := rdb.Set(ctx, "order:42", RedisValue{V: order}, time.Hour).Err()
+//	...
+//	var order ResponseContentOrder
+//	err := rdb.Get(ctx, "order:42").Scan(RedisValue{V: &order})
+//
+// Read a key with the codec it was written with.
+
+// RedisCodec is the representation RedisValue stores.
+type RedisCodec int
+
+const (
+	// RedisJSON is the JSON body a handler would send, by `json` tags. It
+	// keeps every DateTime's offset and is readable in redis-cli.
+	RedisJSON RedisCodec = iota
+	// RedisMsgpack is msgpack, by `json` tags too, and the types' compact
+	// form from msgpack.go. It is smaller and quicker to decode, but a
+	// DateTime comes back in its default location, not its offset.
+	RedisMsgpack
+)
+
+// RedisValue wraps V for go-redis: pass it as a SET argument, or with a
+// pointer in V to Scan into it.
+type RedisValue struct {
+	V     interface{}
+	Codec RedisCodec
+}
+
+func (rv RedisValue) MarshalBinary() ([]byte, error) {
+	if rv.Codec == RedisMsgpack {
+		buf := getBuffer()
+		enc := msgpack.GetEncoder()
+		enc.Reset(buf)
+		enc.SetCustomStructTag("json")
+		err := enc.Encode(rv.V)
+		msgpack.PutEncoder(enc)
+		if err != nil {
+			putBuffer(buf)
+			return nil, err
+		}
+		return bufferBytes(buf), nil
+	}
+	return json.Marshal(rv.V)
+}
+
+// UnmarshalBinary decodes with the validation of a JSON body, or of
+// msgpack.go, so a value that no longer passes is an error rather than a
+// silently bad struct.
+func (rv RedisValue) UnmarshalBinary(b []byte) error {
+	if rv.Codec == RedisMsgpack {
+		dec := msgpack.NewDecoder(bytes.NewReader(b))
+		dec.SetCustomStructTag("json")
+		return dec.Decode(rv.V)
+	}
+	return unmarshalBinaryJSON(rv.V, b)
+}
+
+// unmarshalBinaryJSON is json.Unmarshal with the types' panics turned
+// into errors, as a handler's Bind does.
+func unmarshalBinaryJSON(v interface{}, b []byte) (err error) {
+	defer recoverBadRequest(&err)
+
+	return json.Unmarshal(b, v)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+)
+
+var (
+	ErrCodeRegexPatternNotString = RegisterErrorCode(ErrorCode{
+		Code: "regex_pattern.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeRegexPatternEmpty = RegisterErrorCode(ErrorCode{
+		Code: "regex_pattern.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeRegexPatternTooLong = RegisterErrorCode(ErrorCode{
+		Code: "regex_pattern.too_long", Status: http.StatusBadRequest,
+		Message: "must not be longer than {max} characters", Params: []string{"max"}, Example: "must not be longer than 256 characters",
+	})
+	ErrCodeRegexPatternInvalid = RegisterErrorCode(ErrorCode{
+		Code: "regex_pattern.invalid", Status: http.StatusBadRequest,
+		Message: "{reason}", Params: []string{"reason"}, Example: "error parsing regexp: missing closing ): `(a`",
+		Kind: ErrInvalidFormat,
+	})
+)
+
+// RegexPatternMaxLength bounds the pattern source. Go's RE2 engine runs in
+// linear time, so the length is what limits compile cost and memory.
+var RegexPatternMaxLength = 256
+
+// RegexPattern is compiled once on unmarshal and keeps both the compiled
+// expression and the original source, which is what it marshals back to.
+type RegexPattern struct {
+	source string
+	re     *regexp.Regexp
+}
+
+func CompileRegexPattern(source string) (RegexPattern, error) {
+	if len(source) > RegexPatternMaxLength {
+		return RegexPattern{}, ErrCodeRegexPatternTooLong.Err(map[string]string{"max": strconv.Itoa(RegexPatternMaxLength)})
+	}
+	re, err := regexp.Compile(source)
+	if err != nil {
+		// e.g. "error parsing regexp: missing closing ): `(a`"
+		return RegexPattern{}, ErrCodeRegexPatternInvalid.Err(map[string]string{"reason": err.Error()})
+	}
+	return RegexPattern{source: source, re: re}, nil
+}
+
+// Regexp returns the compiled expression, or nil for the zero value.
+func (rp RegexPattern) Regexp() *regexp.Regexp {
+	return rp.re
+}
+
+// MatchString reports false for the zero value instead of panicking.
+func (rp RegexPattern) MatchString(s string) bool {
+	return rp.re != nil && rp.re.MatchString(s)
+}
+
+func (rp RegexPattern) String() string {
+	return rp.source
+}
+
+func (rp RegexPattern) IsZero() bool {
+	return rp.re == nil
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (rp RegexPattern) MarshalJSON() ([]byte, error) {
+	return json.Marshal(rp.source)
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (rp *RegexPattern) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeRegexPatternNotString.Err(nil)
+	}
+	if s == "" {
+		return ErrCodeRegexPatternEmpty.Err(nil)
+	}
+	compiled, err := CompileRegexPattern(s)
+	if err != nil {
+		return err
+	}
+
+	*rp = compiled
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrCodeResourceNameNotString = RegisterErrorCode(ErrorCode{
+		Code: "resource_name.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeResourceNameEmpty = RegisterErrorCode(ErrorCode{
+		Code: "resource_name.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeResourceNameNoMatch = RegisterErrorCode(ErrorCode{
+		Code: "resource_name.no_match", Status: http.StatusBadRequest,
+		Message: "must match one of {patterns}", Params: []string{"patterns", "value"}, Example: "must match one of projects/{project}/locations/{location}/jobs/{job}",
+		Kind: ErrInvalidFormat,
+	})
+)
+
+var resourceIDPattern = regexp.MustCompile(`^[A-Za-z0-9._~-]{1,63}$`)
+
+// ResourcePattern is an AIP-122 resource name template such as
+// "projects/{project}/locations/{location}/jobs/{job}".
+type ResourcePattern struct {
+	pattern  string
+	segments []resourceSegment
+}
+
+type resourceSegment struct {
+	literal  string
+	variable string
+}
+
+var (
+	resourcePatternsMu sync.RWMutex
+	resourcePatterns   []*ResourcePattern
+)
+
+// RegisterResourcePattern compiles the pattern and adds it to the set that
+// ResourceName unmarshaling matches against. It is meant to be called from
+// package initialization and panics on a malformed pattern.
+func RegisterResourcePattern(pattern string) *ResourcePattern {
+	p, err := compileResourcePattern(pattern)
+	if err != nil {
+		panic(err)
+	}
+
+	resourcePatternsMu.Lock()
+	defer resourcePatternsMu.Unlock()
+	resourcePatterns = append(resourcePatterns, p)
+
+	return p
+}
+
+func compileResourcePattern(pattern string) (*ResourcePattern, error) {
+	p := &ResourcePattern{pattern: pattern}
+	seen := map[string]bool{}
+	for _, part := range strings.Split(pattern, "/") {
+		switch {
+		case part == "":
+			return nil, fmt.Errorf("resource pattern %q has an empty segment", pattern)
+		case strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}"):
+			name := part[1 : len(part)-1]
+			if name == "" || seen[name] {
+				return nil, fmt.Errorf("resource pattern %q has an empty or duplicate variable", pattern)
+			}
+			seen[name] = true
+			p.segments = append(p.segments, resourceSegment{variable: name})
+		case strings.ContainsAny(part, "{}"):
+			return nil, fmt.Errorf("resource pattern %q has a malformed variable %q", pattern, part)
+		default:
+			p.segments = append(p.segments, resourceSegment{literal: part})
+		}
+	}
+	return p, nil
+}
+
+func (p *ResourcePattern) String() string {
+	return p.pattern
+}
+
+// Variables returns the variable names of the pattern in order.
+func (p *ResourcePattern) Variables() []string {
+	var names []string
+	for _, seg := range p.segments {
+		if seg.variable != "" {
+			names = append(names, seg.variable)
+		}
+	}
+	return names
+}
+
+// Build constructs a resource name from one value per variable, in the order
+// the variables appear in the pattern.
+func (p *ResourcePattern) Build(values ...string) (ResourceName, error) {
+	names := p.Variables()
+	if len(values) != len(names) {
+		return ResourceName{}, fmt.Errorf("pattern %s needs %d values, got %d", p.pattern, len(names), len(values))
+	}
+
+	parts := make([]string, 0, len(p.segments))
+	segments := make(map[string]string, len(names))
+	i := 0
+	for _, seg := range p.segments {
+		if seg.variable == "" {
+			parts = append(parts, seg.literal)
+			continue
+		}
+		if !resourceIDPattern.MatchString(values[i]) {
+			return ResourceName{}, fmt.Errorf("%s must be 1-63 characters of letters, digits, '.', '_', '~' or '-'", seg.variable)
+		}
+		parts = append(parts, values[i])
+		segments[seg.variable] = values[i]
+		i++
+	}
+
+	return ResourceName{pattern: p, name: strings.Join(parts, "/"), segments: segments}, nil
+}
+
+// Match parses s against this pattern only.
+func (p *ResourcePattern) Match(s string) (ResourceName, bool) {
+	parts := strings.Split(s, "/")
+	if len(parts) != len(p.segments) {
+		return ResourceName{}, false
+	}
+	var values []string
+	for i, seg := range p.segments {
+		if seg.variable == "" {
+			if parts[i] != seg.literal {
+				return ResourceName{}, false
+			}
+			continue
+		}
+		values = append(values, parts[i])
+	}
+	name, err := p.Build(values...)
+	if err != nil {
+		return ResourceName{}, false
+	}
+	return name, true
+}
+
+type ResourceName struct {
+	pattern  *ResourcePattern
+	name     string
+	segments map[string]string
+}
+
+// ParseResourceName matches s against every registered pattern, in
+// registration order, and returns the first match.
+func ParseResourceName(s string) (ResourceName, error) {
+	if s == "" {
+		return ResourceName{}, ErrCodeResourceNameEmpty.Err(nil)
+	}
+
+	resourcePatternsMu.RLock()
+	defer resourcePatternsMu.RUnlock()
+
+	for _, p := range resourcePatterns {
+		if name, ok := p.Match(s); ok {
+			return name, nil
+		}
+	}
+
+	patterns := make([]string, len(resourcePatterns))
+	for i, p := range resourcePatterns {
+		patterns[i] = p.pattern
+	}
+	return ResourceName{}, ErrCodeResourceNameNoMatch.Err(map[string]string{"patterns": strings.Join(patterns, ", "), "value": s})
+}
+
+func (rn ResourceName) Pattern() *ResourcePattern {
+	return rn.pattern
+}
+
+// Segment returns the value bound to a pattern variable, e.g.
+// Segment("project") for "projects/{project}/...".
+func (rn ResourceName) Segment(variable string) string {
+	return rn.segments[variable]
+}
+
+func (rn ResourceName) String() string {
+	return rn.name
+}
+
+func (rn ResourceName) IsZero() bool {
+	return rn.name == ""
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (rn ResourceName) MarshalJSON() ([]byte, error) {
+	return json.Marshal(rn.name)
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (rn *ResourceName) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeResourceNameNotString.Err(nil)
+	}
+	name, err := ParseResourceName(s)
+	if err != nil {
+		return err
+	}
+
+	*rn = name
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	RequestIDHeader = "X-Request-ID"
+
+	requestIDKey    = "myapp.request_id"
+	requestTimerKey = "myapp.request_timer"
+)
+
+// Envelope is the shape of every JSON success response: the payload under
+// "data" and, when there is any, request metadata under "meta".
+type Envelope struct {
+	Data interface{} `json:"data"`
+	Meta *Meta       `json:"meta,omitempty"`
+}
+
+type Meta struct {
+	RequestID   string      `json:"request_id,omitempty"`
+	TookMS      *Elapsed    `json:"took_ms,omitempty"`
+	Pagination  *Pagination `json:"pagination,omitempty"`
+	NextCursor  Cursor      `json:"next_cursor,omitempty"`
+	PrevCursor  Cursor      `json:"prev_cursor,omitempty"`
+	Deprecation []string    `json:"deprecation,omitempty"`
+}
+
+// ResponseMetaMiddleware starts the request timer and assigns a request id,
+// reusing the client's X-Request-ID when it sent one. Both are picked up by
+// NewMeta.
+func ResponseMetaMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Set(requestTimerKey, StartTimer())
+
+		id := ctx.GetHeader(RequestIDHeader)
+		if id == "" || len(id) > 128 {
+			id = newRequestID()
+		}
+		ctx.Set(requestIDKey, id)
+		ctx.Header(RequestIDHeader, id)
+
+		ctx.Next()
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// MetaBuilder assembles Meta for one response:
+//
+//	ctx.JSON(http.StatusOK, NewMeta(ctx).Pagination(page).Envelope(items))
+type MetaBuilder struct {
+	ctx  *gin.Context
+	meta Meta
+}
+
+func NewMeta(ctx *gin.Context) *MetaBuilder {
+	return &MetaBuilder{ctx: ctx}
+}
+
+// Pagination also sets the Link header for hypermedia clients.
+func (mb *MetaBuilder) Pagination(p Pagination) *MetaBuilder {
+	mb.meta.Pagination = &p
+	mb.ctx.Header("Link", PaginationLinks(mb.ctx, p))
+	return mb
+}
+
+// Cursors records the neighbouring pages of a cursor-paginated list, in meta
+// and in the Link header. Pass "" for a side that has no more items.
+func (mb *MetaBuilder) Cursors(next Cursor, prev Cursor) *MetaBuilder {
+	mb.meta.NextCursor = next
+	mb.meta.PrevCursor = prev
+	mb.ctx.Header("Link", CursorLinks(mb.ctx, next, prev))
+	return mb
+}
+
+// Deprecated adds a warning to meta and the matching Deprecation header so
+// both JSON readers and HTTP tooling see it.
+func (mb *MetaBuilder) Deprecated(message string) *MetaBuilder {
+	mb.meta.Deprecation = append(mb.meta.Deprecation, message)
+	mb.ctx.Header("Deprecation", "true")
+	return mb
+}
+
+// Build fills in the request id and timing recorded by
+// ResponseMetaMiddleware. Timing is taken here, so call it last.
+func (mb *MetaBuilder) Build() *Meta {
+	meta := mb.meta
+	meta.RequestID = mb.ctx.GetString(requestIDKey)
+	if v, ok := mb.ctx.Get(requestTimerKey); ok {
+		took := v.(Timer).Elapsed()
+		meta.TookMS = &took
+	}
+	return &meta
+}
+
+func (mb *MetaBuilder) Envelope(data interface{}) Envelope {
+	return Envelope{Data: data, Meta: mb.Build()}
+}
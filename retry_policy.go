@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+var (
+	ErrCodeRetryPolicyNotObject = RegisterErrorCode(ErrorCode{
+		Code: "retry_policy.not_object", Status: http.StatusBadRequest,
+		Message: "must be a valid retry policy object", Example: "must be a valid retry policy object",
+	})
+	ErrCodeRetryPolicyMaxAttempts = RegisterErrorCode(ErrorCode{
+		Code: "retry_policy.max_attempts", Status: http.StatusBadRequest,
+		Message: "max_attempts must be at least 1", Example: "max_attempts must be at least 1",
+	})
+	ErrCodeRetryPolicyBackoff = RegisterErrorCode(ErrorCode{
+		Code: "retry_policy.backoff", Status: http.StatusBadRequest,
+		Message: "backoff must be one of constant, linear, exponential", Params: []string{"value"}, Example: "backoff must be one of constant, linear, exponential",
+	})
+	ErrCodeRetryPolicyBase = RegisterErrorCode(ErrorCode{
+		Code: "retry_policy.base", Status: http.StatusBadRequest,
+		Message: "base must be a positive duration", Params: []string{"value"}, Example: "base must be a positive duration",
+	})
+	ErrCodeRetryPolicyMax = RegisterErrorCode(ErrorCode{
+		Code: "retry_policy.max", Status: http.StatusBadRequest,
+		Message: "max must be a positive duration", Params: []string{"value"}, Example: "max must be a positive duration",
+	})
+	ErrCodeRetryPolicyMaxBelowBase = RegisterErrorCode(ErrorCode{
+		Code: "retry_policy.max_below_base", Status: http.StatusBadRequest,
+		Message: "max must not be less than base", Example: "max must not be less than base",
+	})
+)
+
+type Backoff string
+
+const (
+	BackoffConstant    Backoff = "constant"
+	BackoffLinear      Backoff = "linear"
+	BackoffExponential Backoff = "exponential"
+)
+
+type RetryPolicy struct {
+	maxAttempts int
+	backoff     Backoff
+	base        time.Duration
+	max         time.Duration
+}
+
+type retryPolicyJSON struct {
+	MaxAttempts int     `json:"max_attempts"`
+	Backoff     Backoff `json:"backoff"`
+	Base        string  `json:"base"`
+	Max         string  `json:"max"`
+}
+
+func (rp RetryPolicy) MaxAttempts() int {
+	return rp.maxAttempts
+}
+
+func (rp RetryPolicy) Backoff() Backoff {
+	return rp.backoff
+}
+
+// NextDelay returns how long to wait before the given attempt (1-based),
+// capped at the policy maximum. Zero means no more attempts are allowed.
+func (rp RetryPolicy) NextDelay(attempt int) time.Duration {
+	if attempt < 1 || attempt > rp.maxAttempts {
+		return 0
+	}
+
+	// Both growing backoffs stop at max before multiplying, so a large
+	// attempt cannot overflow into a negative or wrapped delay.
+	var delay time.Duration
+	switch rp.backoff {
+	case BackoffLinear:
+		if rp.base > 0 && time.Duration(attempt) > rp.max/rp.base {
+			return rp.max
+		}
+		delay = rp.base * time.Duration(attempt)
+	case BackoffExponential:
+		delay = rp.base
+		for i := 1; i < attempt && delay < rp.max; i++ {
+			if delay > rp.max/2 {
+				return rp.max
+			}
+			delay *= 2
+		}
+	default:
+		delay = rp.base
+	}
+
+	if delay > rp.max {
+		return rp.max
+	}
+	return delay
+}
+
+func (rp RetryPolicy) IsZero() bool {
+	return rp == RetryPolicy{}
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (rp RetryPolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(retryPolicyJSON{
+		MaxAttempts: rp.maxAttempts,
+		Backoff:     rp.backoff,
+		Base:        rp.base.String(),
+		Max:         rp.max.String(),
+	})
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (rp *RetryPolicy) UnmarshalJSON(b []byte) error {
+	var raw retryPolicyJSON
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return codedOr(err, ErrCodeRetryPolicyNotObject.Err(nil))
+	}
+
+	if raw.MaxAttempts < 1 {
+		return ErrCodeRetryPolicyMaxAttempts.Err(nil)
+	}
+
+	switch raw.Backoff {
+	case BackoffConstant, BackoffLinear, BackoffExponential:
+	default:
+		return ErrCodeRetryPolicyBackoff.Err(map[string]string{"value": string(raw.Backoff)})
+	}
+
+	base, err := time.ParseDuration(raw.Base)
+	if err != nil || base <= 0 {
+		return ErrCodeRetryPolicyBase.Err(map[string]string{"value": raw.Base})
+	}
+	maxDelay, err := time.ParseDuration(raw.Max)
+	if err != nil || maxDelay <= 0 {
+		return ErrCodeRetryPolicyMax.Err(map[string]string{"value": raw.Max})
+	}
+	if maxDelay < base {
+		return ErrCodeRetryPolicyMaxBelowBase.Err(nil)
+	}
+
+	rp.maxAttempts = raw.MaxAttempts
+	rp.backoff = raw.Backoff
+	rp.base = base
+	rp.max = maxDelay
+
+	return nil
+}
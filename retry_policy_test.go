@@ -0,0 +1,62 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func retryPolicy(t *testing.T, document string) RetryPolicy {
+	t.Helper()
+	var rp RetryPolicy
+	if err := unmarshalBinaryJSON(&rp, []byte(document)); err != nil {
+		t.Fatal(err)
+	}
+	return rp
+}
+
+func TestRetryPolicyNextDelay(t *testing.T) {
+	linear := retryPolicy(t, `{"max_attempts":5,"backoff":"linear","base":"1s","max":"3s"}`)
+	exponential := retryPolicy(t, `{"max_attempts":5,"backoff":"exponential","base":"1s","max":"5s"}`)
+	for _, tc := range []struct {
+		rp      RetryPolicy
+		attempt int
+		want    time.Duration
+	}{
+		{linear, 0, 0},
+		{linear, 1, time.Second},
+		{linear, 2, 2 * time.Second},
+		{linear, 4, 3 * time.Second},
+		{linear, 6, 0},
+		{exponential, 1, time.Second},
+		{exponential, 3, 4 * time.Second},
+		{exponential, 4, 5 * time.Second},
+	} {
+		if got := tc.rp.NextDelay(tc.attempt); got != tc.want {
+			t.Errorf("%s NextDelay(%d) = %s, want %s", tc.rp.Backoff(), tc.attempt, got, tc.want)
+		}
+	}
+}
+
+// base*attempt and repeated doubling overflow time.Duration long before
+// max_attempts runs out; the delay stays at max instead of wrapping.
+func TestRetryPolicyNextDelayOverflow(t *testing.T) {
+	for _, document := range []string{
+		`{"max_attempts":2147483647,"backoff":"linear","base":"1h","max":"2562047h"}`,
+		`{"max_attempts":2147483647,"backoff":"exponential","base":"1h","max":"2562047h"}`,
+		`{"max_attempts":2147483647,"backoff":"exponential","base":"1ns","max":"2562047h47m16.854775807s"}`,
+	} {
+		rp := retryPolicy(t, document)
+		previous := time.Duration(0)
+		for _, attempt := range []int{1, 2, 62, 63, 64, 1 << 20, 2562047, 2562048, math.MaxInt32} {
+			got := rp.NextDelay(attempt)
+			if got <= 0 || got > rp.max || got < previous {
+				t.Errorf("%s NextDelay(%d) = %s after %s, want between it and max %s", rp.Backoff(), attempt, got, previous, rp.max)
+			}
+			previous = got
+		}
+		if got := rp.NextDelay(math.MaxInt32); got != rp.max {
+			t.Errorf("%s last attempt %s, want max %s", rp.Backoff(), got, rp.max)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrCodeSemverNotString = RegisterErrorCode(ErrorCode{
+		Code: "semver.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeSemverEmpty = RegisterErrorCode(ErrorCode{
+		Code: "semver.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeSemverInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "semver.invalid_format", Status: http.StatusBadRequest,
+		Message: "must be a semantic version like 1.2.3", Params: []string{"value"}, Example: "must be a semantic version like 1.2.3",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeSemverTooLarge = RegisterErrorCode(ErrorCode{
+		Code: "semver.too_large", Status: http.StatusBadRequest,
+		Message: "{part} version is too large", Params: []string{"part", "value"}, Example: "major version is too large",
+	})
+)
+
+// The official semver.org pattern.
+var semverPattern = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// SemverAllowVPrefix accepts "v1.2.3" as well as "1.2.3" on input. The
+// normalized form never has the prefix.
+var SemverAllowVPrefix = true
+
+type Semver struct {
+	major      uint64
+	minor      uint64
+	patch      uint64
+	preRelease string
+	build      string
+}
+
+func ParseSemver(s string) (Semver, error) {
+	if SemverAllowVPrefix && !StrictParsing && (strings.HasPrefix(s, "v") || strings.HasPrefix(s, "V")) {
+		s = s[1:]
+	}
+	m := semverPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Semver{}, ErrCodeSemverInvalidFormat.Err(map[string]string{"value": s})
+	}
+
+	var v Semver
+	var err error
+	if v.major, err = strconv.ParseUint(m[1], 10, 64); err != nil {
+		return Semver{}, ErrCodeSemverTooLarge.Err(map[string]string{"part": "major", "value": s})
+	}
+	if v.minor, err = strconv.ParseUint(m[2], 10, 64); err != nil {
+		return Semver{}, ErrCodeSemverTooLarge.Err(map[string]string{"part": "minor", "value": s})
+	}
+	if v.patch, err = strconv.ParseUint(m[3], 10, 64); err != nil {
+		return Semver{}, ErrCodeSemverTooLarge.Err(map[string]string{"part": "patch", "value": s})
+	}
+	v.preRelease = m[4]
+	v.build = m[5]
+	return v, nil
+}
+
+func MustParseSemver(s string) Semver {
+	v, err := ParseSemver(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (v Semver) Major() uint64 {
+	return v.major
+}
+
+func (v Semver) Minor() uint64 {
+	return v.minor
+}
+
+func (v Semver) Patch() uint64 {
+	return v.patch
+}
+
+// PreRelease returns the part after "-", e.g. "rc.1" for "1.0.0-rc.1".
+func (v Semver) PreRelease() string {
+	return v.preRelease
+}
+
+// Build returns the part after "+". It is ignored by Compare.
+func (v Semver) Build() string {
+	return v.build
+}
+
+// Compare returns -1, 0 or +1 following semver precedence rules: numeric
+// fields first, then a version without pre-release ranks above one with it,
+// then pre-release identifiers left to right.
+func (v Semver) Compare(other Semver) int {
+	if c := compareUint(v.major, other.major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.minor, other.minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.patch, other.patch); c != 0 {
+		return c
+	}
+
+	switch {
+	case v.preRelease == other.preRelease:
+		return 0
+	case v.preRelease == "":
+		return 1
+	case other.preRelease == "":
+		return -1
+	}
+
+	a, b := strings.Split(v.preRelease, "."), strings.Split(other.preRelease, ".")
+	for i := 0; i < len(a) && i < len(b); i++ {
+		an, aErr := strconv.ParseUint(a[i], 10, 64)
+		bn, bErr := strconv.ParseUint(b[i], 10, 64)
+		switch {
+		case aErr == nil && bErr == nil:
+			if c := compareUint(an, bn); c != 0 {
+				return c
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareUint(uint64(len(a)), uint64(len(b)))
+}
+
+func compareUint(a uint64, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func (v Semver) LessThan(other Semver) bool {
+	return v.Compare(other) < 0
+}
+
+func (v Semver) GreaterThan(other Semver) bool {
+	return v.Compare(other) > 0
+}
+
+func (v Semver) Equal(other Semver) bool {
+	return v.Compare(other) == 0
+}
+
+func (v Semver) String() string {
+	s := strconv.FormatUint(v.major, 10) + "." + strconv.FormatUint(v.minor, 10) + "." + strconv.FormatUint(v.patch, 10)
+	if v.preRelease != "" {
+		s += "-" + v.preRelease
+	}
+	if v.build != "" {
+		s += "+" + v.build
+	}
+	return s
+}
+
+func (v Semver) IsZero() bool {
+	return v == Semver{}
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (v Semver) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.String())
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (v *Semver) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeSemverNotString.Err(nil)
+	}
+	if s == "" {
+		return ErrCodeSemverEmpty.Err(nil)
+	}
+	parsed, err := ParseSemver(s)
+	if err != nil {
+		return err
+	}
+
+	*v = parsed
+
+	return nil
+}
+
+/*
+	This part implements `encoding.TextMarshaler`
+	type TextMarshaler interface {
+		MarshalText() (text []byte, err error)
+	}
+*/
+func (v Semver) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+/*
+	This part implements `encoding.TextUnmarshaler`
+	type TextUnmarshaler interface {
+		UnmarshalText(text []byte) error
+	}
+*/
+func (v *Semver) UnmarshalText(b []byte) error {
+	return unmarshalTextAsJSON(v, b)
+}
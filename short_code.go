@@ -0,0 +1,205 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrCodeShortCodeNotString = RegisterErrorCode(ErrorCode{
+		Code: "short_code.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeShortCodeEmpty = RegisterErrorCode(ErrorCode{
+		Code: "short_code.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeShortCodeLength = RegisterErrorCode(ErrorCode{
+		Code: "short_code.length", Status: http.StatusBadRequest,
+		Message: "must be {length} characters long", Params: []string{"length", "value"}, Example: "must be 9 characters long",
+	})
+	ErrCodeShortCodeAlphabet = RegisterErrorCode(ErrorCode{
+		Code: "short_code.alphabet", Status: http.StatusBadRequest,
+		Message: "must only contain characters from {alphabet}", Params: []string{"alphabet", "value"}, Example: "must only contain characters from 0123456789ABCDEFGHJKMNPQRSTVWXYZ",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeShortCodeCheck = RegisterErrorCode(ErrorCode{
+		Code: "short_code.check", Status: http.StatusBadRequest,
+		Message: "has an invalid check character", Params: []string{"value"}, Example: "has an invalid check character",
+		Kind: ErrInvalidFormat,
+	})
+)
+
+// ShortCodeFormat describes how redemption codes look: Length random
+// characters drawn from Alphabet followed by one Luhn mod N check character.
+type ShortCodeFormat struct {
+	Alphabet string
+	Length   int
+}
+
+// DefaultShortCodeFormat uses Crockford's base32 alphabet, which leaves out
+// I, L, O and U so codes survive being read aloud or typed from print.
+var DefaultShortCodeFormat = ShortCodeFormat{
+	Alphabet: "0123456789ABCDEFGHJKMNPQRSTVWXYZ",
+	Length:   8,
+}
+
+var crockfordReplacer = strings.NewReplacer("O", "0", "I", "1", "L", "1", "-", "", " ", "")
+
+type ShortCode struct {
+	value string
+}
+
+// Generate draws every character from crypto/rand, so with the default
+// format there are 32^8 (about 10^12) codes per check digit.
+func (f ShortCodeFormat) Generate() (ShortCode, error) {
+	if f.Alphabet == "" {
+		return ShortCode{}, errors.New("alphabet must not be empty")
+	}
+	if f.Length < 1 {
+		return ShortCode{}, errors.New("length must be at least 1")
+	}
+	n := big.NewInt(int64(len(f.Alphabet)))
+	b := make([]byte, f.Length)
+	for i := range b {
+		idx, err := rand.Int(rand.Reader, n)
+		if err != nil {
+			return ShortCode{}, err
+		}
+		b[i] = f.Alphabet[idx.Int64()]
+	}
+	body := string(b)
+	return ShortCode{value: body + string(f.checkChar(body))}, nil
+}
+
+// GenerateUnique retries Generate until exists reports the code as unused,
+// giving up after maxTries attempts.
+func (f ShortCodeFormat) GenerateUnique(exists func(ShortCode) bool, maxTries int) (ShortCode, error) {
+	for i := 0; i < maxTries; i++ {
+		code, err := f.Generate()
+		if err != nil {
+			return ShortCode{}, err
+		}
+		if !exists(code) {
+			return code, nil
+		}
+	}
+	return ShortCode{}, errors.New("could not generate an unused short code after " + strconv.Itoa(maxTries) + " attempts")
+}
+
+// Parse accepts lowercase input when the alphabet has no lowercase letters;
+// an alphabet with lowercase letters is matched case-sensitively.
+func (f ShortCodeFormat) Parse(s string) (ShortCode, error) {
+	if f.Alphabet == strings.ToUpper(f.Alphabet) {
+		s = strings.ToUpper(s)
+	}
+	if f.Alphabet == DefaultShortCodeFormat.Alphabet {
+		s = crockfordReplacer.Replace(s)
+	} else {
+		s = strings.NewReplacer("-", "", " ", "").Replace(s)
+	}
+
+	if len(s) != f.Length+1 {
+		return ShortCode{}, ErrCodeShortCodeLength.Err(map[string]string{"length": strconv.Itoa(f.Length + 1), "value": s})
+	}
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(f.Alphabet, s[i]) < 0 {
+			return ShortCode{}, ErrCodeShortCodeAlphabet.Err(map[string]string{"alphabet": f.Alphabet, "value": s})
+		}
+	}
+	body := s[:f.Length]
+	if f.checkChar(body) != s[f.Length] {
+		return ShortCode{}, ErrCodeShortCodeCheck.Err(map[string]string{"value": s})
+	}
+
+	return ShortCode{value: s}, nil
+}
+
+// checkChar implements the Luhn mod N algorithm over the format alphabet.
+func (f ShortCodeFormat) checkChar(body string) byte {
+	n := len(f.Alphabet)
+	factor := 2
+	sum := 0
+	for i := len(body) - 1; i >= 0; i-- {
+		addend := factor * strings.IndexByte(f.Alphabet, body[i])
+		addend = addend/n + addend%n
+		sum += addend
+		if factor == 2 {
+			factor = 1
+		} else {
+			factor = 2
+		}
+	}
+	return f.Alphabet[(n-sum%n)%n]
+}
+
+func ParseShortCode(s string) (ShortCode, error) {
+	return DefaultShortCodeFormat.Parse(s)
+}
+
+func (sc ShortCode) String() string {
+	return sc.value
+}
+
+// QRPayload renders the canonical code into the string encoded in a QR
+// image: the code joined onto baseURL as a `code` query parameter, or, when
+// baseURL is empty, a bare "CODE:<value>" payload for offline scanners.
+func (sc ShortCode) QRPayload(baseURL string) (string, error) {
+	if baseURL == "" {
+		return "CODE:" + sc.value, nil
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	q.Set("code", sc.value)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+func (sc ShortCode) IsZero() bool {
+	return sc.value == ""
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (sc ShortCode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sc.value)
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (sc *ShortCode) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeShortCodeNotString.Err(nil)
+	}
+	if s == "" {
+		return ErrCodeShortCodeEmpty.Err(nil)
+	}
+	parsed, err := ParseShortCode(s)
+	if err != nil {
+		return err
+	}
+
+	*sc = parsed
+
+	return nil
+}
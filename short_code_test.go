@@ -0,0 +1,38 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShortCodeGenerateRejectsBadFormat(t *testing.T) {
+	for _, f := range []ShortCodeFormat{
+		{Alphabet: "", Length: 8},
+		{Alphabet: "ABC", Length: 0},
+		{Alphabet: "ABC", Length: -1},
+	} {
+		if code, err := f.Generate(); err == nil {
+			t.Errorf("%+v: generated %s, want an error", f, code)
+		}
+	}
+}
+
+func TestShortCodeParseCase(t *testing.T) {
+	lower := ShortCodeFormat{Alphabet: "abcdefghjkmnpqrstuvwxyz23456789", Length: 6}
+	code, err := lower.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lower.Parse(code.String()); err != nil {
+		t.Errorf("lowercase alphabet: Parse(%q): %v", code, err)
+	}
+
+	code, err = DefaultShortCodeFormat.Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lowered := strings.ToLower(code.String())
+	if _, err := DefaultShortCodeFormat.Parse(lowered); err != nil {
+		t.Errorf("default alphabet: Parse(%q): %v", lowered, err)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	ErrCodeSlugNotString = RegisterErrorCode(ErrorCode{
+		Code: "slug.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeSlugEmpty = RegisterErrorCode(ErrorCode{
+		Code: "slug.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeSlugTooLong = RegisterErrorCode(ErrorCode{
+		Code: "slug.too_long", Status: http.StatusBadRequest,
+		Message: "must not be longer than {max} characters", Params: []string{"max", "value"}, Example: "must not be longer than 100 characters",
+	})
+	ErrCodeSlugInvalidFormat = RegisterErrorCode(ErrorCode{
+		Code: "slug.invalid_format", Status: http.StatusBadRequest,
+		Message: "must only contain lowercase letters, digits and single dashes between them", Params: []string{"value"}, Example: "must only contain lowercase letters, digits and single dashes between them",
+		Kind: ErrInvalidFormat,
+	})
+)
+
+// SlugMaxLength bounds both accepted slugs and the output of SlugFrom.
+var SlugMaxLength = 100
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+// Letters that do not decompose into a base letter plus accents.
+var slugTransliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d",
+	'ł': "l", 'þ': "th", 'ı': "i",
+}
+
+// Slug is a URL path segment made of lowercase ASCII letters, digits and
+// single dashes, e.g. "hello-world-2024".
+type Slug string
+
+func ParseSlug(s string) (Slug, error) {
+	if len(s) > SlugMaxLength {
+		return "", ErrCodeSlugTooLong.Err(map[string]string{"max": strconv.Itoa(SlugMaxLength), "value": s})
+	}
+	if !slugPattern.MatchString(s) {
+		return "", ErrCodeSlugInvalidFormat.Err(map[string]string{"value": s})
+	}
+	return Slug(s), nil
+}
+
+// SlugFrom generates a slug from a title: accents are stripped ("Crème
+// Brûlée" becomes "creme-brulee"), everything that is not a letter or digit
+// turns into a dash, and the result is cut at a dash to fit SlugMaxLength.
+// It returns "" when nothing usable is left, e.g. for a title in a
+// non-Latin script.
+func SlugFrom(title string) Slug {
+	var b strings.Builder
+	dash := false
+	for _, r := range norm.NFD.String(strings.ToLower(title)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Combining accent left over from NFD.
+			continue
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		case slugTransliterations[r] != "":
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteString(slugTransliterations[r])
+			dash = false
+		default:
+			dash = true
+		}
+	}
+
+	s := b.String()
+	if len(s) > SlugMaxLength {
+		cut := s[:SlugMaxLength]
+		// Keep whole words unless a single word is longer than the limit.
+		if i := strings.LastIndexByte(cut, '-'); i > 0 && s[SlugMaxLength] != '-' {
+			cut = cut[:i]
+		}
+		s = strings.TrimRight(cut, "-")
+	}
+	return Slug(s)
+}
+
+func (s Slug) String() string {
+	return string(s)
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (s *Slug) UnmarshalJSON(b []byte) error {
+	var str string
+	if err := json.Unmarshal(b, &str); err != nil {
+		return ErrCodeSlugNotString.Err(nil)
+	}
+	if str == "" {
+		return ErrCodeSlugEmpty.Err(nil)
+	}
+	parsed, err := ParseSlug(str)
+	if err != nil {
+		return err
+	}
+
+	*s = parsed
+
+	return nil
+}
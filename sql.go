@@ -0,0 +1,417 @@
+package main
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The types implement sql.Scanner and driver.Valuer, so they can be used as
+// columns with database/sql and with ORMs built on it, see gorm.go:
+//
+//   - DateTime and NullDateTime are sent as a time.Time; a scanned instant
+//     is put in the field's default location, or DateTimeDefaultLocation,
+//     or UTC, like the other formats without an offset.
+//   - Date is sent as "2006-01-02" and reads a date or time.Time column.
+//   - ArrayString is sent in its text form and reads that form or a
+//     Postgres array such as {gift,express}.
+//   - Percentage is sent as a decimal number of percents, 12.5 for 12.5%.
+//   - MaskedString stores the unmasked value.
+//   - The other string-like types (Timezone, Semver, IPAddress, ...) store
+//     their text form and the object-like ones (GeoPoint, RetryPolicy, ...)
+//     their JSON form. The zero value is stored as NULL.
+//
+// Scanned values are checked like a request body, so a row that no longer
+// passes, say after PercentageMax was lowered, fails to scan. The integer
+// and string types (ByteSize, StringInt64, ObfuscatedID, Slug, ...) need
+// nothing: database/sql converts them by kind. Password and
+// CreditCardNumber refuse to be stored, so a struct holding one cannot write
+// the plaintext to a column by accident; store a hash or a token instead.
+
+/*
+	This part implements `sql.Scanner`
+	type Scanner interface {
+		Scan(src any) error
+	}
+*/
+func (dt *DateTime) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*dt = dt.withTime(time.Time{})
+		return nil
+	case time.Time:
+		if err := dt.setTime(v.In(dt.instantLocation())); err != nil {
+			return fmt.Errorf("cannot scan %v into DateTime: %w", v, err)
+		}
+		return nil
+	}
+	return scanText(dt, src)
+}
+
+/*
+	This part implements `driver.Valuer`
+	type Valuer interface {
+		Value() (driver.Value, error)
+	}
+*/
+func (dt DateTime) Value() (driver.Value, error) {
+	return dt.Time(), nil
+}
+
+func (ndt *NullDateTime) Scan(src interface{}) error {
+	if src == nil {
+		ndt.DateTime, ndt.Valid = ndt.DateTime.withTime(time.Time{}), false
+		return nil
+	}
+	if err := ndt.DateTime.Scan(src); err != nil {
+		return err
+	}
+	ndt.Valid = true
+	return nil
+}
+
+func (ndt NullDateTime) Value() (driver.Value, error) {
+	if ndt.IsZero() {
+		return nil, nil
+	}
+	return ndt.DateTime.Value()
+}
+
+func (d *Date) Scan(src interface{}) error {
+	if t, ok := src.(time.Time); ok {
+		*d = NewDate(t.Date())
+		return nil
+	}
+	return scanText(d, src)
+}
+
+func (d Date) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+func (dt *ArrayString) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*dt = nil
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into ArrayString", src)
+	}
+
+	switch {
+	case strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}"):
+		list, err := parsePostgresArray(s)
+		if err != nil {
+			return fmt.Errorf("cannot scan %q into ArrayString: %w", s, err)
+		}
+		*dt = list
+	case s == "":
+		*dt = ArrayString{}
+	default:
+		*dt = dt.parse(s)
+	}
+	return nil
+}
+
+func (dt ArrayString) Value() (driver.Value, error) {
+	if dt == nil {
+		return nil, nil
+	}
+	return dt.String(), nil
+}
+
+func (p *Percentage) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		*p = 0
+		return nil
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Percentage", src)
+	}
+	parsed, err := ParsePercentage(s + "%")
+	if err != nil {
+		return fmt.Errorf("cannot scan %q into Percentage: %w", s, err)
+	}
+	*p = parsed
+	return nil
+}
+
+func (p Percentage) Value() (driver.Value, error) {
+	return formatDecimal(int64(p), int64(Percent)), nil
+}
+
+func (ms *MaskedString) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		ms.value = ""
+	case string:
+		ms.value = v
+	case []byte:
+		ms.value = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into MaskedString", src)
+	}
+	return nil
+}
+
+func (ms MaskedString) Value() (driver.Value, error) {
+	if ms.IsZero() {
+		return nil, nil
+	}
+	return ms.value, nil
+}
+
+func (p Password) Value() (driver.Value, error) {
+	return nil, errors.New("cannot store a Password, store its hash instead")
+}
+
+func (cc CreditCardNumber) Value() (driver.Value, error) {
+	return nil, errors.New("cannot store a CreditCardNumber, store a token or its Last4 instead")
+}
+
+func (bs *BitString) Scan(src interface{}) error  { return scanText(bs, src) }
+func (bs BitString) Value() (driver.Value, error) { return valueText(bs) }
+
+func (bs *BoundedString[T]) Scan(src interface{}) error  { return scanText(bs, src) }
+func (bs BoundedString[T]) Value() (driver.Value, error) { return valueText(bs) }
+
+func (r *CellRange) Scan(src interface{}) error  { return scanText(r, src) }
+func (r CellRange) Value() (driver.Value, error) { return valueText(r) }
+
+func (c *CIDR) Scan(src interface{}) error  { return scanText(c, src) }
+func (c CIDR) Value() (driver.Value, error) { return valueText(c) }
+
+func (c *IPv4CIDR) Scan(src interface{}) error { return scanText(c, src) }
+func (c *IPv6CIDR) Scan(src interface{}) error { return scanText(c, src) }
+
+func (ck *CompositeKey) Scan(src interface{}) error  { return scanText(ck, src) }
+func (ck CompositeKey) Value() (driver.Value, error) { return valueText(ck) }
+
+func (c *CronExpression) Scan(src interface{}) error  { return scanText(c, src) }
+func (c CronExpression) Value() (driver.Value, error) { return valueText(c) }
+
+func (c *HexColor) Scan(src interface{}) error  { return scanText(c, src) }
+func (c HexColor) Value() (driver.Value, error) { return valueText(c) }
+
+func (hd *HTTPDate) Scan(src interface{}) error  { return scanText(hd, src) }
+func (hd HTTPDate) Value() (driver.Value, error) { return valueText(hd) }
+
+func (ip *IPAddress) Scan(src interface{}) error  { return scanText(ip, src) }
+func (ip IPAddress) Value() (driver.Value, error) { return valueText(ip) }
+
+func (ip *IPv4Address) Scan(src interface{}) error { return scanText(ip, src) }
+func (ip *IPv6Address) Scan(src interface{}) error { return scanText(ip, src) }
+
+func (lt *LanguageTag) Scan(src interface{}) error  { return scanText(lt, src) }
+func (lt LanguageTag) Value() (driver.Value, error) { return valueText(lt) }
+
+func (p *Period) Scan(src interface{}) error  { return scanText(p, src) }
+func (p Period) Value() (driver.Value, error) { return valueText(p) }
+
+func (r *Recurrence) Scan(src interface{}) error  { return scanText(r, src) }
+func (r Recurrence) Value() (driver.Value, error) { return valueText(r) }
+
+func (rp *RegexPattern) Scan(src interface{}) error  { return scanText(rp, src) }
+func (rp RegexPattern) Value() (driver.Value, error) { return valueText(rp) }
+
+func (rn *ResourceName) Scan(src interface{}) error  { return scanText(rn, src) }
+func (rn ResourceName) Value() (driver.Value, error) { return valueText(rn) }
+
+func (v *Semver) Scan(src interface{}) error  { return scanText(v, src) }
+func (v Semver) Value() (driver.Value, error) { return valueText(v) }
+
+func (sc *ShortCode) Scan(src interface{}) error  { return scanText(sc, src) }
+func (sc ShortCode) Value() (driver.Value, error) { return valueText(sc) }
+
+func (tz *Timezone) Scan(src interface{}) error  { return scanText(tz, src) }
+func (tz Timezone) Value() (driver.Value, error) { return valueText(tz) }
+
+func (bc *BreakerConfig) Scan(src interface{}) error  { return scanJSON(bc, src) }
+func (bc BreakerConfig) Value() (driver.Value, error) { return valueJSON(bc) }
+
+func (c *Channel) Scan(src interface{}) error  { return scanJSON(c, src) }
+func (c Channel) Value() (driver.Value, error) { return valueJSON(c) }
+
+func (dm *DelimitedMap) Scan(src interface{}) error  { return scanJSON(dm, src) }
+func (dm DelimitedMap) Value() (driver.Value, error) { return valueJSON(dm) }
+
+func (fm *FieldMapping) Scan(src interface{}) error  { return scanJSON(fm, src) }
+func (fm FieldMapping) Value() (driver.Value, error) { return valueJSON(fm) }
+
+func (gp *GeoPoint) Scan(src interface{}) error  { return scanJSON(gp, src) }
+func (gp GeoPoint) Value() (driver.Value, error) { return valueJSON(gp) }
+
+func (hc *HealthCheck) Scan(src interface{}) error  { return scanJSON(hc, src) }
+func (hc HealthCheck) Value() (driver.Value, error) { return valueJSON(hc) }
+
+func (ir *IntRange) Scan(src interface{}) error  { return scanJSON(ir, src) }
+func (ir IntRange) Value() (driver.Value, error) { return valueJSON(ir) }
+
+func (rp *RetryPolicy) Scan(src interface{}) error  { return scanJSON(rp, src) }
+func (rp RetryPolicy) Value() (driver.Value, error) { return valueJSON(rp) }
+
+// scanText reads a text column into target as the JSON string it would be
+// in a request body.
+func scanText(target json.Unmarshaler, src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+		setZero(target)
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("cannot scan %T into %s", src, scanTypeName(target))
+	}
+	if err := unmarshalTextAsJSON(target, []byte(s)); err != nil {
+		return fmt.Errorf("cannot scan %q into %s: %w", s, scanTypeName(target), err)
+	}
+	return nil
+}
+
+func valueText(v jsonZeroer) (driver.Value, error) {
+	if v.IsZero() {
+		return nil, nil
+	}
+	b, err := v.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// scanJSON reads a json, jsonb or text column into target.
+func scanJSON(target json.Unmarshaler, src interface{}) (err error) {
+	var b []byte
+	switch v := src.(type) {
+	case nil:
+		setZero(target)
+		return nil
+	case string:
+		b = []byte(v)
+	case []byte:
+		b = v
+	default:
+		return fmt.Errorf("cannot scan %T into %s", src, scanTypeName(target))
+	}
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("cannot scan %s into %s: %w", b, scanTypeName(target), err)
+		}
+	}()
+	defer recoverBadRequest(&err)
+	return target.UnmarshalJSON(b)
+}
+
+// valueJSON sends the JSON form as a string, which json and jsonb columns
+// accept, see JSONRaw.Value.
+func valueJSON(v jsonZeroer) (driver.Value, error) {
+	if v.IsZero() {
+		return nil, nil
+	}
+	b, err := v.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func setZero(target interface{}) {
+	v := reflect.ValueOf(target).Elem()
+	v.Set(reflect.Zero(v.Type()))
+}
+
+func scanTypeName(target interface{}) string {
+	return reflect.TypeOf(target).Elem().Name()
+}
+
+// parsePostgresArray reads a one-dimensional text[] literal such as
+// {gift,"two words","a \"quote\""}. NULL elements are read as "".
+func parsePostgresArray(s string) ([]string, error) {
+	s = s[1 : len(s)-1]
+	list := []string{}
+	if s == "" {
+		return list, nil
+	}
+	for {
+		var item strings.Builder
+		if strings.HasPrefix(s, `"`) {
+			i := 1
+			for ; i < len(s) && s[i] != '"'; i++ {
+				if s[i] == '\\' && i+1 < len(s) {
+					i++
+				}
+				item.WriteByte(s[i])
+			}
+			if i == len(s) {
+				return nil, errors.New("unterminated quoted element")
+			}
+			s = s[i+1:]
+		} else {
+			end := strings.IndexByte(s, ',')
+			if end == -1 {
+				end = len(s)
+			}
+			if element := strings.TrimSpace(s[:end]); element != "NULL" {
+				item.WriteString(element)
+			}
+			s = s[end:]
+		}
+		list = append(list, item.String())
+
+		if s == "" {
+			return list, nil
+		}
+		if s[0] != ',' {
+			return nil, errors.New("expected , between elements")
+		}
+		s = s[1:]
+	}
+}
+
+// formatPostgresArray writes list as a text[] literal, quoting every
+// element.
+func formatPostgresArray(list []string) string {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, item := range list {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('"')
+		for j := 0; j < len(item); j++ {
+			if item[j] == '"' || item[j] == '\\' {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(item[j])
+		}
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
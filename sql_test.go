@@ -0,0 +1,90 @@
+package main
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+func TestSecretsRefuseToBeStored(t *testing.T) {
+	card, err := ParseCreditCardNumber("4111 1111 1111 1111")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []driver.Valuer{Password("hunter2hunter2"), card, Password(""), CreditCardNumber{}} {
+		if v, err := secret.Value(); err == nil {
+			t.Errorf("%T stored as %v", secret, v)
+		}
+	}
+}
+
+func TestDurationScanIntervalOutOfRange(t *testing.T) {
+	for _, s := range []string{
+		"00:00:1e300",
+		"00:00:9300000000",
+		"2562048:00:00",
+		"106752 days",
+		"106751 days 24:00:00",
+	} {
+		var d Duration
+		if err := d.Scan(s); err == nil {
+			t.Errorf("Scan(%q) = %s, want an out of range error", s, d)
+		}
+	}
+
+	var d Duration
+	if err := d.Scan("-2 days 01:30:00.5"); err != nil || d.Duration() != -(46*time.Hour+29*time.Minute+59500*time.Millisecond) {
+		t.Errorf("Scan = %s, %v", d, err)
+	}
+}
+
+func TestRangeScanNull(t *testing.T) {
+	from, err := ParseDate("2024-03-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dr, err := NewDateRange(from, from.AddDays(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := dr.Scan(nil); err != nil || !dr.IsZero() {
+		t.Errorf("DateRange.Scan(nil) = %s, %v, want the zero range", dr, err)
+	}
+	if v, err := dr.Value(); err != nil || v != nil {
+		t.Errorf("zero DateRange stored as %v, %v, want NULL", v, err)
+	}
+
+	tr, err := NewTimeRange(MustParseDateTime("2024-03-01T10:00:00Z"), MustParseDateTime("2024-03-01T11:00:00Z"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.Scan(nil); err != nil || !tr.IsZero() {
+		t.Errorf("TimeRange.Scan(nil) = %s, %v, want the zero range", tr, err)
+	}
+	if v, err := tr.Value(); err != nil || v != nil {
+		t.Errorf("zero TimeRange stored as %v, %v, want NULL", v, err)
+	}
+}
+
+func TestRangeScanChecksBounds(t *testing.T) {
+	var dr DateRange
+	if err := dr.Scan("[2024-03-05,2024-03-01)"); err == nil {
+		t.Errorf("DateRange scanned a reversed range as %s", dr)
+	}
+	var tr TimeRange
+	if err := tr.Scan(`["2024-03-01 11:00:00+07","2024-03-01 10:00:00+07")`); err == nil {
+		t.Errorf("TimeRange scanned a reversed range as %s", tr)
+	}
+
+	defer func(days int64, span time.Duration) { DateRangeMaxDays, TimeRangeMaxDuration = days, span }(DateRangeMaxDays, TimeRangeMaxDuration)
+	DateRangeMaxDays, TimeRangeMaxDuration = 7, time.Hour
+	if err := dr.Scan("[2024-03-01,2024-04-01)"); err == nil {
+		t.Errorf("DateRange scanned %s past DateRangeMaxDays", dr)
+	}
+	if err := tr.Scan(`["2024-03-01 10:00:00+07","2024-03-01 12:00:00+07")`); err == nil {
+		t.Errorf("TimeRange scanned %s past TimeRangeMaxDuration", tr)
+	}
+	if err := dr.Scan("[2024-03-01,2024-03-04)"); err != nil || dr.Days() != 3 {
+		t.Errorf("DateRange.Scan = %s, %v", dr, err)
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+)
+
+// DecodeStreamMaxErrors is how many invalid elements DecodeStream reports
+// before it stops reading.
+var DecodeStreamMaxErrors = 100
+
+// DecodeStream reads a JSON array from r one element at a time and calls fn
+// with each valid one, so an import of millions of objects only ever holds
+// one of them:
+//
+//	router.POST("/shipments/import", func(ctx *gin.Context) {
+//		err := DecodeStream(ctx.Request.Body, func(s ReportShipment) error {
+//			return store.Save(s)
+//		})
+//		...
+//	})
+//
+// Each element is decoded like a JSONBinding request body, with ctype tags
+// and validation. An invalid element is skipped and reported once the array
+// ends, in FieldErrors whose Field is the element's index followed by the
+// field, e.g. "3.ship_at". An error from fn stops the stream and is
+// returned as it is; malformed JSON stops it too.
+func DecodeStream[T any](r io.Reader, fn func(T) error) error {
+	dec := json.NewDecoder(r)
+	token, err := dec.Token()
+	if err != nil {
+		return TranslateJSONError(err)
+	}
+	if token != json.Delim('[') {
+		return FieldErrors{newFieldError("body", ErrCodeWrongType.Err(map[string]string{"type": "an array", "value": jsonTokenKind(token)}))}
+	}
+
+	var fe FieldErrors
+	for index := 0; dec.More(); index++ {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return TranslateJSONError(err)
+		}
+		var element T
+		if err := decodeStreamElement(raw, &element); err != nil {
+			fe = append(fe, streamElementErrors(index, err)...)
+			if len(fe) >= DecodeStreamMaxErrors {
+				return fe
+			}
+			continue
+		}
+		if err := fn(element); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return TranslateJSONError(err)
+	}
+	if len(fe) > 0 {
+		return fe
+	}
+	return nil
+}
+
+func decodeStreamElement(raw json.RawMessage, element interface{}) (err error) {
+	defer recoverBadRequest(&err)
+
+	return JSONBinding.BindBody(raw, element)
+}
+
+// streamElementErrors names the fields of err after the element's index.
+func streamElementErrors(index int, err error) FieldErrors {
+	prefix := strconv.Itoa(index)
+	err = TranslateValidationErrors(err)
+	var fe FieldErrors
+	if !errors.As(err, &fe) {
+		err = TranslateJSONError(err)
+		if !errors.As(err, &fe) {
+			return FieldErrors{newFieldError(prefix, err)}
+		}
+	}
+	named := make(FieldErrors, len(fe))
+	for i, e := range fe {
+		if e.Field == "body" {
+			e.Field = prefix
+		} else {
+			e.Field = prefix + "." + e.Field
+		}
+		named[i] = e
+	}
+	return named
+}
+
+// jsonTokenKind names a token the way json.UnmarshalTypeError.Value does.
+func jsonTokenKind(token json.Token) string {
+	switch token.(type) {
+	case json.Delim:
+		return "object"
+	case bool:
+		return "bool"
+	case float64, json.Number:
+		return "number"
+	case string:
+		return "string"
+	}
+	return "null"
+}
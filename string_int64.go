@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrCodeStringInt64Invalid = RegisterErrorCode(ErrorCode{
+		Code: "string_int64.invalid", Status: http.StatusBadRequest,
+		Message: "must be a valid integer or numeric string", Example: "must be a valid integer or numeric string",
+	})
+	ErrCodeStringInt64Empty = RegisterErrorCode(ErrorCode{
+		Code: "string_int64.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeStringInt64OutOfRange = RegisterErrorCode(ErrorCode{
+		Code: "string_int64.out_of_range", Status: http.StatusBadRequest,
+		Message: "must be between -9223372036854775808 and 9223372036854775807", Example: "must be between -9223372036854775808 and 9223372036854775807",
+	})
+	ErrCodeStringInt64Fraction = RegisterErrorCode(ErrorCode{
+		Code: "string_int64.fraction", Status: http.StatusBadRequest,
+		Message: "must be a whole number", Example: "must be a whole number",
+	})
+)
+
+// StringInt64QuoteOutput controls whether StringInt64 marshals as a quoted
+// string (the default, safe for JavaScript clients beyond 2^53) or as a plain
+// JSON number.
+var StringInt64QuoteOutput = true
+
+// StringInt64 accepts both 123 and "123" on input.
+type StringInt64 int64
+
+func (si StringInt64) Int64() int64 {
+	return int64(si)
+}
+
+func (si StringInt64) String() string {
+	return strconv.FormatInt(int64(si), 10)
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (si StringInt64) MarshalJSON() ([]byte, error) {
+	if StringInt64QuoteOutput {
+		return []byte(`"` + si.String() + `"`), nil
+	}
+	return []byte(si.String()), nil
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (si *StringInt64) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+
+	var s string
+	if len(b) > 0 && b[0] == '"' {
+		if err := json.Unmarshal(b, &s); err != nil {
+			return ErrCodeStringInt64Invalid.Err(nil)
+		}
+		if s == "" {
+			return ErrCodeStringInt64Empty.Err(nil)
+		}
+	} else {
+		var n json.Number
+		if err := json.Unmarshal(b, &n); err != nil {
+			return ErrCodeStringInt64Invalid.Err(nil)
+		}
+		s = n.String()
+	}
+
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		if numErr, ok := err.(*strconv.NumError); ok && numErr.Err == strconv.ErrRange {
+			return ErrCodeStringInt64OutOfRange.Err(nil)
+		}
+		if _, err := strconv.ParseFloat(s, 64); err == nil || strings.ContainsAny(s, ".eE") {
+			return ErrCodeStringInt64Fraction.Err(nil)
+		}
+		return ErrCodeStringInt64Invalid.Err(nil)
+	}
+
+	*si = StringInt64(i)
+
+	return nil
+}
+
+/*
+	This part implements `encoding.TextMarshaler`
+	type TextMarshaler interface {
+		MarshalText() (text []byte, err error)
+	}
+*/
+func (si StringInt64) MarshalText() ([]byte, error) {
+	return []byte(si.String()), nil
+}
+
+/*
+	This part implements `encoding.TextUnmarshaler`
+	type TextUnmarshaler interface {
+		UnmarshalText(text []byte) error
+	}
+*/
+func (si *StringInt64) UnmarshalText(b []byte) error {
+	return unmarshalTextAsJSON(si, b)
+}
+
+/*
+	This part implements BurntSushi/toml's `toml.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalTOML(interface{}) error
+	}
+*/
+func (si *StringInt64) UnmarshalTOML(value interface{}) error {
+	return unmarshalValueAsJSON(si, value)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// DateTime, Date, Duration, ArrayString, Timezone, Semver and the numeric
+// and ID types also implement encoding.TextMarshaler and TextUnmarshaler,
+// for formats that carry values as text. encoding/xml uses them for both
+// elements and attributes, so ctx.ShouldBindXML and ctx.XML work with the
+// types:
+//
+//	type RequestContentPartnerOrder struct {
+//		XMLName  xml.Name    `xml:"order"`
+//		ID       StringInt64 `xml:"id,attr"`
+//		PlacedAt DateTime    `xml:"placed_at"`
+//		Tags     ArrayString `xml:"tags"`
+//	}
+//
+// Env-config libraries such as caarlos0/env and kelseyhightower/envconfig
+// use TextUnmarshaler as well, so CACHE_TTL=1h30m decodes into a Duration
+// and ALLOWED_ORIGINS=a.com,b.com into an ArrayString:
+//
+//	type EnvConfig struct {
+//		CacheTTL       Duration    `env:"CACHE_TTL"`
+//		AllowedOrigins ArrayString `env:"ALLOWED_ORIGINS"`
+//	}
+//
+// The text form is the JSON value without quotes.
+
+// unmarshalTextAsJSON decodes text as the JSON string it would be in a
+// request body, so text formats get the same validation and messages.
+func unmarshalTextAsJSON(target json.Unmarshaler, text []byte) (err error) {
+	defer recoverBadRequest(&err)
+
+	raw, _ := json.Marshal(string(text))
+	return target.UnmarshalJSON(raw)
+}
+
+// formatText is the text form of a field, for formats that write values as
+// text only. A JSON-only type is written as its JSON string. Plain values
+// are formatted by kind, not by a String method, so they parse back.
+func formatText(field reflect.Value) (string, error) {
+	switch value := field.Interface().(type) {
+	case encoding.TextMarshaler:
+		b, err := value.MarshalText()
+		return string(b), err
+	case time.Time:
+		return value.Format(time.RFC3339Nano), nil
+	case json.Marshaler:
+		b, err := value.MarshalJSON()
+		if err != nil {
+			return "", err
+		}
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return "", fmt.Errorf("%s has no text form", field.Type())
+		}
+		return s, nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		return field.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(field.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(field.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(field.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(field.Float(), 'f', -1, field.Type().Bits()), nil
+	}
+	return "", fmt.Errorf("%s has no text form", field.Type())
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	// Embeds the IANA database so validation does not depend on the host
+	// having /usr/share/zoneinfo installed.
+	_ "time/tzdata"
+)
+
+var (
+	ErrCodeTimezoneNotString = RegisterErrorCode(ErrorCode{
+		Code: "timezone.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeTimezoneEmpty = RegisterErrorCode(ErrorCode{
+		Code: "timezone.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeTimezoneUnknown = RegisterErrorCode(ErrorCode{
+		Code: "timezone.unknown", Status: http.StatusBadRequest,
+		Message: "unknown time zone {value}, must be an IANA name like Asia/Jakarta", Params: []string{"value"}, Example: "unknown time zone Mars/Base, must be an IANA name like Asia/Jakarta",
+		Kind: ErrInvalidFormat,
+	})
+)
+
+// Timezone is an IANA zone name such as "Asia/Jakarta".
+type Timezone struct {
+	location *time.Location
+}
+
+func LoadTimezone(name string) (Timezone, error) {
+	if name == "" || name == "Local" || strings.HasPrefix(name, "/") || strings.Contains(name, "..") {
+		return Timezone{}, errUnknownTimezone(name)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return Timezone{}, errUnknownTimezone(name)
+	}
+	return Timezone{location: loc}, nil
+}
+
+func errUnknownTimezone(name string) error {
+	return ErrCodeTimezoneUnknown.Err(map[string]string{"value": name})
+}
+
+// Location falls back to UTC for the zero value.
+func (tz Timezone) Location() *time.Location {
+	if tz.location == nil {
+		return time.UTC
+	}
+	return tz.location
+}
+
+// Convert returns the same instant as seen on the wall clock of this zone.
+func (tz Timezone) Convert(dt DateTime) DateTime {
+	return DateTime{time: dt.Time().In(tz.Location()), precision: dt.precision}
+}
+
+func (tz Timezone) String() string {
+	return tz.Location().String()
+}
+
+func (tz Timezone) IsZero() bool {
+	return tz.location == nil
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (tz Timezone) MarshalJSON() ([]byte, error) {
+	return json.Marshal(tz.String())
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+*/
+func (tz *Timezone) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return ErrCodeTimezoneNotString.Err(nil)
+	}
+	if s == "" {
+		return ErrCodeTimezoneEmpty.Err(nil)
+	}
+	loaded, err := LoadTimezone(s)
+	if err != nil {
+		return err
+	}
+
+	*tz = loaded
+
+	return nil
+}
+
+/*
+	This part implements `encoding.TextMarshaler`
+	type TextMarshaler interface {
+		MarshalText() (text []byte, err error)
+	}
+*/
+func (tz Timezone) MarshalText() ([]byte, error) {
+	return []byte(tz.String()), nil
+}
+
+/*
+	This part implements `encoding.TextUnmarshaler`
+	type TextUnmarshaler interface {
+		UnmarshalText(text []byte) error
+	}
+*/
+func (tz *Timezone) UnmarshalText(b []byte) error {
+	return unmarshalTextAsJSON(tz, b)
+}
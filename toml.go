@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/json"
+)
+
+// The types with text methods (DateTime, ArrayString, Duration, ByteSize,
+// StringInt64, ObfuscatedID, Percentage and BasisPoints) can be used in
+// TOML config files:
+//
+//   - BurntSushi/toml calls UnmarshalTOML with the decoded value, so a
+//     native TOML offset datetime is taken as is into a DateTime (a local
+//     date or datetime is checked as text), an ArrayString
+//     can be written as a TOML array and a ByteSize as a bare number of
+//     bytes.
+//   - pelletier/go-toml calls UnmarshalText with the value's text, so
+//     datetimes must be in DateTimeLayouts (with a "T", not a space) and
+//     an ArrayString is a string such as "id,sg".
+//
+// Both write the values with MarshalText, as TOML strings. Validation and
+// messages are those of a JSON body.
+
+// tomlLocalLayouts are the layouts of BurntSushi/toml's local date and time
+// values, by the name of the time.Location it gives them.
+var tomlLocalLayouts = map[string]string{
+	"datetime-local": "2006-01-02T15:04:05.999999999",
+	"date-local":     "2006-01-02",
+	"time-local":     "15:04:05.999999999",
+}
+
+// unmarshalValueAsJSON decodes a value handed over already decoded, by
+// BurntSushi/toml (string, int64, float64, bool, time.Time, []interface{}
+// or map[string]interface{}) or by gqlgen, as the same value in a JSON
+// body.
+func unmarshalValueAsJSON(target json.Unmarshaler, value interface{}) (err error) {
+	defer recoverBadRequest(&err)
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return target.UnmarshalJSON(raw)
+}
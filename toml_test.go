@@ -0,0 +1,141 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	burntsushi "github.com/BurntSushi/toml"
+	gotoml "github.com/pelletier/go-toml/v2"
+)
+
+type tomlConfig struct {
+	Timeout   Duration     `toml:"timeout"`
+	MaxUpload ByteSize     `toml:"max_upload"`
+	Since     DateTime     `toml:"since"`
+	Regions   ArrayString  `toml:"regions"`
+	ID        ObfuscatedID `toml:"id"`
+	Requests  StringInt64  `toml:"requests"`
+	Sample    Percentage   `toml:"sample"`
+	Fee       BasisPoints  `toml:"fee"`
+}
+
+func (c tomlConfig) summary() string {
+	return strings.Join([]string{
+		c.Timeout.String(), c.MaxUpload.String(), c.Since.String(), strings.Join(c.Regions.List(), "|"),
+		c.ID.String(), c.Requests.String(), c.Sample.String(), c.Fee.String(),
+	}, " ")
+}
+
+const tomlDocument = `
+timeout = "90s"
+max_upload = "1MiB"
+since = "2020-01-01T02:02:05+07:00"
+regions = "id,sg"
+id = "BEAsrn5Q"
+requests = "9007199254740993"
+sample = "12.5%"
+fee = "125"
+`
+
+const tomlSummary = "1m30s 1MiB 2020-01-01T02:02:05+07:00 id|sg BEAsrn5Q 9007199254740993 12.5% 125bp"
+
+func TestTOMLDecode(t *testing.T) {
+	var bs tomlConfig
+	if _, err := burntsushi.Decode(tomlDocument, &bs); err != nil {
+		t.Fatalf("BurntSushi/toml: %v", err)
+	}
+	if got := bs.summary(); got != tomlSummary {
+		t.Errorf("BurntSushi/toml decoded %s, want %s", got, tomlSummary)
+	}
+
+	var pt tomlConfig
+	if err := gotoml.Unmarshal([]byte(tomlDocument), &pt); err != nil {
+		t.Fatalf("go-toml: %v", err)
+	}
+	if got := pt.summary(); got != tomlSummary {
+		t.Errorf("go-toml decoded %s, want %s", got, tomlSummary)
+	}
+}
+
+// BurntSushi/toml hands UnmarshalTOML native values: a TOML datetime, an
+// array and bare numbers.
+func TestTOMLDecodeNativeValues(t *testing.T) {
+	var c tomlConfig
+	_, err := burntsushi.Decode(`
+timeout = "90s"
+max_upload = 1048576
+since = 2020-01-01T02:02:05+07:00
+regions = ["id", "sg"]
+id = "BEAsrn5Q"
+requests = 42
+sample = 12.5
+fee = 125
+`, &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := c.summary(), "1m30s 1MiB 2020-01-01T02:02:05+07:00 id|sg BEAsrn5Q 42 12.5% 125bp"; got != want {
+		t.Errorf("decoded %s, want %s", got, want)
+	}
+}
+
+func TestTOMLDecodeErrors(t *testing.T) {
+	for _, tc := range []struct {
+		document string
+		message  string
+	}{
+		{`timeout = "soon"`, "format must be a duration like 1h30m or 90s"},
+		{`since = "2020-01-01"`, "format must be YYYY-MM-DDTHH:mm:ssZ"},
+		{`regions = ""`, "must not be empty"},
+		{`id = "42"`, "must be a valid ID"},
+	} {
+		var bs tomlConfig
+		if _, err := burntsushi.Decode(tc.document, &bs); err == nil || !strings.Contains(err.Error(), tc.message) {
+			t.Errorf("BurntSushi/toml %s: error %v, want %q", tc.document, err, tc.message)
+		}
+		var pt tomlConfig
+		if err := gotoml.Unmarshal([]byte(tc.document), &pt); err == nil || !strings.Contains(err.Error(), tc.message) {
+			t.Errorf("go-toml %s: error %v, want %q", tc.document, err, tc.message)
+		}
+	}
+
+	// A local date or datetime has no offset, so it is not a DateTime,
+	// whichever library hands it over.
+	for _, document := range []string{`since = 2020-01-01`, `since = 2020-01-01T10:00:00`} {
+		var bs tomlConfig
+		if _, err := burntsushi.Decode(document, &bs); err == nil || !strings.Contains(err.Error(), "format must be YYYY-MM-DDTHH:mm:ssZ") {
+			t.Errorf("BurntSushi/toml %s: error %v", document, err)
+		}
+		var pt tomlConfig
+		if err := gotoml.Unmarshal([]byte(document), &pt); err == nil || !strings.Contains(err.Error(), "format must be YYYY-MM-DDTHH:mm:ssZ") {
+			t.Errorf("go-toml %s: error %v", document, err)
+		}
+	}
+}
+
+func TestTOMLEncode(t *testing.T) {
+	var c tomlConfig
+	if err := gotoml.Unmarshal([]byte(tomlDocument), &c); err != nil {
+		t.Fatal(err)
+	}
+
+	var bs strings.Builder
+	if err := burntsushi.NewEncoder(&bs).Encode(c); err != nil {
+		t.Fatalf("BurntSushi/toml: %v", err)
+	}
+	pt, err := gotoml.Marshal(c)
+	if err != nil {
+		t.Fatalf("go-toml: %v", err)
+	}
+
+	for name, encoded := range map[string]string{"BurntSushi/toml": bs.String(), "go-toml": string(pt)} {
+		var back tomlConfig
+		if _, err := burntsushi.Decode(encoded, &back); err != nil {
+			t.Errorf("%s output does not decode: %v\n%s", name, err, encoded)
+			continue
+		}
+		if got := back.summary(); got != tomlSummary {
+			t.Errorf("%s round trip %s, want %s\n%s", name, got, tomlSummary, encoded)
+		}
+	}
+}
@@ -0,0 +1,449 @@
+// Package typetest is a conformance suite for custom types. Run it from a
+// test in the package that declares the type, before relying on the type in
+// request structs:
+//
+//	func TestDateTimeConformance(t *testing.T) {
+//		typetest.Conformance(t, typetest.Codec[DateTime]{
+//			Valid:   []string{`"2020-01-01T02:02:05+07:00"`, `"2020-01-01T02:02:05Z"`},
+//			Invalid: []string{`""`, `true`, `"2020-01-01"`},
+//		})
+//	}
+//
+// Rejection may be reported either by returning an error or by panicking,
+// the way this package's types panic with BadRequestError; a panic only
+// counts when Codec.AllowPanic accepts it. Benchmark and Fuzz take the same
+// Codec.
+package typetest
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+// Codec describes the type under test by example.
+type Codec[T any] struct {
+	// Valid are JSON documents that must unmarshal into T.
+	Valid []string
+	// Invalid are JSON documents that must be rejected with a non-empty
+	// message.
+	Invalid []string
+	// Equal compares two values. When nil, T's own Equal(T) bool method is
+	// used if it has one, and the JSON encodings are compared otherwise.
+	Equal func(a T, b T) bool
+	// Engines are other JSON libraries T must behave the same in:
+	//
+	//	Engines: []typetest.Engine{{Name: "sonic", Marshal: sonic.Marshal, Unmarshal: sonic.Unmarshal}},
+	Engines []Engine
+	// AllowPanic tells Conformance, Fuzz and Check which panics of
+	// UnmarshalJSON count as rejecting the input, e.g. a package's legacy
+	// BadRequestError; when nil, any panic is a failure.
+	AllowPanic func(recovered interface{}) bool
+	// WriteOnly is for types that print a mask, such as a password or card
+	// number, which is not meant to be decoded again. Fuzz and Check then
+	// only require that accepted input marshals.
+	WriteOnly bool
+}
+
+// Engine is a JSON library's Marshal and Unmarshal.
+type Engine struct {
+	Name      string
+	Marshal   func(v interface{}) ([]byte, error)
+	Unmarshal func(data []byte, v interface{}) error
+}
+
+// Conformance checks, for every interface T implements:
+//
+//   - JSON: valid inputs decode, re-encode stably and round-trip; invalid
+//     inputs are rejected, by an error or a panic c.AllowPanic accepts; the
+//     zero value encodes without panicking.
+//   - encoding.TextMarshaler/TextUnmarshaler: values round-trip.
+//   - driver.Valuer/sql.Scanner: Value can be scanned back into an equal
+//     value, and Scan does not keep the caller's []byte.
+//   - Clone() T, if declared: the clone is equal to the original.
+//   - Each of Engines: every example, alone and as a struct field, decodes
+//     to the same value, or fails with the same error or panic, and the
+//     values encode to the same bytes as with encoding/json.
+func Conformance[T any](t *testing.T, c Codec[T]) {
+	t.Helper()
+	if len(c.Valid) == 0 {
+		t.Fatal("typetest: Codec.Valid needs at least one example")
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		for _, input := range c.Valid {
+			v, err := decodeJSON[T]([]byte(input))
+			if err != nil {
+				t.Errorf("unmarshal %s: %v", input, err)
+				continue
+			}
+			first, err := encodeJSON(v)
+			if err != nil {
+				t.Errorf("marshal value from %s: %v", input, err)
+				continue
+			}
+			again, err := decodeJSON[T](first)
+			if err != nil {
+				t.Errorf("unmarshal own output %s (from %s): %v", first, input, err)
+				continue
+			}
+			second, err := encodeJSON(again)
+			if err != nil {
+				t.Errorf("marshal round-tripped value from %s: %v", input, err)
+				continue
+			}
+			if !bytes.Equal(first, second) {
+				t.Errorf("output is not stable: %s, then %s", first, second)
+			}
+			if !c.equal(v, again) {
+				t.Errorf("round trip of %s changed the value: %#v, then %#v", input, v, again)
+			}
+		}
+
+		for _, input := range c.Invalid {
+			var v T
+			rejected, err := catchPanic(c, func() error { return json.Unmarshal([]byte(input), &v) })
+			switch {
+			case err != nil:
+				t.Errorf("unmarshal %s: %v", input, err)
+			case rejected == nil:
+				t.Errorf("unmarshal %s: accepted, want rejected", input)
+			case rejected.Error() == "":
+				t.Errorf("unmarshal %s: rejected without a message", input)
+			}
+		}
+
+		var zero T
+		if _, err := encodeJSON(zero); err != nil {
+			t.Errorf("marshal zero value: %v", err)
+		}
+	})
+
+	samples := make([]T, 0, len(c.Valid))
+	for _, input := range c.Valid {
+		if v, err := decodeJSON[T]([]byte(input)); err == nil {
+			samples = append(samples, v)
+		}
+	}
+
+	var zero T
+	if _, ok := interface{}(zero).(encoding.TextMarshaler); ok {
+		if _, ok := interface{}(&zero).(encoding.TextUnmarshaler); ok {
+			t.Run("Text", func(t *testing.T) {
+				for _, v := range samples {
+					text, err := catch(func() ([]byte, error) {
+						return interface{}(v).(encoding.TextMarshaler).MarshalText()
+					})
+					if err != nil {
+						t.Errorf("MarshalText %#v: %v", v, err)
+						continue
+					}
+					var back T
+					if _, err := catch(func() ([]byte, error) {
+						return nil, interface{}(&back).(encoding.TextUnmarshaler).UnmarshalText(text)
+					}); err != nil {
+						t.Errorf("UnmarshalText %q: %v", text, err)
+						continue
+					}
+					if !c.equal(v, back) {
+						t.Errorf("text round trip of %q changed the value: %#v, then %#v", text, v, back)
+					}
+				}
+			})
+		}
+	}
+
+	if _, ok := interface{}(zero).(driver.Valuer); ok {
+		if _, ok := interface{}(&zero).(sql.Scanner); ok {
+			t.Run("SQL", func(t *testing.T) {
+				for _, v := range samples {
+					value, err := interface{}(v).(driver.Valuer).Value()
+					if err != nil {
+						t.Errorf("Value %#v: %v", v, err)
+						continue
+					}
+					if value != nil && !driver.IsValue(value) {
+						t.Errorf("Value %#v returned %T, which is not a driver.Value", v, value)
+						continue
+					}
+					// Drivers reuse their buffers, so scan from a copy and
+					// scribble over it afterwards.
+					src := value
+					buf, isBytes := value.([]byte)
+					if isBytes {
+						buf = append([]byte(nil), buf...)
+						src = buf
+					}
+					var back T
+					if err := interface{}(&back).(sql.Scanner).Scan(src); err != nil {
+						t.Errorf("Scan %#v: %v", value, err)
+						continue
+					}
+					for i := range buf {
+						buf[i] = 0
+					}
+					if !c.equal(v, back) {
+						t.Errorf("SQL round trip changed the value: %#v, then %#v", v, back)
+					}
+				}
+			})
+		}
+	}
+
+	if _, ok := reflect.TypeOf(&zero).Elem().MethodByName("Clone"); ok {
+		t.Run("Clone", func(t *testing.T) {
+			for _, v := range samples {
+				clone, ok := interface{}(v).(interface{ Clone() T })
+				if !ok {
+					t.Fatalf("Clone must have the signature func() %T", zero)
+				}
+				if !c.equal(v, clone.Clone()) {
+					t.Errorf("Clone changed the value: %#v, then %#v", v, clone.Clone())
+				}
+			}
+		})
+	}
+
+	for _, engine := range c.Engines {
+		engine := engine
+		t.Run("Engine/"+engine.Name, func(t *testing.T) {
+			for _, input := range append(append([]string(nil), c.Valid...), c.Invalid...) {
+				want, got := decodeWith[T](json.Unmarshal, []byte(input)), decodeWith[T](engine.Unmarshal, []byte(input))
+				if got != want {
+					t.Errorf("unmarshal %s: %s, encoding/json: %s", input, got, want)
+				}
+				field := []byte(`{"v":` + input + `}`)
+				want, got = decodeWith[fieldOf[T]](json.Unmarshal, field), decodeWith[fieldOf[T]](engine.Unmarshal, field)
+				if got != want {
+					t.Errorf("unmarshal %s: %s, encoding/json: %s", field, got, want)
+				}
+			}
+			for _, v := range append(samples, zero) {
+				want, got := encodeWith(json.Marshal, v), encodeWith(engine.Marshal, v)
+				if got != want {
+					t.Errorf("marshal %#v: %s, encoding/json: %s", v, got, want)
+				}
+			}
+		})
+	}
+}
+
+// Benchmark times json.Unmarshal and json.Marshal of each of c.Valid, and
+// of each of c.Engines, with allocations reported:
+//
+//	func BenchmarkDateTime(b *testing.B) {
+//		typetest.Benchmark(b, dateTimeCodec)
+//	}
+//
+// Run it with -benchmem and compare runs with benchstat.
+func Benchmark[T any](b *testing.B, c Codec[T]) {
+	if len(c.Valid) == 0 {
+		b.Fatal("typetest: Codec.Valid needs at least one example")
+	}
+	engines := append([]Engine{{Name: "encoding/json", Marshal: json.Marshal, Unmarshal: json.Unmarshal}}, c.Engines...)
+	for _, engine := range engines {
+		engine := engine
+		for i, input := range c.Valid {
+			data := []byte(input)
+			v, err := decodeJSON[T](data)
+			if err != nil {
+				b.Fatalf("%s: valid input rejected: %v", input, err)
+			}
+			name := fmt.Sprintf("%s/%d", engine.Name, i)
+			b.Run(name+"/Unmarshal", func(b *testing.B) {
+				b.ReportAllocs()
+				var target T
+				for n := 0; n < b.N; n++ {
+					_ = engine.Unmarshal(data, &target)
+				}
+			})
+			b.Run(name+"/Marshal", func(b *testing.B) {
+				b.ReportAllocs()
+				var boxed interface{} = v
+				for n := 0; n < b.N; n++ {
+					_, _ = engine.Marshal(boxed)
+				}
+			})
+		}
+	}
+}
+
+// Corpus is the seed corpus Fuzz adds for every type, after the type's own
+// Valid and Invalid examples: empty, oversized and out-of-range values, bad
+// escapes and UTF-8, and every JSON kind.
+var Corpus = []string{
+	`null`, `true`, `0`, `-0`, `1.5`, `1e309`, `-9223372036854775809`, `18446744073709551616`,
+	`[]`, `[null]`, `{}`, `{"":null}`, `[[[[[[[[[[[[[[[[[[[[]]]]]]]]]]]]]]]]]]]]`,
+	`""`, `" "`, `"\u0000"`, `"\ud800"`, "\"\xff\xfe\"", `"\u2028"`, `"\\"`, `"\""`,
+	`"-1"`, `"0"`, `"NaN"`, `"Infinity"`, `"0x1F"`, `"1e999"`, `"99999999999999999999"`,
+	`","`, `",,,"`, `":"`, `"::"`, `"/"`, `"../../etc/passwd"`, `"%00"`, `"${jndi:ldap://x}"`,
+	`"9999-12-31T23:59:59.999999999+23:59"`, `"0000-01-01T00:00:00Z"`, `"2020-02-30T00:00:00Z"`,
+	`"` + strings.Repeat("9", 400) + `"`, `"` + strings.Repeat("a,", 5000) + `"`,
+}
+
+// Fuzz is a native fuzz target for T's UnmarshalJSON. No input may panic
+// outside c.AllowPanic, and an accepted input must marshal, unmarshal
+// again and marshal to the same bytes:
+//
+//	func FuzzDateTimeUnmarshal(f *testing.F) {
+//		typetest.Fuzz(f, dateTimeCodec)
+//	}
+//
+// The seed corpus is c.Valid, c.Invalid and Corpus; run it with
+// go test -fuzz=FuzzDateTimeUnmarshal, and plain go test replays the seeds
+// and whatever testdata/fuzz has kept.
+func Fuzz[T any](f *testing.F, c Codec[T]) {
+	for _, seeds := range [][]string{c.Valid, c.Invalid, Corpus} {
+		for _, seed := range seeds {
+			f.Add([]byte(seed))
+		}
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if err := Check(c, data); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+// Check is one run of Fuzz: it returns how T breaks the rules on data, or
+// nil.
+func Check[T any](c Codec[T], data []byte) error {
+	var v T
+	rejected, err := catchPanic(c, func() error { return json.Unmarshal(data, &v) })
+	if err != nil {
+		return fmt.Errorf("unmarshal %s: %w", clip(data), err)
+	}
+	if rejected != nil {
+		if rejected.Error() == "" {
+			return fmt.Errorf("unmarshal %s: rejected without a message", clip(data))
+		}
+		return nil
+	}
+
+	first, err := encodeJSON(v)
+	if err != nil {
+		return fmt.Errorf("marshal value from %s: %v", clip(data), err)
+	}
+	if c.WriteOnly {
+		return nil
+	}
+	var again T
+	if _, err := catch(func() ([]byte, error) { return nil, json.Unmarshal(first, &again) }); err != nil {
+		return fmt.Errorf("unmarshal own output %s (from %s): %v", clip(first), clip(data), err)
+	}
+	second, err := encodeJSON(again)
+	if err != nil {
+		return fmt.Errorf("marshal round-tripped value from %s: %v", clip(data), err)
+	}
+	if !bytes.Equal(first, second) {
+		return fmt.Errorf("output of %s is not stable: %s, then %s", clip(data), clip(first), clip(second))
+	}
+	return nil
+}
+
+// clip quotes data for a failure message, shortened to what a reader can
+// take in.
+func clip(data []byte) string {
+	const limit = 80
+	if len(data) > limit {
+		return fmt.Sprintf("%q... (%d bytes)", data[:limit], len(data))
+	}
+	return fmt.Sprintf("%q", data)
+}
+
+// catchPanic runs f and returns the error it rejected the input with, a
+// panic c allows included, or as err a panic c does not allow.
+func catchPanic[T any](c Codec[T], f func() error) (rejected error, err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		if c.AllowPanic != nil && c.AllowPanic(r) {
+			rejected = fmt.Errorf("%v", r)
+			return
+		}
+		err = fmt.Errorf("panic: %v\n%s", r, debug.Stack())
+	}()
+	return f(), nil
+}
+
+type fieldOf[T any] struct {
+	V T `json:"v"`
+}
+
+// decodeWith describes what unmarshal does with b: the value it decoded,
+// as encoding/json encodes it, or the error or panic and its type.
+func decodeWith[T any](unmarshal func([]byte, interface{}) error, b []byte) (outcome string) {
+	defer func() {
+		if r := recover(); r != nil {
+			outcome = fmt.Sprintf("panic %T: %v", r, r)
+		}
+	}()
+	var v T
+	if err := unmarshal(b, &v); err != nil {
+		return fmt.Sprintf("error %T: %v", err, err)
+	}
+	out, err := encodeJSON(v)
+	if err != nil {
+		return fmt.Sprintf("value that does not marshal: %v", err)
+	}
+	return "value " + string(out)
+}
+
+func encodeWith(marshal func(interface{}) ([]byte, error), v interface{}) (outcome string) {
+	defer func() {
+		if r := recover(); r != nil {
+			outcome = fmt.Sprintf("panic %T: %v", r, r)
+		}
+	}()
+	out, err := marshal(v)
+	if err != nil {
+		return fmt.Sprintf("error %T: %v", err, err)
+	}
+	return string(out)
+}
+
+func (c Codec[T]) equal(a T, b T) bool {
+	if c.Equal != nil {
+		return c.Equal(a, b)
+	}
+	if eq, ok := interface{}(a).(interface{ Equal(T) bool }); ok {
+		return eq.Equal(b)
+	}
+	ja, errA := encodeJSON(a)
+	jb, errB := encodeJSON(b)
+	return errA == nil && errB == nil && bytes.Equal(ja, jb)
+}
+
+func decodeJSON[T any](b []byte) (T, error) {
+	var v T
+	_, err := catch(func() ([]byte, error) {
+		return nil, json.Unmarshal(b, &v)
+	})
+	return v, err
+}
+
+func encodeJSON[T any](v T) ([]byte, error) {
+	return catch(func() ([]byte, error) {
+		return json.Marshal(v)
+	})
+}
+
+// catch turns a panic into an error, since rejecting input by panicking is
+// a supported convention.
+func catch(f func() ([]byte, error)) (b []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return f()
+}
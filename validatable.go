@@ -0,0 +1,50 @@
+package main
+
+import (
+	"reflect"
+)
+
+// Validatable is implemented by types with rules beyond their format. Bind,
+// JSONBinding, BindQuery, BindForm and BindURI call Validate on every
+// top-level field that decoded without error, and then on the request
+// struct itself once all fields are valid. A field's error is reported as a
+// FieldError with its message sent to the client; the struct's is returned
+// as is.
+//
+//	type MeetingStart struct{ DateTime }
+//
+//	func (m MeetingStart) Validate() error {
+//		if hour := m.Time().Hour(); hour < 9 || hour >= 17 {
+//			return errors.New("must be within business hours")
+//		}
+//		return nil
+//	}
+//
+// ctx.ShouldBind does not know about it.
+type Validatable interface {
+	Validate() error
+}
+
+// validateField runs Validate on a decoded field, if its type has one.
+func validateField(field reflect.Value) error {
+	target := field
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil
+		}
+	} else {
+		target = field.Addr()
+	}
+	if v, ok := target.Interface().(Validatable); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// validateRequest runs Validate on the request struct, if it has one.
+func validateRequest(obj interface{}) error {
+	if v, ok := obj.(Validatable); ok {
+		return v.Validate()
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var ErrCodeRuleFailed = RegisterErrorCode(ErrorCode{
+	Code: "value.rule_failed", Status: http.StatusBadRequest,
+	Message: "must satisfy {rule}", Params: []string{"rule"}, Example: "must satisfy max=10",
+})
+
+// RegisterWithValidator makes `binding` tags work on the struct-based types,
+// which validator otherwise sees as opaque structs: DateTime, Date and
+// NullDateTime are checked as their time.Time, so "required" rejects the
+// zero time and "gt"/"lt" compare with now, and Duration as its
+// time.Duration, so "gte=1m,lte=1h" works. Integer and slice types such as
+// StringInt64 and ArrayString need nothing.
+//
+// Field names in the errors come from the json, form, uri or csv tag, the names
+// the client sent. gin's validator is set up with:
+//
+//	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+//		RegisterWithValidator(v)
+//	}
+//
+// Like validator's own registration functions, call it before the first
+// request.
+func RegisterWithValidator(v *validator.Validate) {
+	v.RegisterCustomTypeFunc(validatorValue, DateTime{}, Date{}, NullDateTime{}, Duration{})
+	v.RegisterTagNameFunc(func(spec reflect.StructField) string {
+		for _, tag := range []string{"json", "form", "uri", "csv"} {
+			name, _, _ := strings.Cut(spec.Tag.Get(tag), ",")
+			if name == "-" {
+				return ""
+			}
+			if name != "" {
+				return name
+			}
+		}
+		return spec.Name
+	})
+}
+
+// validatorValue is the value validator checks in place of a custom type.
+// An invalid NullDateTime is nil, which "required" rejects and "omitempty"
+// skips.
+func validatorValue(field reflect.Value) interface{} {
+	switch value := field.Interface().(type) {
+	case DateTime:
+		return value.Time()
+	case Date:
+		return value.Time()
+	case NullDateTime:
+		if !value.Valid {
+			return nil
+		}
+		return value.DateTime.Time()
+	case Duration:
+		return value.Duration()
+	}
+	return nil
+}
+
+// TranslateValidationErrors turns validator's errors into FieldErrors, so
+// tag rules are answered like the custom types' own errors. "required" is
+// ErrCodeEmpty and any other rule ErrCodeRuleFailed. Other errors are
+// returned unchanged.
+func TranslateValidationErrors(err error) error {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return err
+	}
+	fe := make(FieldErrors, 0, len(validationErrs))
+	for _, e := range validationErrs {
+		// The namespace starts with the request struct's name.
+		_, field, _ := strings.Cut(e.Namespace(), ".")
+		if e.Tag() == "required" {
+			fe = append(fe, newFieldError(field, ErrCodeEmpty.Err(nil)))
+			continue
+		}
+		rule := e.Tag()
+		if e.Param() != "" {
+			rule += "=" + e.Param()
+		}
+		fe = append(fe, newFieldError(field, ErrCodeRuleFailed.Err(map[string]string{"rule": rule})))
+	}
+	return fe
+}
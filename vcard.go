@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// vcardStructured lists the properties whose value is a list of components
+// separated by `;` (RFC 6350 section 6.2), supplied as a []string field.
+var vcardStructured = map[string]bool{
+	"N":   true,
+	"ADR": true,
+	"ORG": true,
+}
+
+// MarshalVCard renders each contact as a vCard 4.0 entry. Contacts are
+// structs (or pointers to structs) whose fields carry a `vcard` tag naming the
+// property, e.g.
+//
+//	type Contact struct {
+//		Name    string   `vcard:"fn"`
+//		Parts   []string `vcard:"n"`   // family;given;additional;prefix;suffix
+//		Email   []string `vcard:"email"`
+//		Phone   string   `vcard:"tel"`
+//		Address []string `vcard:"adr"` // po box;ext;street;city;region;code;country
+//	}
+//
+// Repeatable properties such as EMAIL and TEL may be []string, emitting one
+// line per value. FN is mandatory in vCard 4.0.
+func MarshalVCard(contacts ...interface{}) ([]byte, error) {
+	buf := getBuffer()
+	for _, contact := range contacts {
+		if err := writeVCard(buf, contact); err != nil {
+			putBuffer(buf)
+			return nil, err
+		}
+	}
+	return bufferBytes(buf), nil
+}
+
+func writeVCard(buf *bytes.Buffer, contact interface{}) error {
+	v := reflect.ValueOf(contact)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return errors.New("vcard: nil contact")
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("vcard: contact must be a struct, got %s", v.Kind())
+	}
+
+	var lines []string
+	hasFN := false
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		name := field.Tag.Get("vcard")
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+		name = strings.ToUpper(name)
+
+		values := vcardValues(v.Field(i), name)
+		if name == "FN" && len(values) > 0 {
+			hasFN = true
+		}
+		for _, value := range values {
+			lines = append(lines, name+":"+value)
+		}
+	}
+
+	if !hasFN {
+		return errors.New("vcard: contact has no fn field")
+	}
+
+	writeContentLine(buf, "BEGIN:VCARD")
+	writeContentLine(buf, "VERSION:4.0")
+	for _, line := range lines {
+		writeContentLine(buf, line)
+	}
+	writeContentLine(buf, "END:VCARD")
+	return nil
+}
+
+// vcardValues returns one escaped value per line to emit, leaving out empty
+// values so optional properties are skipped.
+func vcardValues(field reflect.Value, name string) []string {
+	var parts []string
+	switch v := field.Interface().(type) {
+	case []string:
+		parts = v
+	case ArrayString:
+		parts = v
+	case fmt.Stringer:
+		parts = []string{v.String()}
+	case string:
+		parts = []string{v}
+	default:
+		parts = []string{fmt.Sprint(v)}
+	}
+
+	if vcardStructured[name] {
+		escaped := make([]string, len(parts))
+		empty := true
+		for i, part := range parts {
+			escaped[i] = textEscaper.Replace(part)
+			if part != "" {
+				empty = false
+			}
+		}
+		if empty {
+			return nil
+		}
+		return []string{strings.Join(escaped, ";")}
+	}
+
+	values := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part != "" {
+			values = append(values, textEscaper.Replace(part))
+		}
+	}
+	return values
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrCodeWeekdayNotString = RegisterErrorCode(ErrorCode{
+		Code: "weekday.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeWeekdayEmpty = RegisterErrorCode(ErrorCode{
+		Code: "weekday.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeWeekdayInvalidValue = RegisterErrorCode(ErrorCode{
+		Code: "weekday.invalid_value", Status: http.StatusBadRequest,
+		Message: "must be one of {values}", Params: []string{"values", "value"}, Example: "must be one of monday, tuesday, wednesday, thursday, friday, saturday, sunday",
+		Kind: ErrInvalidFormat,
+	})
+	ErrCodeMonthNotString = RegisterErrorCode(ErrorCode{
+		Code: "month.not_string", Status: http.StatusBadRequest,
+		Message: "must be a valid string", Example: "must be a valid string",
+		Kind: ErrNotAString,
+	})
+	ErrCodeMonthEmpty = RegisterErrorCode(ErrorCode{
+		Code: "month.empty", Status: http.StatusBadRequest,
+		Message: "must not be empty", Example: "must not be empty",
+		Kind: ErrEmptyValue,
+	})
+	ErrCodeMonthInvalidValue = RegisterErrorCode(ErrorCode{
+		Code: "month.invalid_value", Status: http.StatusBadRequest,
+		Message: "must be one of {values}", Params: []string{"values", "value"}, Example: "must be one of january, february, march, april, may, june, july, august, september, october, november, december",
+		Kind: ErrInvalidFormat,
+	})
+)
+
+// Lookup tables for ParseWeekday and ParseMonth, keyed by lowercase full
+// name, three-letter abbreviation and number.
+var (
+	weekdayNames = map[string]time.Weekday{}
+	monthNames   = map[string]time.Month{}
+
+	weekdayValues string
+	monthValues   string
+)
+
+func init() {
+	names := make([]string, 0, 7)
+	// ISO 8601 order and numbering: Monday is 1, Sunday is 7.
+	for i := 1; i <= 7; i++ {
+		day := time.Weekday(i % 7)
+		name := strings.ToLower(day.String())
+		weekdayNames[name] = day
+		weekdayNames[name[:3]] = day
+		weekdayNames[strconv.Itoa(i)] = day
+		names = append(names, name)
+	}
+	weekdayValues = strings.Join(names, ", ")
+
+	names = names[:0]
+	for month := time.January; month <= time.December; month++ {
+		name := strings.ToLower(month.String())
+		monthNames[name] = month
+		monthNames[name[:3]] = month
+		monthNames[strconv.Itoa(int(month))] = month
+		names = append(names, name)
+	}
+	monthValues = strings.Join(names, ", ")
+}
+
+// Weekday accepts "monday", "Mon" or the ISO 8601 number "1" (Sunday is
+// "7"), case-insensitively, and marshals as the lowercase full name.
+type Weekday time.Weekday
+
+func ParseWeekday(s string) (Weekday, error) {
+	day, ok := weekdayNames[strings.ToLower(strings.TrimSpace(s))]
+	if !ok {
+		return 0, ErrCodeWeekdayInvalidValue.Err(map[string]string{"values": weekdayValues, "value": s})
+	}
+	return Weekday(day), nil
+}
+
+func (w Weekday) Weekday() time.Weekday {
+	return time.Weekday(w)
+}
+
+func (w Weekday) String() string {
+	return strings.ToLower(time.Weekday(w).String())
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (w Weekday) MarshalJSON() ([]byte, error) {
+	return json.Marshal(w.String())
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+
+	A bare JSON number is read as the ISO 8601 day number.
+*/
+func (w *Weekday) UnmarshalJSON(b []byte) error {
+	s, err := calendarEnumInput(b, ErrCodeWeekdayNotString, ErrCodeWeekdayEmpty)
+	if err != nil {
+		return err
+	}
+	parsed, err := ParseWeekday(s)
+	if err != nil {
+		return err
+	}
+
+	*w = parsed
+
+	return nil
+}
+
+// Month accepts "january", "Jan" or "1", case-insensitively, and marshals
+// as the lowercase full name.
+type Month time.Month
+
+func ParseMonth(s string) (Month, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	// "01" through "09" as well as "1" through "9".
+	if len(s) == 2 && s[0] == '0' {
+		s = s[1:]
+	}
+	month, ok := monthNames[s]
+	if !ok {
+		return 0, ErrCodeMonthInvalidValue.Err(map[string]string{"values": monthValues, "value": s})
+	}
+	return Month(month), nil
+}
+
+func (m Month) Month() time.Month {
+	return time.Month(m)
+}
+
+// String returns "" for the zero Month, which is not a month.
+func (m Month) String() string {
+	if m < Month(time.January) || m > Month(time.December) {
+		return ""
+	}
+	return strings.ToLower(time.Month(m).String())
+}
+
+/*
+	This part implements `json.Marshaler`
+	type Marshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+*/
+func (m Month) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+/*
+	This part implements `json.Unmarshaler`
+	type Unmarshaler interface {
+		UnmarshalJSON([]byte) error
+	}
+
+	A bare JSON number is read as the month number.
+*/
+func (m *Month) UnmarshalJSON(b []byte) error {
+	s, err := calendarEnumInput(b, ErrCodeMonthNotString, ErrCodeMonthEmpty)
+	if err != nil {
+		return err
+	}
+	parsed, err := ParseMonth(s)
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+
+	return nil
+}
+
+// calendarEnumInput returns the string or bare number that b holds, or the
+// error of the caller's notString or empty code.
+func calendarEnumInput(b []byte, notString ErrorCode, empty ErrorCode) (string, error) {
+	b = bytes.TrimSpace(b)
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return "", notString.Err(nil)
+		}
+		if s == "" {
+			return "", empty.Err(nil)
+		}
+		return s, nil
+	}
+	return string(b), nil
+}
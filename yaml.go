@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Duration, ByteSize, DateTime and ArrayString implement yaml.Marshaler and
+// the node-based yaml.Unmarshaler of gopkg.in/yaml.v3, so they can be used in
+// service config structs:
+//
+//	type ServiceConfig struct {
+//		Timeout   Duration    `yaml:"timeout"`
+//		MaxUpload ByteSize    `yaml:"max_upload"`
+//		Since     DateTime    `yaml:"since"`
+//		Regions   ArrayString `yaml:"regions"`
+//	}
+//
+// Values are written as in JSON and validated with the same messages. An
+// ArrayString also accepts a YAML sequence. A Password is written as null,
+// as in JSON.
+
+// unmarshalYAMLAsJSON decodes a YAML scalar, quoted or not, as the JSON
+// string it would be in a request body.
+func unmarshalYAMLAsJSON(target json.Unmarshaler, value *yaml.Node) error {
+	if value.Kind == yaml.AliasNode {
+		value = value.Alias
+	}
+	if value.Kind != yaml.ScalarNode {
+		return ErrCodeNotString.Err(nil)
+	}
+	return unmarshalTextAsJSON(target, []byte(value.Value))
+}
+
+// unmarshalYAMLStrict decodes b into v, rejecting keys v has no field for.
+// An empty document leaves v as it is.
+func unmarshalYAMLStrict(b []byte, v interface{}) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(b))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+/*
+	This part implements `yaml.Marshaler`
+	type Marshaler interface {
+		MarshalYAML() (interface{}, error)
+	}
+*/
+func (p Password) MarshalYAML() (interface{}, error) {
+	return nil, nil
+}
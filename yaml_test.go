@@ -0,0 +1,81 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestYAMLDecode(t *testing.T) {
+	var c ServiceConfig
+	err := yaml.Unmarshal([]byte("timeout: 90s\nmax_upload: 1048576\nsince: 2020-01-01T02:02:05+07:00\nregions: [id, sg]\n"), &c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join([]string{c.Timeout.String(), c.MaxUpload.String(), c.Since.String(), strings.Join(c.Regions.List(), "|")}, " ")
+	if want := "1m30s 1MiB 2020-01-01T02:02:05+07:00 id|sg"; got != want {
+		t.Errorf("decoded %s, want %s", got, want)
+	}
+
+	// An anchor is decoded through its alias like any scalar.
+	if err := yaml.Unmarshal([]byte("timeout: &t 2h\nmax_upload: 1KB\nsince: 2020-01-01T00:00:00Z\nregions: *t\n"), &c); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(c.Regions.List(), "|"); got != "2h" {
+		t.Errorf("regions through an alias %s, want 2h", got)
+	}
+}
+
+func TestYAMLDecodeErrors(t *testing.T) {
+	for _, tc := range []struct {
+		document string
+		message  string
+	}{
+		{"timeout: soon", "format must be a duration like 1h30m or 90s"},
+		{"timeout: [1, 2]", "must be a valid string"},
+		{"max_upload: lots", "format must be a number followed by a unit like MB or GiB"},
+		{"since: 2020-01-01", "format must be YYYY-MM-DDTHH:mm:ssZ"},
+		{"regions: []", "must not be empty"},
+		{"regions: [{a: b}]", "must be a valid string"},
+	} {
+		var c ServiceConfig
+		if err := yaml.Unmarshal([]byte(tc.document), &c); err == nil || err.Error() != tc.message {
+			t.Errorf("%s: error %v, want %q", tc.document, err, tc.message)
+		}
+	}
+}
+
+func TestLoadConfigYAML(t *testing.T) {
+	defer func(separator string) { ArrayStringSeparator = separator }(ArrayStringSeparator)
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "types.yaml")
+	if err := os.WriteFile(path, []byte("array_separator: \";\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadConfig(path); err != nil {
+		t.Fatal(err)
+	}
+	if ArrayStringSeparator != ";" {
+		t.Errorf("ArrayStringSeparator %q, want ;", ArrayStringSeparator)
+	}
+
+	empty := filepath.Join(dir, "empty.yaml")
+	if err := os.WriteFile(empty, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadConfig(empty); err != nil {
+		t.Errorf("empty file: %v", err)
+	}
+
+	unknown := filepath.Join(dir, "unknown.yaml")
+	if err := os.WriteFile(unknown, []byte("array_seperator: \";\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadConfig(unknown); err == nil || !strings.Contains(err.Error(), "array_seperator") {
+		t.Errorf("unknown key: error %v, want it named", err)
+	}
+}